@@ -1,14 +1,21 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os/signal"
+	"syscall"
 
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 	"github.com/gadhittana01/cosmos-validation-tracking/handler"
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/cors"
 	openApiMiddleware "github.com/go-openapi/runtime/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
 )
 
 type App interface {
@@ -16,32 +23,71 @@ type App interface {
 }
 
 type AppImpl struct {
-	route                 *chi.Mux
-	config                *utils.BaseConfig
-	validatorHandler      handler.ValidatorHandler
-	validatorScheduler    handler.SchedulerHandler
-	logger                utils.LoggerSvc
-	recoveryMiddlewareSvc utils.RecoveryMiddlewareSvc
+	route                  *chi.Mux
+	config                 *utils.BaseConfig
+	validatorHandler       handler.ValidatorHandler
+	delegatorHandler       handler.DelegatorHandler
+	validatorScheduler     handler.SchedulerHandler
+	apiKeyHandler          handler.APIKeyHandler
+	healthHandler          handler.HealthHandler
+	logger                 utils.LoggerSvc
+	recoveryMiddlewareSvc  utils.RecoveryMiddlewareSvc
+	authMiddlewareSvc      utils.AuthMiddlewareSvc
+	rateLimitMiddlewareSvc utils.RateLimitMiddlewareSvc
+	metricsMiddlewareSvc   utils.MetricsMiddlewareSvc
+	tracingMiddlewareSvc   utils.TracingMiddlewareSvc
+	requestIDMiddlewareSvc utils.RequestIDMiddlewareSvc
+	tracer                 utils.TracerSvc
+	jobs                   utils.JobManager
+	db                     utils.PGXPool
+	redisClient            utils.RedisClient
 }
 
 func NewApp(route *chi.Mux,
 	config *utils.BaseConfig,
 	validatorHandler handler.ValidatorHandler,
+	delegatorHandler handler.DelegatorHandler,
 	validatorScheduler handler.SchedulerHandler,
+	apiKeyHandler handler.APIKeyHandler,
+	healthHandler handler.HealthHandler,
 	logger utils.LoggerSvc,
 	recoveryMiddlewareSvc utils.RecoveryMiddlewareSvc,
+	authMiddlewareSvc utils.AuthMiddlewareSvc,
+	rateLimitMiddlewareSvc utils.RateLimitMiddlewareSvc,
+	metricsMiddlewareSvc utils.MetricsMiddlewareSvc,
+	tracingMiddlewareSvc utils.TracingMiddlewareSvc,
+	requestIDMiddlewareSvc utils.RequestIDMiddlewareSvc,
+	tracer utils.TracerSvc,
+	jobs utils.JobManager,
+	db utils.PGXPool,
+	redisClient utils.RedisClient,
 ) App {
 	return &AppImpl{
-		route:                 route,
-		config:                config,
-		validatorHandler:      validatorHandler,
-		validatorScheduler:    validatorScheduler,
-		logger:                logger,
-		recoveryMiddlewareSvc: recoveryMiddlewareSvc,
+		route:                  route,
+		config:                 config,
+		validatorHandler:       validatorHandler,
+		delegatorHandler:       delegatorHandler,
+		validatorScheduler:     validatorScheduler,
+		apiKeyHandler:          apiKeyHandler,
+		healthHandler:          healthHandler,
+		logger:                 logger,
+		recoveryMiddlewareSvc:  recoveryMiddlewareSvc,
+		authMiddlewareSvc:      authMiddlewareSvc,
+		rateLimitMiddlewareSvc: rateLimitMiddlewareSvc,
+		metricsMiddlewareSvc:   metricsMiddlewareSvc,
+		tracingMiddlewareSvc:   tracingMiddlewareSvc,
+		requestIDMiddlewareSvc: requestIDMiddlewareSvc,
+		tracer:                 tracer,
+		jobs:                   jobs,
+		db:                     db,
+		redisClient:            redisClient,
 	}
 }
 
 func (s *AppImpl) Start() {
+	s.route.Use(s.requestIDMiddlewareSvc.RequestID)
+	s.route.Use(s.tracingMiddlewareSvc.Tracing)
+	s.route.Use(s.metricsMiddlewareSvc.Metrics)
 	s.route.Use(s.recoveryMiddlewareSvc.Recovery)
 	s.route.Use(cors.Handler(cors.Options{
 		AllowedOrigins: []string{"*"},
@@ -62,17 +108,91 @@ func (s *AppImpl) Start() {
 		w.Write([]byte("OK"))
 	})
 
-	s.validatorHandler.SetupValidatorRoutes(s.route)
-	s.validatorScheduler.SetupSchedulerRoutes(s.route)
+	s.healthHandler.SetupHealthRoutes(s.route)
+
+	s.route.Group(func(route chi.Router) {
+		if s.config.AuthReadRequired {
+			s.protect(route, constant.APIKeyScopeRead, constant.RateLimitGroupRead, s.config.RateLimitRead)
+		} else {
+			route.Use(s.rateLimitMiddlewareSvc.RateLimit(constant.RateLimitGroupRead, s.config.RateLimitRead, s.config.RateLimitWindow))
+		}
+		s.validatorHandler.SetupValidatorRoutes(route)
+		s.delegatorHandler.SetupDelegatorRoutes(route)
+	})
+
+	s.route.Group(func(route chi.Router) {
+		s.protect(route, constant.APIKeyScopeTriggerJobs, constant.RateLimitGroupTriggerJobs, s.config.RateLimitTriggerJobs)
+		s.validatorScheduler.SetupSchedulerRoutes(route)
+	})
+
+	s.route.Group(func(route chi.Router) {
+		s.protect(route, constant.APIKeyScopeAdmin, constant.RateLimitGroupAdmin, s.config.RateLimitAdmin)
+		s.apiKeyHandler.SetupAPIKeyRoutes(route)
+		route.Handle("/metrics", promhttp.Handler())
+	})
 
 	s.route.NotFound(func(w http.ResponseWriter, r *http.Request) {
-		utils.GenerateErrorResp[any](w, nil, 404)
+		utils.GenerateProblemResp(w, r, s.logger, utils.NewAppError(constant.ErrorCodeNotFound, "route not found", http.StatusNotFound))
 	})
 
-	s.logger.Info(fmt.Sprintf("server started on port %d", s.config.ServerPort))
-	port := fmt.Sprintf(":%d", s.config.ServerPort)
-	err := http.ListenAndServe(port, s.route)
-	if err != nil {
-		panic(err)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", s.config.ServerPort),
+		Handler: s.route,
 	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		s.logger.Info(fmt.Sprintf("server started on port %d", s.config.ServerPort))
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	case <-ctx.Done():
+		stop()
+		s.shutdown(server)
+	}
+}
+
+// protect limits the requests of a route group per IP before authenticating them, so a client guessing keys is throttled
+// as well, then requires scope and limits the authenticated requests per API key.
+func (s *AppImpl) protect(route chi.Router, scope string, group string, limit int) {
+	route.Use(s.rateLimitMiddlewareSvc.RateLimit(constant.RateLimitGroupAuth, s.config.RateLimitAuth, s.config.RateLimitWindow))
+	route.Use(s.authMiddlewareSvc.RequireScope(scope))
+	route.Use(s.rateLimitMiddlewareSvc.RateLimit(group, limit, s.config.RateLimitWindow))
+}
+
+// shutdown drains in-flight requests first so no new job gets triggered, then waits for the running jobs
+// and closes the DB pool and Redis client they use, and flushes the remaining spans, all within the shutdown timeout.
+func (s *AppImpl) shutdown(server *http.Server) {
+	s.logger.Info("Shutting down server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		s.logger.Error("Error shutting down server", zap.Error(err))
+	}
+
+	if err := s.jobs.Shutdown(ctx); err != nil {
+		s.logger.Error("Error waiting for running jobs", zap.Error(err))
+	}
+
+	s.db.Close()
+
+	if err := s.redisClient.Close(); err != nil {
+		s.logger.Error("Error closing redis client", zap.Error(err))
+	}
+
+	if err := s.tracer.Shutdown(ctx); err != nil {
+		s.logger.Error("Error flushing traces", zap.Error(err))
+	}
+
+	s.logger.Info("Server stopped")
 }
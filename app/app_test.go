@@ -0,0 +1,58 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalidAPIKey = utils.NewAppError(constant.ErrorCodeUnauthorized, "unauthorized", http.StatusUnauthorized)
+
+func TestProtect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	config.RateLimitAuth = 3
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockutl.LoggerMock(mockLogger)
+	mockVerifier := mockutl.NewMockAPIKeyVerifier(ctrl)
+	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
+	app := &AppImpl{
+		config:                 config,
+		authMiddlewareSvc:      utils.NewAuthMiddlewareSvc(mockVerifier, mockLogger),
+		rateLimitMiddlewareSvc: utils.NewRateLimitMiddlewareSvc(config, cacheSvc, mockLogger),
+	}
+
+	route := chi.NewRouter()
+	route.Group(func(route chi.Router) {
+		app.protect(route, constant.APIKeyScopeAdmin, constant.RateLimitGroupAdmin, config.RateLimitAdmin)
+		route.Get("/api/v1/api-keys", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	t.Run("unauthenticated requests are rate limited by ip", func(t *testing.T) {
+		mockVerifier.EXPECT().VerifyAPIKey(gomock.Any(), "guessed-key").Return(utils.APIKeyIdentity{}, errInvalidAPIKey).Times(config.RateLimitAuth)
+
+		var codes []int
+		for i := 0; i <= config.RateLimitAuth; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/api-keys", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			req.Header.Set("Authorization", "guessed-key")
+
+			rec := httptest.NewRecorder()
+			route.ServeHTTP(rec, req)
+			codes = append(codes, rec.Code)
+		}
+
+		assert.Equal(t, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}, codes)
+	})
+}
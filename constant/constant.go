@@ -3,7 +3,7 @@ package constant
 const (
 	// Format of date & time
 	DateFormat = "2006-01-02"
-	TimeFormat = "2006-01-02 15:04:05"
+	TimeFormat = "2006-01-02T15:04:05Z07:00"
 )
 
 const (
@@ -22,4 +22,150 @@ const (
 	ValidatorHourlySnapshotCacheKey   = "validator_hourly_snapshot"
 	ValidatorDailySnapshotCacheKey    = "validator_daily_snapshot"
 	ValidatorDelegatorHistoryCacheKey = "validator_delegator_history"
+	DelegatorSummaryCacheKey          = "delegator_summary"
+	DelegatorChangeHistoryCacheKey    = "delegator_change_history"
+	ValidatorCohortCacheKey           = "validator_cohort"
+	ValidatorConcentrationCacheKey    = "validator_concentration"
+	ValidatorDistributionCacheKey     = "validator_distribution"
+	ValidatorDelegatorEventCacheKey   = "validator_delegator_event"
+	ValidatorCompareCacheKey          = "validator_compare"
+	ValidatorDelegationAsOfCacheKey   = "validator_delegation_as_of"
+	RateLimitCacheKey                 = "rate_limit"
+	CacheLockKey                      = "cache_lock"
+)
+
+const (
+	// CohortPeriod is the length of the periods delegators are grouped and followed by
+	CohortPeriodWeek     = "week"
+	CohortPeriodMonth    = "month"
+	DefaultCohortPeriods = 12
+)
+
+const (
+	// DistributionBuckets are the default and maximum bucket boundaries of the delegator distribution, in ATOM
+	DefaultDistributionBuckets = "1,10,100,1000,10000,100000"
+	MaxDistributionBuckets     = 20
+	UatomPerAtom               = 1000000
+)
+
+const (
+	// CompareDays are the default and maximum number of days, and MaxCompareValidators the maximum number of validators, of a comparison
+	DefaultCompareDays   = 30
+	MaxCompareDays       = 366
+	MaxCompareValidators = 10
+)
+
+const (
+	// DelegationSortBy is the order delegations as of an instant are listed in
+	DelegationSortByAmount     = "amount"
+	DelegationSortByAmountDesc = "-amount"
+	DelegationSortByChange     = "change"
+	DelegationSortByChangeDesc = "-change"
+)
+
+const (
+	// SnapshotStorageMode decides whether every balance or only the changed ones are stored
+	SnapshotStorageModeFull = "full"
+	SnapshotStorageModeCDC  = "cdc"
+)
+
+const (
+	// JobName identifies the scheduler job a run belongs to
+	HourlyCollectJobName   = "hourly_collect"
+	DailyCollectJobName    = "daily_collect"
+	RetentionJobName       = "retention"
+	RetentionDryRunJobName = "retention_dry_run"
+	ParquetExportJobName   = "parquet_export"
+	DelegatorEventsJobName = "delegator_events"
+)
+
+const (
+	// JobLockKey prefixes the locks that keep a scheduler job from running on several replicas at once
+	JobLockKey = "job_lock"
+)
+
+const (
+	// DelegatorEventType is how the presence of a delegator changed on a day
+	DelegatorEventTypeNew      = "new"
+	DelegatorEventTypeChurned  = "churned"
+	DelegatorEventTypeReturned = "returned"
+)
+
+const (
+	// APIKeyScope is what an API key is allowed to do, admin implying every other scope
+	APIKeyScopeRead        = "read"
+	APIKeyScopeTriggerJobs = "trigger-jobs"
+	APIKeyScopeAdmin       = "admin"
+	APIKeyPrefix           = "vtk_"
+	APIKeyBytes            = 32
+	APIKeyDisplayLength    = 12
+	// APIKeyAdminID identifies the configured admin key, which has no stored ID
+	APIKeyAdminID = "admin"
+)
+
+const (
+	// RateLimitGroup names the route groups that are rate limited separately
+	RateLimitGroupRead        = "read"
+	RateLimitGroupTriggerJobs = "trigger_jobs"
+	RateLimitGroupAdmin       = "admin"
+	// RateLimitGroupAuth limits the requests reaching authentication per IP, so guessed keys are throttled too
+	RateLimitGroupAuth = "auth"
+)
+
+const (
+	// RequestIDHeader carries the ID that ties the log lines of a request together
+	RequestIDHeader    = "X-Request-ID"
+	RequestIDMaxLength = 128
+)
+
+const (
+	// HealthStatus is the state of the service or of one of the dependencies it checks
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+const (
+	// HealthCheck names the dependencies checked for readiness
+	HealthCheckPostgres         = "postgres"
+	HealthCheckRedis            = "redis"
+	HealthCheckMigrations       = "migrations"
+	HealthCheckHourlyCollection = "hourly_collection"
+)
+
+const (
+	// ExportStorage is where the parquet export job writes its files
+	ExportStorageLocal = "local"
+	ExportStorageS3    = "s3"
+)
+
+const (
+	// ExportDataset is the table a parquet export belongs to
+	ExportDatasetDelegationSnapshots = "delegation_snapshots"
+	ExportDatasetDailyAggregates     = "daily_aggregates"
+)
+
+const (
+	// ExportFormat is the format read endpoints stream their full result set in
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+const (
+	// ErrorCode is the machine readable code of a problem+json error response
+	ErrorCodeInvalidRequest   = "invalid_request"
+	ErrorCodeValidationFailed = "validation_failed"
+	ErrorCodeInvalidTimezone  = "invalid_timezone"
+	ErrorCodeInvalidDateRange = "invalid_date_range"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeRateLimited      = "rate_limited"
+	ErrorCodeQueryFailed      = "query_failed"
+	ErrorCodeInternal         = "internal_error"
+)
+
+const (
+	// ProblemContentType is the media type of RFC 7807 error responses
+	ProblemContentType = "application/problem+json"
+	ProblemTypeDefault = "about:blank"
 )
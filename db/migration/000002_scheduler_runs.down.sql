@@ -0,0 +1 @@
+DROP TABLE IF EXISTS scheduler_runs;
\ No newline at end of file
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS scheduler_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_name TEXT NOT NULL,
+    validator_address TEXT NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    is_checkpoint BOOLEAN NOT NULL DEFAULT false,
+    rows_affected BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Older runs stamped every row separately, so the rows of a run are aligned on its last timestamp
+-- (grouped per minute) for the run lookups, which match a run by its exact timestamp, to find all of them.
+UPDATE delegation_snapshots d
+    SET timestamp = r.timestamp
+    FROM (
+        SELECT validator_address, date_trunc('minute', timestamp) AS minute, MAX(timestamp) AS timestamp
+            FROM delegation_snapshots
+            GROUP BY validator_address, date_trunc('minute', timestamp)
+    ) r
+    WHERE d.validator_address = r.validator_address
+        AND date_trunc('minute', d.timestamp) = r.minute
+        AND d.timestamp <> r.timestamp;
+
+-- Every run stored so far wrote a full row per delegator, so each one is a checkpoint.
+INSERT INTO scheduler_runs (job_name, validator_address, timestamp, is_checkpoint, rows_affected)
+SELECT 'hourly_collect', validator_address, MAX(timestamp), true, COUNT(*)
+    FROM delegation_snapshots
+    GROUP BY validator_address, date_trunc('minute', timestamp);
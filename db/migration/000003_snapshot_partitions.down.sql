@@ -0,0 +1,24 @@
+DROP INDEX IF EXISTS scheduler_runs_validator_job_timestamp_idx;
+DROP INDEX IF EXISTS daily_aggregates_validator_delegator_date_idx;
+DROP INDEX IF EXISTS daily_aggregates_validator_date_idx;
+
+ALTER TABLE delegation_snapshots RENAME TO delegation_snapshots_partitioned;
+
+CREATE TABLE delegation_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    validator_address TEXT NOT NULL,
+    delegator_address TEXT NOT NULL,
+    amount_uatom BIGINT NOT NULL,
+    change_uatom BIGINT NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO delegation_snapshots (id, validator_address, delegator_address, amount_uatom, change_uatom, timestamp, created_at, updated_at)
+SELECT id, validator_address, delegator_address, amount_uatom, change_uatom, timestamp, created_at, updated_at
+    FROM delegation_snapshots_partitioned;
+
+DROP TABLE delegation_snapshots_partitioned;
+
+DROP FUNCTION IF EXISTS create_delegation_snapshot_partitions(DATE, INT);
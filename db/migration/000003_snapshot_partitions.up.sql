@@ -0,0 +1,71 @@
+CREATE OR REPLACE FUNCTION create_delegation_snapshot_partitions(from_month DATE, month_count INT)
+RETURNS INT AS $$
+DECLARE
+    partition_start DATE;
+    partition_name TEXT;
+    created_count INT := 0;
+BEGIN
+    FOR i IN 0..month_count - 1 LOOP
+        partition_start := (date_trunc('month', from_month) + make_interval(months => i))::DATE;
+        partition_name := format('delegation_snapshots_%s', to_char(partition_start, 'YYYY_MM'));
+
+        IF to_regclass(partition_name) IS NULL THEN
+            EXECUTE format(
+                'CREATE TABLE %I PARTITION OF delegation_snapshots FOR VALUES FROM (%L) TO (%L)',
+                partition_name,
+                partition_start::TEXT || ' 00:00:00+00',
+                (partition_start + INTERVAL '1 month')::DATE::TEXT || ' 00:00:00+00'
+            );
+            created_count := created_count + 1;
+        END IF;
+    END LOOP;
+
+    RETURN created_count;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE delegation_snapshots RENAME TO delegation_snapshots_legacy;
+
+CREATE TABLE delegation_snapshots (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    validator_address TEXT NOT NULL,
+    delegator_address TEXT NOT NULL,
+    amount_uatom BIGINT NOT NULL,
+    change_uatom BIGINT NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id, timestamp),
+    CONSTRAINT delegation_snapshots_validator_delegator_timestamp_key UNIQUE (validator_address, delegator_address, timestamp)
+) PARTITION BY RANGE (timestamp);
+
+CREATE INDEX IF NOT EXISTS delegation_snapshots_validator_timestamp_idx
+    ON delegation_snapshots (validator_address, timestamp);
+
+-- Partitions for every month that already holds data, plus the next three.
+SELECT create_delegation_snapshot_partitions(
+    m.first_month,
+    (EXTRACT(YEAR FROM age(m.current_month, m.first_month)) * 12
+        + EXTRACT(MONTH FROM age(m.current_month, m.first_month)))::INT + 4
+)
+FROM (
+    SELECT date_trunc('month', COALESCE(MIN(timestamp AT TIME ZONE 'UTC'), CURRENT_DATE))::DATE AS first_month,
+           date_trunc('month', CURRENT_DATE)::DATE AS current_month
+        FROM delegation_snapshots_legacy
+) m;
+
+INSERT INTO delegation_snapshots (id, validator_address, delegator_address, amount_uatom, change_uatom, timestamp, created_at, updated_at)
+SELECT id, validator_address, delegator_address, amount_uatom, change_uatom, timestamp, created_at, updated_at
+    FROM delegation_snapshots_legacy
+    ON CONFLICT (validator_address, delegator_address, timestamp) DO NOTHING;
+
+DROP TABLE delegation_snapshots_legacy;
+
+CREATE INDEX IF NOT EXISTS daily_aggregates_validator_date_idx
+    ON daily_aggregates (validator_address, date);
+
+CREATE INDEX IF NOT EXISTS daily_aggregates_validator_delegator_date_idx
+    ON daily_aggregates (validator_address, delegator_address, date);
+
+CREATE INDEX IF NOT EXISTS scheduler_runs_validator_job_timestamp_idx
+    ON scheduler_runs (validator_address, job_name, timestamp);
@@ -0,0 +1 @@
+ALTER TABLE daily_aggregates DROP CONSTRAINT IF EXISTS daily_aggregates_validator_delegator_date_key;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Reruns of the daily job inserted the same day again, so only the latest row of a day is kept.
+DELETE FROM daily_aggregates a
+    USING daily_aggregates b
+    WHERE a.validator_address = b.validator_address
+        AND a.delegator_address = b.delegator_address
+        AND a.date = b.date
+        AND (a.created_at, a.id) < (b.created_at, b.id);
+
+ALTER TABLE daily_aggregates
+    ADD CONSTRAINT daily_aggregates_validator_delegator_date_key UNIQUE (validator_address, delegator_address, date);
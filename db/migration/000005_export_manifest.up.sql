@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS export_manifest (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dataset TEXT NOT NULL,
+    validator_address TEXT NOT NULL,
+    date DATE NOT NULL,
+    object_key TEXT NOT NULL,
+    rows_exported BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT export_manifest_dataset_validator_date_key UNIQUE (dataset, validator_address, date)
+);
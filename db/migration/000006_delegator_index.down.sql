@@ -0,0 +1 @@
+DROP INDEX IF EXISTS delegation_snapshots_delegator_validator_timestamp_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS delegation_snapshots_delegator_validator_timestamp_idx
+    ON delegation_snapshots (delegator_address, validator_address, timestamp);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS daily_concentration_metrics (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    validator_address TEXT NOT NULL,
+    date DATE NOT NULL,
+    delegator_count BIGINT NOT NULL,
+    total_amount BIGINT NOT NULL,
+    gini_coefficient DOUBLE PRECISION NOT NULL,
+    herfindahl_index DOUBLE PRECISION NOT NULL,
+    top_10_share DOUBLE PRECISION NOT NULL,
+    top_100_share DOUBLE PRECISION NOT NULL,
+    nakamoto_33 BIGINT NOT NULL,
+    nakamoto_50 BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT daily_concentration_metrics_validator_date_key UNIQUE (validator_address, date)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS delegator_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    validator_address TEXT NOT NULL,
+    delegator_address TEXT NOT NULL,
+    date DATE NOT NULL,
+    type TEXT NOT NULL,
+    amount_uatom BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT delegator_events_validator_delegator_date_type_key UNIQUE (validator_address, delegator_address, date, type)
+);
+
+CREATE INDEX IF NOT EXISTS delegator_events_validator_date_idx
+    ON delegator_events (validator_address, date);
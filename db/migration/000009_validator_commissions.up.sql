@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS validator_commissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    validator_address TEXT NOT NULL,
+    date DATE NOT NULL,
+    commission_rate DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT validator_commissions_validator_date_key UNIQUE (validator_address, date)
+);
@@ -0,0 +1,24 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (name, key_prefix, key_hash, scopes)
+    VALUES (@name, @key_prefix, @key_hash, @scopes::text[])
+    RETURNING id, name, key_prefix, scopes, last_used_at, revoked_at, created_at;
+
+-- name: GetAPIKeys :many
+SELECT id, name, key_prefix, scopes, last_used_at, revoked_at, created_at
+    FROM api_keys
+    ORDER BY created_at DESC;
+
+-- name: GetActiveAPIKeyByHash :one
+SELECT id, name, key_prefix, scopes, last_used_at, revoked_at, created_at
+    FROM api_keys
+    WHERE key_hash = @key_hash AND revoked_at IS NULL;
+
+-- name: UpdateAPIKeyLastUsed :exec
+UPDATE api_keys
+    SET last_used_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = @id;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+    SET revoked_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = @id AND revoked_at IS NULL;
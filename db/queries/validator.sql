@@ -59,11 +59,780 @@ VALUES ($1, $2, $3, $4, $5)
 RETURNING id;
 
 -- name: GetLatestDelegationSnapshot :many
-SELECT DISTINCT ON (delegator_address, validator_address)
-           validator_address, delegator_address, amount_uatom
+SELECT s.validator_address, s.delegator_address, s.amount_uatom
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address, d.validator_address)
+               d.validator_address, d.delegator_address, d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            ORDER BY d.delegator_address, d.validator_address, d.timestamp DESC
+    ) s
+    WHERE NOT @latest_run_only::boolean OR s.timestamp = (
+        SELECT MAX(r.timestamp) FROM scheduler_runs r
+            WHERE r.validator_address = s.validator_address AND r.job_name = @job_name
+    );
+
+-- name: GetLatestDelegationSnapshotByValidator :many
+SELECT DISTINCT ON (delegator_address)
+           delegator_address, amount_uatom
     FROM delegation_snapshots
-    ORDER BY delegator_address, validator_address, timestamp DESC;
+    WHERE validator_address = $1
+    ORDER BY delegator_address, timestamp DESC;
 
 -- name: CreateDailyAggregate :one
 INSERT INTO daily_aggregates (validator_address, delegator_address, date, total_amount)
-VALUES ($1, $2, $3, $4) RETURNING id;
\ No newline at end of file
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (validator_address, delegator_address, date) DO UPDATE SET
+    total_amount = EXCLUDED.total_amount,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id;
+
+-- name: CreateDelegationSnapshots :copyfrom
+INSERT INTO delegation_snapshots (
+    validator_address,
+    delegator_address,
+    amount_uatom,
+    change_uatom,
+    timestamp
+)
+VALUES ($1, $2, $3, $4, $5);
+
+
+-- name: CreateSchedulerRun :one
+INSERT INTO scheduler_runs (job_name, validator_address, timestamp, is_checkpoint, rows_affected)
+VALUES ($1, $2, $3, $4, $5) RETURNING id;
+
+-- name: GetLatestSchedulerRun :one
+SELECT timestamp
+    FROM scheduler_runs
+    WHERE job_name = $1
+    ORDER BY timestamp DESC LIMIT 1;
+
+-- name: GetExistsSchedulerRunWithRowsAffected :one
+SELECT EXISTS (
+    SELECT 1 FROM scheduler_runs
+        WHERE validator_address = $1 AND job_name = $2 AND rows_affected > 0
+);
+
+-- name: GetLatestCheckpointSchedulerRun :one
+SELECT timestamp
+    FROM scheduler_runs
+    WHERE validator_address = $1 AND job_name = $2 AND is_checkpoint
+    ORDER BY timestamp DESC LIMIT 1;
+
+-- name: GetReconstructedDelegationSnapshotByValidator :many
+SELECT s.delegator_address, s.amount_uatom, r.timestamp,
+       (CASE WHEN s.timestamp = r.timestamp THEN s.change_uatom ELSE 0 END)::bigint AS change_uatom
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom, d.change_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $2 AND s.amount_uatom <> 0
+    ORDER BY r.timestamp ASC, s.delegator_address ASC
+    LIMIT $3
+    OFFSET $4;
+
+-- name: GetCountReconstructedDelegationSnapshotByValidator :one
+SELECT COUNT(*)
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $2 AND s.amount_uatom <> 0;
+
+-- name: GetReconstructedDelegatorHistoryByValidator :many
+SELECT r.timestamp, s.amount_uatom,
+       (CASE WHEN s.timestamp = r.timestamp THEN s.change_uatom ELSE 0 END)::bigint AS change_uatom
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT d.amount_uatom, d.change_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.delegator_address = $2
+              AND d.timestamp <= r.timestamp
+            ORDER BY d.timestamp DESC LIMIT 1
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $3 AND s.amount_uatom <> 0
+    ORDER BY
+    CASE WHEN @sort_by::text = '-date' THEN r.timestamp END DESC,
+    CASE WHEN @sort_by::text = 'date' THEN r.timestamp END ASC
+    LIMIT $4
+    OFFSET $5;
+
+-- name: GetCountReconstructedDelegatorHistoryByValidator :one
+SELECT COUNT(*)
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.delegator_address = $2
+              AND d.timestamp <= r.timestamp
+            ORDER BY d.timestamp DESC LIMIT 1
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $3 AND s.amount_uatom <> 0;
+
+
+-- name: CreateDelegationSnapshotPartitions :one
+SELECT create_delegation_snapshot_partitions(@from_month::date, @month_count::int)::int AS created_count;
+
+
+-- name: GetValidatorAddressesBySchedulerRun :many
+SELECT DISTINCT validator_address
+    FROM scheduler_runs
+    WHERE job_name = $1
+    ORDER BY validator_address;
+
+-- name: GetLatestCheckpointSchedulerRunBefore :one
+SELECT timestamp
+    FROM scheduler_runs
+    WHERE validator_address = $1 AND job_name = $2 AND is_checkpoint AND timestamp <= @before::timestamptz
+    ORDER BY timestamp DESC LIMIT 1;
+
+-- name: CreateDownsampledDailyAggregates :execrows
+INSERT INTO daily_aggregates (validator_address, delegator_address, date, total_amount)
+SELECT r.validator_address, s.delegator_address, r.date, s.amount_uatom
+    FROM (
+        SELECT DISTINCT ON ((sr.timestamp AT TIME ZONE @day_timezone::text)::date)
+               sr.validator_address, sr.job_name, sr.timestamp, (sr.timestamp AT TIME ZONE @day_timezone::text)::date AS date
+            FROM scheduler_runs sr
+            WHERE sr.validator_address = @validator_address AND sr.job_name = @job_name AND sr.timestamp < @before::timestamptz
+            ORDER BY (sr.timestamp AT TIME ZONE @day_timezone::text)::date, sr.timestamp DESC
+    ) r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+ON CONFLICT (validator_address, delegator_address, date) DO NOTHING;
+
+-- name: GetCountDelegationSnapshotBefore :one
+SELECT COUNT(*)
+    FROM delegation_snapshots
+    WHERE validator_address = $1 AND timestamp < @before::timestamptz;
+
+-- name: DeleteDelegationSnapshotsBefore :execrows
+DELETE FROM delegation_snapshots
+    WHERE validator_address = $1 AND timestamp < @before::timestamptz;
+
+-- name: DeleteSchedulerRunsBefore :execrows
+DELETE FROM scheduler_runs
+    WHERE validator_address = $1 AND job_name = $2 AND timestamp < @before::timestamptz;
+
+-- name: GetDailyAggregateInTimezoneByValidator :many
+SELECT s.delegator_address, r.date, s.amount_uatom AS total_amount
+    FROM (
+        SELECT DISTINCT ON ((sr.timestamp AT TIME ZONE @day_timezone::text)::date)
+               sr.validator_address, sr.job_name, sr.timestamp, (sr.timestamp AT TIME ZONE @day_timezone::text)::date AS date
+            FROM scheduler_runs sr
+            WHERE sr.validator_address = @validator_address AND sr.job_name = @job_name
+            ORDER BY (sr.timestamp AT TIME ZONE @day_timezone::text)::date, sr.timestamp DESC
+    ) r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+    ORDER BY r.date ASC, s.delegator_address ASC
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: GetCountDailyAggregateInTimezoneByValidator :one
+SELECT COUNT(*)
+    FROM (
+        SELECT DISTINCT ON ((sr.timestamp AT TIME ZONE @day_timezone::text)::date)
+               sr.validator_address, sr.job_name, sr.timestamp
+            FROM scheduler_runs sr
+            WHERE sr.validator_address = @validator_address AND sr.job_name = @job_name
+            ORDER BY (sr.timestamp AT TIME ZONE @day_timezone::text)::date, sr.timestamp DESC
+    ) r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0;
+
+-- name: GetUnexportedSnapshotDates :many
+SELECT DISTINCT (r.timestamp AT TIME ZONE @day_timezone::text)::date AS date
+    FROM scheduler_runs r
+    WHERE r.validator_address = @validator_address
+      AND r.job_name = @job_name
+      AND r.timestamp < @before::timestamptz
+      AND NOT EXISTS (
+          SELECT 1 FROM export_manifest m
+              WHERE m.dataset = @dataset
+                AND m.validator_address = r.validator_address
+                AND m.date = (r.timestamp AT TIME ZONE @day_timezone::text)::date
+      )
+    ORDER BY date;
+
+-- name: GetUnexportedDailyAggregateDates :many
+SELECT DISTINCT a.date
+    FROM daily_aggregates a
+    WHERE a.validator_address = @validator_address
+      AND a.date < @before::date
+      AND NOT EXISTS (
+          SELECT 1 FROM export_manifest m
+              WHERE m.dataset = @dataset
+                AND m.validator_address = a.validator_address
+                AND m.date = a.date
+      )
+    ORDER BY a.date;
+
+-- name: GetDelegationSnapshotByValidatorAndTimeRange :many
+SELECT validator_address, delegator_address, amount_uatom, change_uatom, timestamp
+    FROM delegation_snapshots
+    WHERE validator_address = $1 AND timestamp >= @start_time::timestamptz AND timestamp < @end_time::timestamptz
+    ORDER BY timestamp ASC, delegator_address ASC;
+
+-- name: GetDailyAggregateByValidatorAndDate :many
+SELECT validator_address, delegator_address, date, total_amount
+    FROM daily_aggregates
+    WHERE validator_address = $1 AND date = $2
+    ORDER BY delegator_address ASC;
+
+-- name: CreateExportManifest :one
+INSERT INTO export_manifest (dataset, validator_address, date, object_key, rows_exported)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (dataset, validator_address, date)
+DO UPDATE SET object_key = EXCLUDED.object_key, rows_exported = EXCLUDED.rows_exported, updated_at = CURRENT_TIMESTAMP
+RETURNING id;
+
+-- name: GetCurrentDelegationByDelegator :many
+SELECT s.validator_address, s.amount_uatom, s.timestamp
+    FROM (
+        SELECT DISTINCT ON (d.validator_address)
+               d.validator_address, d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.delegator_address = @delegator_address
+            ORDER BY d.validator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+      AND (NOT @latest_run_only::boolean OR s.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = s.validator_address AND r.job_name = @job_name
+      ))
+    ORDER BY s.amount_uatom DESC, s.validator_address ASC;
+
+-- name: GetDelegatorChangeHistory :many
+WITH changes AS (
+    SELECT d.validator_address, d.timestamp, d.amount_uatom, d.change_uatom
+        FROM delegation_snapshots d
+        WHERE d.delegator_address = @delegator_address AND d.change_uatom <> 0
+    UNION ALL
+    -- In full storage a delegator who left is missing from the next run of the validator instead of
+    -- having a row of their own, so the exit is rebuilt from the gap.
+    SELECT d.validator_address, n.timestamp, 0::bigint AS amount_uatom, -d.amount_uatom AS change_uatom
+        FROM delegation_snapshots d
+        CROSS JOIN LATERAL (
+            SELECT r.timestamp FROM scheduler_runs r
+                WHERE r.validator_address = d.validator_address
+                  AND r.job_name = @job_name
+                  AND r.timestamp > d.timestamp
+                ORDER BY r.timestamp ASC
+                LIMIT 1
+        ) n
+        WHERE @synthesize_exits::boolean
+          AND d.delegator_address = @delegator_address
+          AND d.amount_uatom > 0
+          AND NOT EXISTS (
+              SELECT 1 FROM delegation_snapshots e
+                  WHERE e.validator_address = d.validator_address
+                    AND e.delegator_address = d.delegator_address
+                    AND e.timestamp = n.timestamp
+          )
+)
+SELECT validator_address, timestamp, amount_uatom, change_uatom
+    FROM changes
+    WHERE (sqlc.narg('start_time')::timestamptz IS NULL OR timestamp >= sqlc.narg('start_time'))
+      AND (sqlc.narg('end_time')::timestamptz IS NULL OR timestamp < sqlc.narg('end_time'))
+    ORDER BY
+    CASE WHEN @sort_by::text = '-date' THEN "timestamp" END DESC,
+    CASE WHEN @sort_by::text = 'date' THEN "timestamp" END ASC,
+    validator_address ASC
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: GetCountDelegatorChangeHistory :one
+WITH changes AS (
+    SELECT d.validator_address, d.timestamp, d.amount_uatom, d.change_uatom
+        FROM delegation_snapshots d
+        WHERE d.delegator_address = @delegator_address AND d.change_uatom <> 0
+    UNION ALL
+    -- In full storage a delegator who left is missing from the next run of the validator instead of
+    -- having a row of their own, so the exit is rebuilt from the gap.
+    SELECT d.validator_address, n.timestamp, 0::bigint AS amount_uatom, -d.amount_uatom AS change_uatom
+        FROM delegation_snapshots d
+        CROSS JOIN LATERAL (
+            SELECT r.timestamp FROM scheduler_runs r
+                WHERE r.validator_address = d.validator_address
+                  AND r.job_name = @job_name
+                  AND r.timestamp > d.timestamp
+                ORDER BY r.timestamp ASC
+                LIMIT 1
+        ) n
+        WHERE @synthesize_exits::boolean
+          AND d.delegator_address = @delegator_address
+          AND d.amount_uatom > 0
+          AND NOT EXISTS (
+              SELECT 1 FROM delegation_snapshots e
+                  WHERE e.validator_address = d.validator_address
+                    AND e.delegator_address = d.delegator_address
+                    AND e.timestamp = n.timestamp
+          )
+)
+SELECT COUNT(*)
+    FROM changes
+    WHERE (sqlc.narg('start_time')::timestamptz IS NULL OR timestamp >= sqlc.narg('start_time'))
+      AND (sqlc.narg('end_time')::timestamptz IS NULL OR timestamp < sqlc.narg('end_time'));
+
+-- name: GetDelegatorCohortRetentionByValidator :many
+WITH cohorts AS (
+    SELECT DISTINCT ON (d.delegator_address)
+           d.delegator_address,
+           d.amount_uatom AS initial_amount,
+           date_trunc(@period::text, d.timestamp AT TIME ZONE @day_timezone::text) AS cohort_start
+        FROM delegation_snapshots d
+        WHERE d.validator_address = @validator_address AND d.amount_uatom > 0
+        ORDER BY d.delegator_address, d.timestamp ASC
+),
+periods AS (
+    SELECT c.cohort_start, o.period_offset,
+           LEAST(
+               (c.cohort_start + (o.period_offset + 1) * ('1 ' || @period::text)::interval) AT TIME ZONE @day_timezone::text,
+               @as_of::timestamptz
+           ) AS period_end
+        FROM (SELECT DISTINCT cohort_start FROM cohorts) c
+        CROSS JOIN generate_series(0, @period_count::int) AS o(period_offset)
+        WHERE (c.cohort_start + o.period_offset * ('1 ' || @period::text)::interval) AT TIME ZONE @day_timezone::text <= @as_of::timestamptz
+)
+SELECT p.cohort_start::date AS cohort_start,
+       p.period_offset::int AS period_offset,
+       COUNT(*) AS cohort_size,
+       COUNT(*) FILTER (WHERE s.remaining_amount > 0) AS retained_count,
+       SUM(c.initial_amount)::bigint AS initial_amount,
+       SUM(s.remaining_amount)::bigint AS remaining_amount
+    FROM periods p
+    JOIN cohorts c ON c.cohort_start = p.cohort_start
+    CROSS JOIN LATERAL (
+        SELECT COALESCE((
+            SELECT CASE WHEN NOT @latest_run_only::boolean OR d.timestamp = (
+                       SELECT MAX(r.timestamp) FROM scheduler_runs r
+                           WHERE r.validator_address = @validator_address
+                             AND r.job_name = @job_name
+                             AND r.timestamp <= p.period_end
+                   ) THEN d.amount_uatom ELSE 0 END
+                FROM delegation_snapshots d
+                WHERE d.validator_address = @validator_address
+                  AND d.delegator_address = c.delegator_address
+                  AND d.timestamp <= p.period_end
+                ORDER BY d.timestamp DESC LIMIT 1
+        ), 0)::bigint AS remaining_amount
+    ) s
+    GROUP BY p.cohort_start, p.period_offset
+    ORDER BY p.cohort_start ASC, p.period_offset ASC;
+
+-- name: CreateDailyConcentrationMetrics :execrows
+WITH balances AS (
+    SELECT a.validator_address, a.delegator_address, a.date, a.total_amount
+        FROM daily_aggregates a
+        WHERE a.total_amount > 0
+          AND (a.date = @date OR NOT EXISTS (
+              SELECT 1 FROM daily_concentration_metrics m
+                  WHERE m.validator_address = a.validator_address AND m.date = a.date
+          ))
+),
+ranked AS (
+    SELECT b.validator_address, b.date, b.total_amount::numeric AS amount,
+           ROW_NUMBER() OVER (PARTITION BY b.validator_address, b.date ORDER BY b.total_amount ASC, b.delegator_address ASC) AS asc_rank,
+           ROW_NUMBER() OVER (PARTITION BY b.validator_address, b.date ORDER BY b.total_amount DESC, b.delegator_address ASC) AS desc_rank,
+           SUM(b.total_amount) OVER (
+               PARTITION BY b.validator_address, b.date
+               ORDER BY b.total_amount DESC, b.delegator_address ASC
+               ROWS UNBOUNDED PRECEDING
+           )::numeric AS cumulative_amount,
+           SUM(b.total_amount) OVER (PARTITION BY b.validator_address, b.date)::numeric AS day_total
+        FROM balances b
+)
+INSERT INTO daily_concentration_metrics (
+    validator_address,
+    date,
+    delegator_count,
+    total_amount,
+    gini_coefficient,
+    herfindahl_index,
+    top_10_share,
+    top_100_share,
+    nakamoto_33,
+    nakamoto_50
+)
+SELECT r.validator_address,
+       r.date,
+       COUNT(*),
+       SUM(r.amount)::bigint,
+       (2 * SUM(r.asc_rank * r.amount) / (COUNT(*) * SUM(r.amount)) - (COUNT(*) + 1)::numeric / COUNT(*))::float8,
+       SUM((r.amount / r.day_total) ^ 2)::float8,
+       (COALESCE(SUM(r.amount) FILTER (WHERE r.desc_rank <= 10), 0) / SUM(r.amount))::float8,
+       (COALESCE(SUM(r.amount) FILTER (WHERE r.desc_rank <= 100), 0) / SUM(r.amount))::float8,
+       MIN(r.desc_rank) FILTER (WHERE r.cumulative_amount * 3 >= r.day_total),
+       MIN(r.desc_rank) FILTER (WHERE r.cumulative_amount * 2 >= r.day_total)
+    FROM ranked r
+    GROUP BY r.validator_address, r.date
+ON CONFLICT (validator_address, date) DO UPDATE SET
+    delegator_count = EXCLUDED.delegator_count,
+    total_amount = EXCLUDED.total_amount,
+    gini_coefficient = EXCLUDED.gini_coefficient,
+    herfindahl_index = EXCLUDED.herfindahl_index,
+    top_10_share = EXCLUDED.top_10_share,
+    top_100_share = EXCLUDED.top_100_share,
+    nakamoto_33 = EXCLUDED.nakamoto_33,
+    nakamoto_50 = EXCLUDED.nakamoto_50,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: GetDailyConcentrationMetricByValidator :many
+SELECT date, delegator_count, total_amount, gini_coefficient, herfindahl_index,
+       top_10_share, top_100_share, nakamoto_33, nakamoto_50
+    FROM daily_concentration_metrics
+    WHERE validator_address = @validator_address
+      AND (sqlc.narg('start_date')::date IS NULL OR date >= sqlc.narg('start_date'))
+      AND (sqlc.narg('end_date')::date IS NULL OR date <= sqlc.narg('end_date'))
+    ORDER BY date ASC
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: GetCountDailyConcentrationMetricByValidator :one
+SELECT COUNT(*)
+    FROM daily_concentration_metrics
+    WHERE validator_address = @validator_address
+      AND (sqlc.narg('start_date')::date IS NULL OR date >= sqlc.narg('start_date'))
+      AND (sqlc.narg('end_date')::date IS NULL OR date <= sqlc.narg('end_date'));
+
+-- name: GetCurrentDelegatorDistributionByValidator :many
+SELECT width_bucket(s.amount_uatom, @boundaries::bigint[])::int AS bucket,
+       COUNT(*) AS delegator_count,
+       SUM(s.amount_uatom)::bigint AS total_amount
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = @validator_address
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+      AND (NOT @latest_run_only::boolean OR s.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = @validator_address AND r.job_name = @job_name
+      ))
+    GROUP BY bucket
+    ORDER BY bucket ASC;
+
+-- name: GetDailyDelegatorDistributionByValidator :many
+SELECT width_bucket(a.total_amount, @boundaries::bigint[])::int AS bucket,
+       COUNT(*) AS delegator_count,
+       SUM(a.total_amount)::bigint AS total_amount
+    FROM daily_aggregates a
+    WHERE a.validator_address = @validator_address AND a.date = @date::date
+      AND a.total_amount <> 0
+    GROUP BY bucket
+    ORDER BY bucket ASC;
+
+-- name: GetUnprocessedDelegatorEventDates :many
+SELECT DISTINCT (r.timestamp AT TIME ZONE @day_timezone::text)::date AS date
+    FROM scheduler_runs r
+    WHERE r.validator_address = @validator_address
+      AND r.job_name = @job_name
+      AND r.timestamp < @before::timestamptz
+      AND NOT EXISTS (
+          SELECT 1 FROM scheduler_runs e
+              WHERE e.validator_address = r.validator_address
+                AND e.job_name = @event_job_name
+                AND (e.timestamp AT TIME ZONE @day_timezone::text)::date = (r.timestamp AT TIME ZONE @day_timezone::text)::date
+      )
+    ORDER BY date;
+
+-- name: CreateDelegatorEvents :execrows
+-- Compares the balances at the last run of the day with the ones at the last run before it.
+-- Only delegators with a row in between, or at the previous run, can have changed.
+WITH runs AS (
+    SELECT
+        (SELECT MAX(r.timestamp) FROM scheduler_runs r
+            WHERE r.validator_address = @validator_address AND r.job_name = @job_name
+              AND r.timestamp >= @start_time::timestamptz AND r.timestamp < @end_time::timestamptz) AS end_run,
+        COALESCE((SELECT MAX(r.timestamp) FROM scheduler_runs r
+            WHERE r.validator_address = @validator_address AND r.job_name = @job_name
+              AND r.timestamp < @start_time::timestamptz), '-infinity'::timestamptz) AS previous_run
+),
+balances AS (
+    SELECT c.delegator_address,
+           COALESCE((
+               SELECT CASE WHEN NOT @latest_run_only::boolean OR d.timestamp = runs.previous_run THEN d.amount_uatom ELSE 0 END
+                   FROM delegation_snapshots d
+                   WHERE d.validator_address = @validator_address AND d.delegator_address = c.delegator_address
+                     AND d.timestamp <= runs.previous_run
+                   ORDER BY d.timestamp DESC LIMIT 1
+           ), 0)::bigint AS previous_amount,
+           COALESCE((
+               SELECT CASE WHEN NOT @latest_run_only::boolean OR d.timestamp = runs.end_run THEN d.amount_uatom ELSE 0 END
+                   FROM delegation_snapshots d
+                   WHERE d.validator_address = @validator_address AND d.delegator_address = c.delegator_address
+                     AND d.timestamp <= runs.end_run
+                   ORDER BY d.timestamp DESC LIMIT 1
+           ), 0)::bigint AS end_amount,
+           runs.previous_run
+        FROM runs
+        CROSS JOIN LATERAL (
+            SELECT DISTINCT d.delegator_address
+                FROM delegation_snapshots d
+                WHERE d.validator_address = @validator_address
+                  AND d.timestamp >= runs.previous_run AND d.timestamp <= runs.end_run
+        ) c
+)
+INSERT INTO delegator_events (validator_address, delegator_address, date, type, amount_uatom)
+SELECT @validator_address, b.delegator_address, @date::date,
+       CASE
+           WHEN b.previous_amount > 0 THEN 'churned'
+           WHEN EXISTS (
+               SELECT 1 FROM delegation_snapshots d
+                   WHERE d.validator_address = @validator_address AND d.delegator_address = b.delegator_address
+                     AND d.timestamp <= b.previous_run AND d.amount_uatom > 0
+           ) OR EXISTS (
+               SELECT 1 FROM delegator_events e
+                   WHERE e.validator_address = @validator_address AND e.delegator_address = b.delegator_address
+                     AND e.date < @date::date
+           ) THEN 'returned'
+           ELSE 'new'
+       END,
+       GREATEST(b.previous_amount, b.end_amount)
+    FROM balances b
+    WHERE (b.previous_amount = 0) <> (b.end_amount = 0)
+ON CONFLICT (validator_address, delegator_address, date, type) DO UPDATE SET
+    amount_uatom = EXCLUDED.amount_uatom,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: GetDelegatorEventByValidator :many
+SELECT date, delegator_address, type, amount_uatom
+    FROM delegator_events
+    WHERE validator_address = @validator_address
+      AND (@type::text = '' OR type = @type::text)
+      AND (sqlc.narg('start_date')::date IS NULL OR date >= sqlc.narg('start_date'))
+      AND (sqlc.narg('end_date')::date IS NULL OR date <= sqlc.narg('end_date'))
+    ORDER BY date DESC, amount_uatom DESC, delegator_address ASC
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: GetCountDelegatorEventByValidator :one
+SELECT COUNT(*)
+    FROM delegator_events
+    WHERE validator_address = @validator_address
+      AND (@type::text = '' OR type = @type::text)
+      AND (sqlc.narg('start_date')::date IS NULL OR date >= sqlc.narg('start_date'))
+      AND (sqlc.narg('end_date')::date IS NULL OR date <= sqlc.narg('end_date'));
+
+-- name: GetDailyDelegatorEventCountByValidator :many
+SELECT date, type, COUNT(*) AS delegator_count, SUM(amount_uatom)::bigint AS total_amount
+    FROM delegator_events
+    WHERE validator_address = @validator_address
+      AND (@type::text = '' OR type = @type::text)
+      AND (sqlc.narg('start_date')::date IS NULL OR date >= sqlc.narg('start_date'))
+      AND (sqlc.narg('end_date')::date IS NULL OR date <= sqlc.narg('end_date'))
+    GROUP BY date, type
+    ORDER BY date DESC, type ASC;
+
+-- name: CreateValidatorCommission :one
+INSERT INTO validator_commissions (validator_address, date, commission_rate)
+VALUES (@validator_address, @date, @commission_rate)
+ON CONFLICT (validator_address, date) DO UPDATE SET
+    commission_rate = EXCLUDED.commission_rate,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id;
+
+-- name: GetDailyTotalByValidators :many
+SELECT a.validator_address, a.date, COUNT(*) AS delegator_count, SUM(a.total_amount)::bigint AS total_amount
+    FROM daily_aggregates a
+    WHERE a.validator_address = ANY(@validator_addresses::text[])
+      AND a.date >= @start_date::date AND a.date <= @end_date::date
+      AND a.total_amount <> 0
+    GROUP BY a.validator_address, a.date
+    ORDER BY a.validator_address, a.date;
+
+-- name: GetValidatorCommissionByValidators :many
+SELECT validator_address, date, commission_rate
+    FROM validator_commissions
+    WHERE validator_address = ANY(@validator_addresses::text[])
+      AND date >= @start_date::date AND date <= @end_date::date
+    ORDER BY validator_address, date;
+
+-- name: GetDelegationAsOfByValidator :many
+SELECT b.delegator_address, b.amount_uatom, b.timestamp
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = @validator_address AND d.timestamp <= @at::timestamptz
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) b
+    WHERE b.amount_uatom <> 0
+      AND (NOT @latest_run_only::boolean OR b.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = @validator_address AND r.job_name = @job_name AND r.timestamp <= @at::timestamptz
+      ))
+    ORDER BY
+    CASE WHEN @sort_by::text = '-amount' THEN b.amount_uatom END DESC,
+    CASE WHEN @sort_by::text = 'amount' THEN b.amount_uatom END ASC,
+    b.delegator_address ASC
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: GetCountDelegationAsOfByValidator :one
+SELECT COUNT(*)
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = @validator_address AND d.timestamp <= @at::timestamptz
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) b
+    WHERE b.amount_uatom <> 0
+      AND (NOT @latest_run_only::boolean OR b.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = @validator_address AND r.job_name = @job_name AND r.timestamp <= @at::timestamptz
+      ));
+
+-- name: GetDelegationDiffByValidator :many
+WITH at_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = @validator_address AND d.timestamp <= @at::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT @latest_run_only::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = @validator_address AND r.job_name = @job_name AND r.timestamp <= @at::timestamptz
+          ))
+),
+compare_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = @validator_address AND d.timestamp <= @compare_to::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT @latest_run_only::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = @validator_address AND r.job_name = @job_name AND r.timestamp <= @compare_to::timestamptz
+          ))
+)
+SELECT COALESCE(a.delegator_address, c.delegator_address)::text AS delegator_address,
+       COALESCE(a.amount_uatom, 0)::bigint AS amount_uatom,
+       COALESCE(c.amount_uatom, 0)::bigint AS compare_amount_uatom,
+       (COALESCE(a.amount_uatom, 0) - COALESCE(c.amount_uatom, 0))::bigint AS change_uatom
+    FROM at_balances a
+    FULL OUTER JOIN compare_balances c ON c.delegator_address = a.delegator_address
+    WHERE COALESCE(a.amount_uatom, 0) <> COALESCE(c.amount_uatom, 0)
+    ORDER BY
+    CASE WHEN @sort_by::text = '-amount' THEN COALESCE(a.amount_uatom, 0) END DESC,
+    CASE WHEN @sort_by::text = 'amount' THEN COALESCE(a.amount_uatom, 0) END ASC,
+    CASE WHEN @sort_by::text = '-change' THEN COALESCE(a.amount_uatom, 0) - COALESCE(c.amount_uatom, 0) END DESC,
+    CASE WHEN @sort_by::text = 'change' THEN COALESCE(a.amount_uatom, 0) - COALESCE(c.amount_uatom, 0) END ASC,
+    COALESCE(a.delegator_address, c.delegator_address) ASC
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset');
+
+-- name: GetCountDelegationDiffByValidator :one
+WITH at_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = @validator_address AND d.timestamp <= @at::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT @latest_run_only::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = @validator_address AND r.job_name = @job_name AND r.timestamp <= @at::timestamptz
+          ))
+),
+compare_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = @validator_address AND d.timestamp <= @compare_to::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT @latest_run_only::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = @validator_address AND r.job_name = @job_name AND r.timestamp <= @compare_to::timestamptz
+          ))
+)
+SELECT COUNT(*)
+    FROM at_balances a
+    FULL OUTER JOIN compare_balances c ON c.delegator_address = a.delegator_address
+    WHERE COALESCE(a.amount_uatom, 0) <> COALESCE(c.amount_uatom, 0);
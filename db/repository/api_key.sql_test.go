@@ -0,0 +1,183 @@
+package querier
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateAPIKeyParams{
+		Name:      "collector",
+		KeyPrefix: "vtk_0123abcd",
+		KeyHash:   "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		Scopes:    []string{"trigger-jobs"},
+	}
+	row := CreateAPIKeyRow{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		KeyPrefix: req.KeyPrefix,
+		Scopes:    req.Scopes,
+		CreatedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success create api key", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createAPIKey)).
+			WithArgs(req.Name, req.KeyPrefix, req.KeyHash, req.Scopes).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "key_prefix", "scopes", "last_used_at", "revoked_at", "created_at"}).
+				AddRow(row.ID, row.Name, row.KeyPrefix, row.Scopes, row.LastUsedAt, row.RevokedAt, row.CreatedAt))
+
+		res, err := q.CreateAPIKey(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, row, res)
+	})
+
+	t.Run("failed create api key", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createAPIKey)).
+			WithArgs(req.Name, req.KeyPrefix, req.KeyHash, req.Scopes).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateAPIKey(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	row := GetAPIKeysRow{
+		ID:         uuid.New(),
+		Name:       "dashboard",
+		KeyPrefix:  "vtk_0123abcd",
+		Scopes:     []string{"read"},
+		LastUsedAt: sql.NullTime{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+		CreatedAt:  time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success get api keys", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getAPIKeys)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "key_prefix", "scopes", "last_used_at", "revoked_at", "created_at"}).
+				AddRow(row.ID, row.Name, row.KeyPrefix, row.Scopes, row.LastUsedAt, row.RevokedAt, row.CreatedAt))
+
+		res, err := q.GetAPIKeys(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetAPIKeysRow{row}, res)
+	})
+
+	t.Run("failed get api keys", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getAPIKeys)).
+			WillReturnError(errQuery)
+
+		res, err := q.GetAPIKeys(ctx)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetActiveAPIKeyByHash(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	keyHash := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+	row := GetActiveAPIKeyByHashRow{
+		ID:        uuid.New(),
+		Name:      "collector",
+		KeyPrefix: "vtk_0123abcd",
+		Scopes:    []string{"trigger-jobs"},
+		CreatedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success get active api key by hash", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getActiveAPIKeyByHash)).
+			WithArgs(keyHash).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "key_prefix", "scopes", "last_used_at", "revoked_at", "created_at"}).
+				AddRow(row.ID, row.Name, row.KeyPrefix, row.Scopes, row.LastUsedAt, row.RevokedAt, row.CreatedAt))
+
+		res, err := q.GetActiveAPIKeyByHash(ctx, keyHash)
+		assert.NoError(t, err)
+		assert.Equal(t, row, res)
+	})
+
+	t.Run("failed get active api key by hash", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getActiveAPIKeyByHash)).
+			WithArgs(keyHash).
+			WillReturnError(errQuery)
+
+		res, err := q.GetActiveAPIKeyByHash(ctx, keyHash)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestUpdateAPIKeyLastUsed(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	t.Run("success update api key last used", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(updateAPIKeyLastUsed)).
+			WithArgs(id).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err := q.UpdateAPIKeyLastUsed(ctx, id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed update api key last used", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(updateAPIKeyLastUsed)).
+			WithArgs(id).
+			WillReturnError(errQuery)
+
+		err := q.UpdateAPIKeyLastUsed(ctx, id)
+		assert.Error(t, err)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	t.Run("success revoke api key", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(revokeAPIKey)).
+			WithArgs(id).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		res, err := q.RevokeAPIKey(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res)
+	})
+
+	t.Run("failed revoke api key", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(revokeAPIKey)).
+			WithArgs(id).
+			WillReturnError(errQuery)
+
+		res, err := q.RevokeAPIKey(ctx, id)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
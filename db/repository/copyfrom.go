@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: copyfrom.go
+
+package querier
+
+import (
+	"context"
+)
+
+// iteratorForCreateDelegationSnapshots implements pgx.CopyFromSource.
+type iteratorForCreateDelegationSnapshots struct {
+	rows                 []CreateDelegationSnapshotsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateDelegationSnapshots) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateDelegationSnapshots) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ValidatorAddress,
+		r.rows[0].DelegatorAddress,
+		r.rows[0].AmountUatom,
+		r.rows[0].ChangeUatom,
+		r.rows[0].Timestamp,
+	}, nil
+}
+
+func (r iteratorForCreateDelegationSnapshots) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateDelegationSnapshots(ctx context.Context, arg []CreateDelegationSnapshotsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"delegation_snapshots"}, []string{"validator_address", "delegator_address", "amount_uatom", "change_uatom", "timestamp"}, &iteratorForCreateDelegationSnapshots{rows: arg})
+}
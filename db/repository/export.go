@@ -0,0 +1,101 @@
+package querier
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ExportDelegatorHistoryByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	DelegatorAddress string `json:"delegator_address"`
+	SortBy           string `json:"sort_by"`
+}
+
+type ExportReconstructedDelegatorHistoryByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	DelegatorAddress string `json:"delegator_address"`
+	JobName          string `json:"job_name"`
+	SortBy           string `json:"sort_by"`
+}
+
+// The export queries reuse the paginated ones with a NULL limit, which Postgres
+// treats as no limit, and hand every row to fn straight from the cursor.
+
+func (q *Queries) ExportDelegationSnapshotByValidator(ctx context.Context, validatorAddress string, fn func(GetDelegationSnapshotByValidatorRow) error) error {
+	rows, err := q.db.Query(ctx, getDelegationSnapshotByValidator, validatorAddress, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	var i GetDelegationSnapshotByValidatorRow
+	_, err = pgx.ForEachRow(rows, []any{&i.DelegatorAddress, &i.AmountUatom, &i.Timestamp, &i.ChangeUatom}, func() error {
+		return fn(i)
+	})
+	return err
+}
+
+func (q *Queries) ExportReconstructedDelegationSnapshotByValidator(ctx context.Context, arg GetCountReconstructedDelegationSnapshotByValidatorParams, fn func(GetReconstructedDelegationSnapshotByValidatorRow) error) error {
+	rows, err := q.db.Query(ctx, getReconstructedDelegationSnapshotByValidator, arg.ValidatorAddress, arg.JobName, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	var i GetReconstructedDelegationSnapshotByValidatorRow
+	_, err = pgx.ForEachRow(rows, []any{&i.DelegatorAddress, &i.AmountUatom, &i.Timestamp, &i.ChangeUatom}, func() error {
+		return fn(i)
+	})
+	return err
+}
+
+func (q *Queries) ExportDailyAggregateByValidator(ctx context.Context, validatorAddress string, fn func(GetDailyAggregateByValidatorRow) error) error {
+	rows, err := q.db.Query(ctx, getDailyAggregateByValidator, validatorAddress, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	var i GetDailyAggregateByValidatorRow
+	_, err = pgx.ForEachRow(rows, []any{&i.DelegatorAddress, &i.Date, &i.TotalAmount}, func() error {
+		return fn(i)
+	})
+	return err
+}
+
+func (q *Queries) ExportDailyAggregateInTimezoneByValidator(ctx context.Context, arg GetCountDailyAggregateInTimezoneByValidatorParams, fn func(GetDailyAggregateInTimezoneByValidatorRow) error) error {
+	rows, err := q.db.Query(ctx, getDailyAggregateInTimezoneByValidator, arg.DayTimezone, arg.ValidatorAddress, arg.JobName, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	var i GetDailyAggregateInTimezoneByValidatorRow
+	_, err = pgx.ForEachRow(rows, []any{&i.DelegatorAddress, &i.Date, &i.TotalAmount}, func() error {
+		return fn(i)
+	})
+	return err
+}
+
+func (q *Queries) ExportDelegatorHistoryByValidator(ctx context.Context, arg ExportDelegatorHistoryByValidatorParams, fn func(GetDelegatorHistoryByValidatorRow) error) error {
+	rows, err := q.db.Query(ctx, getDelegatorHistoryByValidator, arg.ValidatorAddress, arg.DelegatorAddress, nil, 0, arg.SortBy)
+	if err != nil {
+		return err
+	}
+
+	var i GetDelegatorHistoryByValidatorRow
+	_, err = pgx.ForEachRow(rows, []any{&i.Timestamp, &i.AmountUatom, &i.ChangeUatom}, func() error {
+		return fn(i)
+	})
+	return err
+}
+
+func (q *Queries) ExportReconstructedDelegatorHistoryByValidator(ctx context.Context, arg ExportReconstructedDelegatorHistoryByValidatorParams, fn func(GetReconstructedDelegatorHistoryByValidatorRow) error) error {
+	rows, err := q.db.Query(ctx, getReconstructedDelegatorHistoryByValidator, arg.ValidatorAddress, arg.DelegatorAddress, arg.JobName, nil, 0, arg.SortBy)
+	if err != nil {
+		return err
+	}
+
+	var i GetReconstructedDelegatorHistoryByValidatorRow
+	_, err = pgx.ForEachRow(rows, []any{&i.Timestamp, &i.AmountUatom, &i.ChangeUatom}, func() error {
+		return fn(i)
+	})
+	return err
+}
@@ -0,0 +1,282 @@
+package querier
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportDelegationSnapshotByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	row := GetDelegationSnapshotByValidatorRow{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		AmountUatom:      1000,
+		Timestamp:        time.Now(),
+		ChangeUatom:      100,
+	}
+
+	t.Run("success export delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationSnapshotByValidator)).
+			WithArgs(validatorAddress, nil, 0).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom", "timestamp", "change_uatom"}).
+				AddRow(row.DelegatorAddress, row.AmountUatom, row.Timestamp, row.ChangeUatom).
+				AddRow(row.DelegatorAddress, row.AmountUatom, row.Timestamp, row.ChangeUatom))
+
+		var res []GetDelegationSnapshotByValidatorRow
+		err := q.ExportDelegationSnapshotByValidator(ctx, validatorAddress, func(item GetDelegationSnapshotByValidatorRow) error {
+			res = append(res, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegationSnapshotByValidatorRow{row, row}, res)
+	})
+
+	t.Run("failed export delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationSnapshotByValidator)).
+			WithArgs(validatorAddress, nil, 0).
+			WillReturnError(errQuery)
+
+		err := q.ExportDelegationSnapshotByValidator(ctx, validatorAddress, func(item GetDelegationSnapshotByValidatorRow) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("failed write exported delegation snapshot", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationSnapshotByValidator)).
+			WithArgs(validatorAddress, nil, 0).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom", "timestamp", "change_uatom"}).
+				AddRow(row.DelegatorAddress, row.AmountUatom, row.Timestamp, row.ChangeUatom))
+
+		err := q.ExportDelegationSnapshotByValidator(ctx, validatorAddress, func(item GetDelegationSnapshotByValidatorRow) error {
+			return errQuery
+		})
+		assert.ErrorIs(t, err, errQuery)
+	})
+}
+
+func TestExportReconstructedDelegationSnapshotByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountReconstructedDelegationSnapshotByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+	}
+	row := GetReconstructedDelegationSnapshotByValidatorRow{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		AmountUatom:      1000,
+		Timestamp:        time.Now(),
+		ChangeUatom:      0,
+	}
+
+	t.Run("success export reconstructed delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegationSnapshotByValidator)).
+			WithArgs(req.ValidatorAddress, req.JobName, nil, 0).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom", "timestamp", "change_uatom"}).
+				AddRow(row.DelegatorAddress, row.AmountUatom, row.Timestamp, row.ChangeUatom))
+
+		var res []GetReconstructedDelegationSnapshotByValidatorRow
+		err := q.ExportReconstructedDelegationSnapshotByValidator(ctx, req, func(item GetReconstructedDelegationSnapshotByValidatorRow) error {
+			res = append(res, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []GetReconstructedDelegationSnapshotByValidatorRow{row}, res)
+	})
+
+	t.Run("failed export reconstructed delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegationSnapshotByValidator)).
+			WithArgs(req.ValidatorAddress, req.JobName, nil, 0).
+			WillReturnError(errQuery)
+
+		err := q.ExportReconstructedDelegationSnapshotByValidator(ctx, req, func(item GetReconstructedDelegationSnapshotByValidatorRow) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestExportDailyAggregateByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	row := GetDailyAggregateByValidatorRow{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Now(),
+		TotalAmount:      1000,
+	}
+
+	t.Run("success export daily aggregate by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateByValidator)).
+			WithArgs(validatorAddress, nil, 0).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "date", "total_amount"}).
+				AddRow(row.DelegatorAddress, row.Date, row.TotalAmount))
+
+		var res []GetDailyAggregateByValidatorRow
+		err := q.ExportDailyAggregateByValidator(ctx, validatorAddress, func(item GetDailyAggregateByValidatorRow) error {
+			res = append(res, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyAggregateByValidatorRow{row}, res)
+	})
+
+	t.Run("failed export daily aggregate by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateByValidator)).
+			WithArgs(validatorAddress, nil, 0).
+			WillReturnError(errQuery)
+
+		err := q.ExportDailyAggregateByValidator(ctx, validatorAddress, func(item GetDailyAggregateByValidatorRow) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestExportDailyAggregateInTimezoneByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDailyAggregateInTimezoneByValidatorParams{
+		DayTimezone:      "Europe/Berlin",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+	}
+	row := GetDailyAggregateInTimezoneByValidatorRow{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Now(),
+		TotalAmount:      1000,
+	}
+
+	t.Run("success export daily aggregate in timezone by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateInTimezoneByValidator)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, 0, nil).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "date", "total_amount"}).
+				AddRow(row.DelegatorAddress, row.Date, row.TotalAmount))
+
+		var res []GetDailyAggregateInTimezoneByValidatorRow
+		err := q.ExportDailyAggregateInTimezoneByValidator(ctx, req, func(item GetDailyAggregateInTimezoneByValidatorRow) error {
+			res = append(res, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyAggregateInTimezoneByValidatorRow{row}, res)
+	})
+
+	t.Run("failed export daily aggregate in timezone by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateInTimezoneByValidator)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, 0, nil).
+			WillReturnError(errQuery)
+
+		err := q.ExportDailyAggregateInTimezoneByValidator(ctx, req, func(item GetDailyAggregateInTimezoneByValidatorRow) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestExportDelegatorHistoryByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := ExportDelegatorHistoryByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		SortBy:           "-date",
+	}
+	row := GetDelegatorHistoryByValidatorRow{
+		Timestamp:   time.Now(),
+		AmountUatom: 1000,
+		ChangeUatom: 100,
+	}
+
+	t.Run("success export delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, nil, 0, req.SortBy).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp", "amount_uatom", "change_uatom"}).
+				AddRow(row.Timestamp, row.AmountUatom, row.ChangeUatom))
+
+		var res []GetDelegatorHistoryByValidatorRow
+		err := q.ExportDelegatorHistoryByValidator(ctx, req, func(item GetDelegatorHistoryByValidatorRow) error {
+			res = append(res, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegatorHistoryByValidatorRow{row}, res)
+	})
+
+	t.Run("failed export delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, nil, 0, req.SortBy).
+			WillReturnError(errQuery)
+
+		err := q.ExportDelegatorHistoryByValidator(ctx, req, func(item GetDelegatorHistoryByValidatorRow) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestExportReconstructedDelegatorHistoryByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := ExportReconstructedDelegatorHistoryByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		SortBy:           "date",
+	}
+	row := GetReconstructedDelegatorHistoryByValidatorRow{
+		Timestamp:   time.Now(),
+		AmountUatom: 1000,
+		ChangeUatom: 0,
+	}
+
+	t.Run("success export reconstructed delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, req.JobName, nil, 0, req.SortBy).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp", "amount_uatom", "change_uatom"}).
+				AddRow(row.Timestamp, row.AmountUatom, row.ChangeUatom))
+
+		var res []GetReconstructedDelegatorHistoryByValidatorRow
+		err := q.ExportReconstructedDelegatorHistoryByValidator(ctx, req, func(item GetReconstructedDelegatorHistoryByValidatorRow) error {
+			res = append(res, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []GetReconstructedDelegatorHistoryByValidatorRow{row}, res)
+	})
+
+	t.Run("failed export reconstructed delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, req.JobName, nil, 0, req.SortBy).
+			WillReturnError(errQuery)
+
+		err := q.ExportReconstructedDelegatorHistoryByValidator(ctx, req, func(item GetReconstructedDelegatorHistoryByValidatorRow) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
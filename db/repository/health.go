@@ -0,0 +1,24 @@
+package querier
+
+import (
+	"context"
+)
+
+// getMigrationVersion reads the table golang-migrate keeps its state in, which is not part of the sqlc schema
+const getMigrationVersion = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+type GetMigrationVersionRow struct {
+	Version int64 `json:"version"`
+	Dirty   bool  `json:"dirty"`
+}
+
+func (r *RepositoryImpl) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+func (r *RepositoryImpl) GetMigrationVersion(ctx context.Context) (GetMigrationVersionRow, error) {
+	row := r.db.QueryRow(ctx, getMigrationVersion)
+	var i GetMigrationVersionRow
+	err := row.Scan(&i.Version, &i.Dirty)
+	return i, err
+}
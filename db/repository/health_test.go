@@ -0,0 +1,56 @@
+package querier
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	t.Run("success ping", func(t *testing.T) {
+		mockDB.ExpectPing()
+
+		err := q.Ping(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed ping", func(t *testing.T) {
+		mockDB.ExpectPing().WillReturnError(errQuery)
+
+		err := q.Ping(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetMigrationVersion(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	t.Run("success get migration version", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getMigrationVersion)).
+			WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(int64(9), false))
+
+		res, err := q.GetMigrationVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, GetMigrationVersionRow{Version: 9}, res)
+	})
+
+	t.Run("failed get migration version", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getMigrationVersion)).
+			WillReturnError(errQuery)
+
+		res, err := q.GetMigrationVersion(ctx)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
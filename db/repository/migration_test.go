@@ -0,0 +1,289 @@
+package querier
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMigrate empties the database of DB_CONN_STRING, skipping the test without one, and returns a migrate on it
+// that drops every table once the test is done.
+func newTestMigrate(t *testing.T) (*utils.BaseConfig, *sql.DB, *migrate.Migrate) {
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../../config", "test", config)
+	if config.DBConnString == "" {
+		t.Skip("DB_CONN_STRING is not set")
+	}
+	db := utils.ConnectDB(config.DBConnString)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	_, err := db.Exec("CREATE EXTENSION IF NOT EXISTS pgcrypto")
+	require.NoError(t, err)
+	newMigrate := func() *migrate.Migrate {
+		driver, err := postgres.WithInstance(db, &postgres.Config{})
+		require.NoError(t, err)
+		m, err := migrate.NewWithDatabaseInstance("file://../migration", config.DBName, driver)
+		require.NoError(t, err)
+		return m
+	}
+	require.NoError(t, newMigrate().Drop())
+	m := newMigrate()
+	t.Cleanup(func() {
+		_ = m.Drop()
+	})
+
+	return config, db, m
+}
+
+// TestSchedulerRunsMigration runs against the database of DB_CONN_STRING, which it drops every table of, and is skipped without one.
+func TestSchedulerRunsMigration(t *testing.T) {
+	ctx := context.Background()
+	_, db, m := newTestMigrate(t)
+	require.NoError(t, m.Migrate(1))
+
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	runs := []time.Time{
+		time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+	}
+	// Legacy runs stamped each delegator row with the time it was written
+	for _, run := range runs {
+		for i, delegatorAddress := range []string{"cosmos1a", "cosmos1b", "cosmos1c"} {
+			_, err := db.ExecContext(ctx, `INSERT INTO delegation_snapshots (validator_address, delegator_address, amount_uatom, change_uatom, timestamp)
+				VALUES ($1, $2, 100, 0, $3)`, validatorAddress, delegatorAddress, run.Add(time.Duration(i)*time.Second))
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, m.Migrate(2))
+
+	t.Run("every legacy row is aligned on its run timestamp", func(t *testing.T) {
+		for _, run := range runs {
+			var count int
+			err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM delegation_snapshots d
+				JOIN scheduler_runs r ON r.validator_address = d.validator_address AND r.timestamp = d.timestamp
+				WHERE r.timestamp = $1 AND r.is_checkpoint`, run.Add(2*time.Second)).Scan(&count)
+			assert.NoError(t, err)
+			assert.Equal(t, 3, count)
+		}
+	})
+
+	t.Run("one checkpoint run is recorded per legacy run", func(t *testing.T) {
+		var count, rowsAffected int
+		err := db.QueryRowContext(ctx, `SELECT COUNT(*), SUM(rows_affected) FROM scheduler_runs WHERE validator_address = $1`, validatorAddress).
+			Scan(&count, &rowsAffected)
+		assert.NoError(t, err)
+		assert.Equal(t, len(runs), count)
+		assert.Equal(t, 6, rowsAffected)
+	})
+
+	t.Run("migrates up to the latest version", func(t *testing.T) {
+		err := m.Up()
+		assert.NoError(t, err)
+	})
+}
+
+// TestDailyAggregatesUniqueMigration runs against the database of DB_CONN_STRING, which it drops every table of, and is skipped without one.
+func TestDailyAggregatesUniqueMigration(t *testing.T) {
+	ctx := context.Background()
+	config, db, m := newTestMigrate(t)
+	require.NoError(t, m.Migrate(3))
+
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Each rerun of the daily job inserted the day again
+	for i, amount := range []int64{100, 200, 300} {
+		_, err := db.ExecContext(ctx, `INSERT INTO daily_aggregates (validator_address, delegator_address, date, total_amount, created_at)
+			VALUES ($1, 'cosmos1a', $2, $3, $4)`, validatorAddress, date, amount, date.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, m.Migrate(4))
+	pool := utils.ConnectDBPool(config.DBConnString)
+	defer pool.Close()
+	q := New(pool)
+
+	t.Run("only the latest row of a day is kept", func(t *testing.T) {
+		var count int
+		var totalAmount int64
+		err := db.QueryRowContext(ctx, `SELECT COUNT(*), MAX(total_amount) FROM daily_aggregates WHERE validator_address = $1`, validatorAddress).
+			Scan(&count, &totalAmount)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, int64(300), totalAmount)
+	})
+
+	t.Run("a rerun updates the day", func(t *testing.T) {
+		_, err := q.CreateDailyAggregate(ctx, CreateDailyAggregateParams{
+			ValidatorAddress: validatorAddress,
+			DelegatorAddress: "cosmos1a",
+			Date:             date,
+			TotalAmount:      400,
+		})
+		assert.NoError(t, err)
+
+		var count int
+		var totalAmount int64
+		err = db.QueryRowContext(ctx, `SELECT COUNT(*), MAX(total_amount) FROM daily_aggregates WHERE validator_address = $1`, validatorAddress).
+			Scan(&count, &totalAmount)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, int64(400), totalAmount)
+	})
+}
+
+// TestGetDelegatorChangeHistoryExits runs against the database of DB_CONN_STRING, which it drops every table of, and is skipped without one.
+func TestGetDelegatorChangeHistoryExits(t *testing.T) {
+	ctx := context.Background()
+	config, db, m := newTestMigrate(t)
+	require.NoError(t, m.Up())
+	pool := utils.ConnectDBPool(config.DBConnString)
+	defer pool.Close()
+	q := New(pool)
+
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	delegatorAddress := "cosmos1a"
+	firstRun := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+	runs := []time.Time{firstRun, firstRun.Add(time.Hour), firstRun.Add(2 * time.Hour)}
+	_, err := q.CreateDelegationSnapshotPartitions(ctx, CreateDelegationSnapshotPartitionsParams{FromMonth: firstRun, MonthCount: 2})
+	require.NoError(t, err)
+
+	// The delegator joins with the first run, is missing from the second one and comes back with the third one
+	for i, run := range runs {
+		_, err := q.CreateSchedulerRun(ctx, CreateSchedulerRunParams{
+			JobName:          "hourly_collect",
+			ValidatorAddress: validatorAddress,
+			Timestamp:        run,
+			IsCheckpoint:     true,
+		})
+		require.NoError(t, err)
+		if i == 1 {
+			continue
+		}
+
+		_, err = db.ExecContext(ctx, `INSERT INTO delegation_snapshots (validator_address, delegator_address, amount_uatom, change_uatom, timestamp)
+			VALUES ($1, $2, 100, 100, $3)`, validatorAddress, delegatorAddress, run)
+		require.NoError(t, err)
+	}
+
+	t.Run("exits are rebuilt from the runs the delegator is missing from", func(t *testing.T) {
+		history, err := q.GetDelegatorChangeHistory(ctx, GetDelegatorChangeHistoryParams{
+			DelegatorAddress: delegatorAddress,
+			JobName:          "hourly_collect",
+			SynthesizeExits:  true,
+			SortBy:           "date",
+			Limit:            10,
+		})
+		assert.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.True(t, history[1].Timestamp.Equal(runs[1]))
+		assert.Equal(t, int64(0), history[1].AmountUatom)
+		assert.Equal(t, int64(-100), history[1].ChangeUatom)
+
+		count, err := q.GetCountDelegatorChangeHistory(ctx, GetCountDelegatorChangeHistoryParams{
+			DelegatorAddress: delegatorAddress,
+			JobName:          "hourly_collect",
+			SynthesizeExits:  true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("exits are not rebuilt in cdc storage", func(t *testing.T) {
+		history, err := q.GetDelegatorChangeHistory(ctx, GetDelegatorChangeHistoryParams{
+			DelegatorAddress: delegatorAddress,
+			JobName:          "hourly_collect",
+			SortBy:           "date",
+			Limit:            10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+	})
+}
+
+// TestDailyAggregatesLeavingDelegator runs against the database of DB_CONN_STRING, which it drops every table of, and is skipped without one.
+func TestDailyAggregatesLeavingDelegator(t *testing.T) {
+	ctx := context.Background()
+	config, db, m := newTestMigrate(t)
+	require.NoError(t, m.Up())
+	pool := utils.ConnectDBPool(config.DBConnString)
+	defer pool.Close()
+	q := New(pool)
+
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	firstRun := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	runs := []time.Time{firstRun, firstRun.Add(time.Hour)}
+	_, err := q.CreateDelegationSnapshotPartitions(ctx, CreateDelegationSnapshotPartitionsParams{FromMonth: firstRun, MonthCount: 2})
+	require.NoError(t, err)
+
+	// Full storage writes no zero row for cosmos1b, which leaves before the second run
+	for i, run := range runs {
+		_, err := q.CreateSchedulerRun(ctx, CreateSchedulerRunParams{
+			JobName:          "hourly_collect",
+			ValidatorAddress: validatorAddress,
+			Timestamp:        run,
+			IsCheckpoint:     true,
+		})
+		require.NoError(t, err)
+
+		delegatorAddresses := []string{"cosmos1a", "cosmos1b"}
+		if i == 1 {
+			delegatorAddresses = delegatorAddresses[:1]
+		}
+		for _, delegatorAddress := range delegatorAddresses {
+			_, err = db.ExecContext(ctx, `INSERT INTO delegation_snapshots (validator_address, delegator_address, amount_uatom, change_uatom, timestamp)
+				VALUES ($1, $2, 100, 0, $3)`, validatorAddress, delegatorAddress, run)
+			require.NoError(t, err)
+		}
+	}
+
+	snapshots, err := q.GetLatestDelegationSnapshot(ctx, GetLatestDelegationSnapshotParams{
+		LatestRunOnly: true,
+		JobName:       "hourly_collect",
+	})
+	require.NoError(t, err)
+	for _, snapshot := range snapshots {
+		_, err = q.CreateDailyAggregate(ctx, CreateDailyAggregateParams{
+			ValidatorAddress: snapshot.ValidatorAddress,
+			DelegatorAddress: snapshot.DelegatorAddress,
+			Date:             date,
+			TotalAmount:      snapshot.AmountUatom,
+		})
+		require.NoError(t, err)
+	}
+	_, err = q.CreateDailyConcentrationMetrics(ctx, date)
+	require.NoError(t, err)
+
+	t.Run("delegator who left is not aggregated", func(t *testing.T) {
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, "cosmos1a", snapshots[0].DelegatorAddress)
+
+		var count int
+		err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM daily_aggregates WHERE validator_address = $1 AND date = $2`, validatorAddress, date).
+			Scan(&count)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("delegator who left does not count in concentration", func(t *testing.T) {
+		metrics, err := q.GetDailyConcentrationMetricByValidator(ctx, GetDailyConcentrationMetricByValidatorParams{
+			ValidatorAddress: validatorAddress,
+			Limit:            10,
+		})
+		assert.NoError(t, err)
+		require.Len(t, metrics, 1)
+		assert.Equal(t, int64(1), metrics[0].DelegatorCount)
+		assert.Equal(t, int64(100), metrics[0].TotalAmount)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	pgx "github.com/jackc/pgx/v5"
 	pgconn "github.com/jackc/pgx/v5/pgconn"
+	pgxpool "github.com/jackc/pgx/v5/pgxpool"
 )
 
 // MockPGXPool is a mock of PGXPool interface.
@@ -36,6 +37,21 @@ func (m *MockPGXPool) EXPECT() *MockPGXPoolMockRecorder {
 	return m.recorder
 }
 
+// Acquire mocks base method.
+func (m *MockPGXPool) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx)
+	ret0, _ := ret[0].(*pgxpool.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockPGXPoolMockRecorder) Acquire(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockPGXPool)(nil).Acquire), ctx)
+}
+
 // Begin mocks base method.
 func (m *MockPGXPool) Begin(ctx context.Context) (pgx.Tx, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +94,21 @@ func (mr *MockPGXPoolMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPGXPool)(nil).Close))
 }
 
+// CopyFrom mocks base method.
+func (m *MockPGXPool) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFrom", ctx, tableName, columnNames, rowSrc)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyFrom indicates an expected call of CopyFrom.
+func (mr *MockPGXPoolMockRecorder) CopyFrom(ctx, tableName, columnNames, rowSrc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFrom", reflect.TypeOf((*MockPGXPool)(nil).CopyFrom), ctx, tableName, columnNames, rowSrc)
+}
+
 // Exec mocks base method.
 func (m *MockPGXPool) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +129,20 @@ func (mr *MockPGXPoolMockRecorder) Exec(ctx, sql interface{}, arguments ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockPGXPool)(nil).Exec), varargs...)
 }
 
+// Ping mocks base method.
+func (m *MockPGXPool) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockPGXPoolMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockPGXPool)(nil).Ping), ctx)
+}
+
 // Query mocks base method.
 func (m *MockPGXPool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
 	m.ctrl.T.Helper()
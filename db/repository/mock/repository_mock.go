@@ -7,6 +7,7 @@ package mockrepo
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	repository "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
 	utils "github.com/gadhittana01/cosmos-validation-tracking/utils"
@@ -15,6 +16,165 @@ import (
 	v5 "github.com/jackc/pgx/v5"
 )
 
+// MockExporter is a mock of Exporter interface.
+type MockExporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockExporterMockRecorder
+}
+
+// MockExporterMockRecorder is the mock recorder for MockExporter.
+type MockExporterMockRecorder struct {
+	mock *MockExporter
+}
+
+// NewMockExporter creates a new mock instance.
+func NewMockExporter(ctrl *gomock.Controller) *MockExporter {
+	mock := &MockExporter{ctrl: ctrl}
+	mock.recorder = &MockExporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExporter) EXPECT() *MockExporterMockRecorder {
+	return m.recorder
+}
+
+// ExportDailyAggregateByValidator mocks base method.
+func (m *MockExporter) ExportDailyAggregateByValidator(ctx context.Context, validatorAddress string, fn func(repository.GetDailyAggregateByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDailyAggregateByValidator", ctx, validatorAddress, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDailyAggregateByValidator indicates an expected call of ExportDailyAggregateByValidator.
+func (mr *MockExporterMockRecorder) ExportDailyAggregateByValidator(ctx, validatorAddress, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDailyAggregateByValidator", reflect.TypeOf((*MockExporter)(nil).ExportDailyAggregateByValidator), ctx, validatorAddress, fn)
+}
+
+// ExportDailyAggregateInTimezoneByValidator mocks base method.
+func (m *MockExporter) ExportDailyAggregateInTimezoneByValidator(ctx context.Context, arg repository.GetCountDailyAggregateInTimezoneByValidatorParams, fn func(repository.GetDailyAggregateInTimezoneByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDailyAggregateInTimezoneByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDailyAggregateInTimezoneByValidator indicates an expected call of ExportDailyAggregateInTimezoneByValidator.
+func (mr *MockExporterMockRecorder) ExportDailyAggregateInTimezoneByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDailyAggregateInTimezoneByValidator", reflect.TypeOf((*MockExporter)(nil).ExportDailyAggregateInTimezoneByValidator), ctx, arg, fn)
+}
+
+// ExportDelegationSnapshotByValidator mocks base method.
+func (m *MockExporter) ExportDelegationSnapshotByValidator(ctx context.Context, validatorAddress string, fn func(repository.GetDelegationSnapshotByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDelegationSnapshotByValidator", ctx, validatorAddress, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDelegationSnapshotByValidator indicates an expected call of ExportDelegationSnapshotByValidator.
+func (mr *MockExporterMockRecorder) ExportDelegationSnapshotByValidator(ctx, validatorAddress, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDelegationSnapshotByValidator", reflect.TypeOf((*MockExporter)(nil).ExportDelegationSnapshotByValidator), ctx, validatorAddress, fn)
+}
+
+// ExportDelegatorHistoryByValidator mocks base method.
+func (m *MockExporter) ExportDelegatorHistoryByValidator(ctx context.Context, arg repository.ExportDelegatorHistoryByValidatorParams, fn func(repository.GetDelegatorHistoryByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDelegatorHistoryByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDelegatorHistoryByValidator indicates an expected call of ExportDelegatorHistoryByValidator.
+func (mr *MockExporterMockRecorder) ExportDelegatorHistoryByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDelegatorHistoryByValidator", reflect.TypeOf((*MockExporter)(nil).ExportDelegatorHistoryByValidator), ctx, arg, fn)
+}
+
+// ExportReconstructedDelegationSnapshotByValidator mocks base method.
+func (m *MockExporter) ExportReconstructedDelegationSnapshotByValidator(ctx context.Context, arg repository.GetCountReconstructedDelegationSnapshotByValidatorParams, fn func(repository.GetReconstructedDelegationSnapshotByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReconstructedDelegationSnapshotByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReconstructedDelegationSnapshotByValidator indicates an expected call of ExportReconstructedDelegationSnapshotByValidator.
+func (mr *MockExporterMockRecorder) ExportReconstructedDelegationSnapshotByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReconstructedDelegationSnapshotByValidator", reflect.TypeOf((*MockExporter)(nil).ExportReconstructedDelegationSnapshotByValidator), ctx, arg, fn)
+}
+
+// ExportReconstructedDelegatorHistoryByValidator mocks base method.
+func (m *MockExporter) ExportReconstructedDelegatorHistoryByValidator(ctx context.Context, arg repository.ExportReconstructedDelegatorHistoryByValidatorParams, fn func(repository.GetReconstructedDelegatorHistoryByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReconstructedDelegatorHistoryByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReconstructedDelegatorHistoryByValidator indicates an expected call of ExportReconstructedDelegatorHistoryByValidator.
+func (mr *MockExporterMockRecorder) ExportReconstructedDelegatorHistoryByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReconstructedDelegatorHistoryByValidator", reflect.TypeOf((*MockExporter)(nil).ExportReconstructedDelegatorHistoryByValidator), ctx, arg, fn)
+}
+
+// MockHealthChecker is a mock of HealthChecker interface.
+type MockHealthChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckerMockRecorder
+}
+
+// MockHealthCheckerMockRecorder is the mock recorder for MockHealthChecker.
+type MockHealthCheckerMockRecorder struct {
+	mock *MockHealthChecker
+}
+
+// NewMockHealthChecker creates a new mock instance.
+func NewMockHealthChecker(ctrl *gomock.Controller) *MockHealthChecker {
+	mock := &MockHealthChecker{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthChecker) EXPECT() *MockHealthCheckerMockRecorder {
+	return m.recorder
+}
+
+// GetMigrationVersion mocks base method.
+func (m *MockHealthChecker) GetMigrationVersion(ctx context.Context) (repository.GetMigrationVersionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationVersion", ctx)
+	ret0, _ := ret[0].(repository.GetMigrationVersionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrationVersion indicates an expected call of GetMigrationVersion.
+func (mr *MockHealthCheckerMockRecorder) GetMigrationVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationVersion", reflect.TypeOf((*MockHealthChecker)(nil).GetMigrationVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockHealthChecker) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockHealthCheckerMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockHealthChecker)(nil).Ping), ctx)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -38,6 +198,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateAPIKey mocks base method.
+func (m *MockRepository) CreateAPIKey(ctx context.Context, arg repository.CreateAPIKeyParams) (repository.CreateAPIKeyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, arg)
+	ret0, _ := ret[0].(repository.CreateAPIKeyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockRepositoryMockRecorder) CreateAPIKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockRepository)(nil).CreateAPIKey), ctx, arg)
+}
+
 // CreateDailyAggregate mocks base method.
 func (m *MockRepository) CreateDailyAggregate(ctx context.Context, arg repository.CreateDailyAggregateParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +228,21 @@ func (mr *MockRepositoryMockRecorder) CreateDailyAggregate(ctx, arg interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyAggregate", reflect.TypeOf((*MockRepository)(nil).CreateDailyAggregate), ctx, arg)
 }
 
+// CreateDailyConcentrationMetrics mocks base method.
+func (m *MockRepository) CreateDailyConcentrationMetrics(ctx context.Context, date time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDailyConcentrationMetrics", ctx, date)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDailyConcentrationMetrics indicates an expected call of CreateDailyConcentrationMetrics.
+func (mr *MockRepositoryMockRecorder) CreateDailyConcentrationMetrics(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyConcentrationMetrics", reflect.TypeOf((*MockRepository)(nil).CreateDailyConcentrationMetrics), ctx, date)
+}
+
 // CreateDelegationSnapshot mocks base method.
 func (m *MockRepository) CreateDelegationSnapshot(ctx context.Context, arg repository.CreateDelegationSnapshotParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -68,138 +258,970 @@ func (mr *MockRepositoryMockRecorder) CreateDelegationSnapshot(ctx, arg interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegationSnapshot", reflect.TypeOf((*MockRepository)(nil).CreateDelegationSnapshot), ctx, arg)
 }
 
-// GetCountDailyAggregateByValidator mocks base method.
-func (m *MockRepository) GetCountDailyAggregateByValidator(ctx context.Context, validatorAddress string) (int64, error) {
+// CreateDelegationSnapshotPartitions mocks base method.
+func (m *MockRepository) CreateDelegationSnapshotPartitions(ctx context.Context, arg repository.CreateDelegationSnapshotPartitionsParams) (int32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountDailyAggregateByValidator", ctx, validatorAddress)
+	ret := m.ctrl.Call(m, "CreateDelegationSnapshotPartitions", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelegationSnapshotPartitions indicates an expected call of CreateDelegationSnapshotPartitions.
+func (mr *MockRepositoryMockRecorder) CreateDelegationSnapshotPartitions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegationSnapshotPartitions", reflect.TypeOf((*MockRepository)(nil).CreateDelegationSnapshotPartitions), ctx, arg)
+}
+
+// CreateDelegationSnapshots mocks base method.
+func (m *MockRepository) CreateDelegationSnapshots(ctx context.Context, arg []repository.CreateDelegationSnapshotsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelegationSnapshots", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCountDailyAggregateByValidator indicates an expected call of GetCountDailyAggregateByValidator.
-func (mr *MockRepositoryMockRecorder) GetCountDailyAggregateByValidator(ctx, validatorAddress interface{}) *gomock.Call {
+// CreateDelegationSnapshots indicates an expected call of CreateDelegationSnapshots.
+func (mr *MockRepositoryMockRecorder) CreateDelegationSnapshots(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDailyAggregateByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDailyAggregateByValidator), ctx, validatorAddress)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegationSnapshots", reflect.TypeOf((*MockRepository)(nil).CreateDelegationSnapshots), ctx, arg)
 }
 
-// GetCountDelegationSnapshotByValidator mocks base method.
-func (m *MockRepository) GetCountDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) (int64, error) {
+// CreateDelegatorEvents mocks base method.
+func (m *MockRepository) CreateDelegatorEvents(ctx context.Context, arg repository.CreateDelegatorEventsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountDelegationSnapshotByValidator", ctx, validatorAddress)
+	ret := m.ctrl.Call(m, "CreateDelegatorEvents", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCountDelegationSnapshotByValidator indicates an expected call of GetCountDelegationSnapshotByValidator.
-func (mr *MockRepositoryMockRecorder) GetCountDelegationSnapshotByValidator(ctx, validatorAddress interface{}) *gomock.Call {
+// CreateDelegatorEvents indicates an expected call of CreateDelegatorEvents.
+func (mr *MockRepositoryMockRecorder) CreateDelegatorEvents(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegationSnapshotByValidator), ctx, validatorAddress)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegatorEvents", reflect.TypeOf((*MockRepository)(nil).CreateDelegatorEvents), ctx, arg)
 }
 
-// GetCountDelegatorHistoryByValidator mocks base method.
-func (m *MockRepository) GetCountDelegatorHistoryByValidator(ctx context.Context, arg repository.GetCountDelegatorHistoryByValidatorParams) (int64, error) {
+// CreateDownsampledDailyAggregates mocks base method.
+func (m *MockRepository) CreateDownsampledDailyAggregates(ctx context.Context, arg repository.CreateDownsampledDailyAggregatesParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountDelegatorHistoryByValidator", ctx, arg)
+	ret := m.ctrl.Call(m, "CreateDownsampledDailyAggregates", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCountDelegatorHistoryByValidator indicates an expected call of GetCountDelegatorHistoryByValidator.
-func (mr *MockRepositoryMockRecorder) GetCountDelegatorHistoryByValidator(ctx, arg interface{}) *gomock.Call {
+// CreateDownsampledDailyAggregates indicates an expected call of CreateDownsampledDailyAggregates.
+func (mr *MockRepositoryMockRecorder) CreateDownsampledDailyAggregates(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegatorHistoryByValidator), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDownsampledDailyAggregates", reflect.TypeOf((*MockRepository)(nil).CreateDownsampledDailyAggregates), ctx, arg)
 }
 
-// GetDB mocks base method.
-func (m *MockRepository) GetDB() utils.PGXPool {
+// CreateExportManifest mocks base method.
+func (m *MockRepository) CreateExportManifest(ctx context.Context, arg repository.CreateExportManifestParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDB")
-	ret0, _ := ret[0].(utils.PGXPool)
+	ret := m.ctrl.Call(m, "CreateExportManifest", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExportManifest indicates an expected call of CreateExportManifest.
+func (mr *MockRepositoryMockRecorder) CreateExportManifest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExportManifest", reflect.TypeOf((*MockRepository)(nil).CreateExportManifest), ctx, arg)
+}
+
+// CreateSchedulerRun mocks base method.
+func (m *MockRepository) CreateSchedulerRun(ctx context.Context, arg repository.CreateSchedulerRunParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedulerRun", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedulerRun indicates an expected call of CreateSchedulerRun.
+func (mr *MockRepositoryMockRecorder) CreateSchedulerRun(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedulerRun", reflect.TypeOf((*MockRepository)(nil).CreateSchedulerRun), ctx, arg)
+}
+
+// CreateValidatorCommission mocks base method.
+func (m *MockRepository) CreateValidatorCommission(ctx context.Context, arg repository.CreateValidatorCommissionParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateValidatorCommission", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateValidatorCommission indicates an expected call of CreateValidatorCommission.
+func (mr *MockRepositoryMockRecorder) CreateValidatorCommission(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValidatorCommission", reflect.TypeOf((*MockRepository)(nil).CreateValidatorCommission), ctx, arg)
+}
+
+// DeleteDelegationSnapshotsBefore mocks base method.
+func (m *MockRepository) DeleteDelegationSnapshotsBefore(ctx context.Context, arg repository.DeleteDelegationSnapshotsBeforeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDelegationSnapshotsBefore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDelegationSnapshotsBefore indicates an expected call of DeleteDelegationSnapshotsBefore.
+func (mr *MockRepositoryMockRecorder) DeleteDelegationSnapshotsBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDelegationSnapshotsBefore", reflect.TypeOf((*MockRepository)(nil).DeleteDelegationSnapshotsBefore), ctx, arg)
+}
+
+// DeleteSchedulerRunsBefore mocks base method.
+func (m *MockRepository) DeleteSchedulerRunsBefore(ctx context.Context, arg repository.DeleteSchedulerRunsBeforeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedulerRunsBefore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSchedulerRunsBefore indicates an expected call of DeleteSchedulerRunsBefore.
+func (mr *MockRepositoryMockRecorder) DeleteSchedulerRunsBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedulerRunsBefore", reflect.TypeOf((*MockRepository)(nil).DeleteSchedulerRunsBefore), ctx, arg)
+}
+
+// ExportDailyAggregateByValidator mocks base method.
+func (m *MockRepository) ExportDailyAggregateByValidator(ctx context.Context, validatorAddress string, fn func(repository.GetDailyAggregateByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDailyAggregateByValidator", ctx, validatorAddress, fn)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetDB indicates an expected call of GetDB.
-func (mr *MockRepositoryMockRecorder) GetDB() *gomock.Call {
+// ExportDailyAggregateByValidator indicates an expected call of ExportDailyAggregateByValidator.
+func (mr *MockRepositoryMockRecorder) ExportDailyAggregateByValidator(ctx, validatorAddress, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockRepository)(nil).GetDB))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDailyAggregateByValidator", reflect.TypeOf((*MockRepository)(nil).ExportDailyAggregateByValidator), ctx, validatorAddress, fn)
 }
 
-// GetDailyAggregateByValidator mocks base method.
-func (m *MockRepository) GetDailyAggregateByValidator(ctx context.Context, arg repository.GetDailyAggregateByValidatorParams) ([]repository.GetDailyAggregateByValidatorRow, error) {
+// ExportDailyAggregateInTimezoneByValidator mocks base method.
+func (m *MockRepository) ExportDailyAggregateInTimezoneByValidator(ctx context.Context, arg repository.GetCountDailyAggregateInTimezoneByValidatorParams, fn func(repository.GetDailyAggregateInTimezoneByValidatorRow) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDailyAggregateByValidator", ctx, arg)
-	ret0, _ := ret[0].([]repository.GetDailyAggregateByValidatorRow)
+	ret := m.ctrl.Call(m, "ExportDailyAggregateInTimezoneByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDailyAggregateInTimezoneByValidator indicates an expected call of ExportDailyAggregateInTimezoneByValidator.
+func (mr *MockRepositoryMockRecorder) ExportDailyAggregateInTimezoneByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDailyAggregateInTimezoneByValidator", reflect.TypeOf((*MockRepository)(nil).ExportDailyAggregateInTimezoneByValidator), ctx, arg, fn)
+}
+
+// ExportDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) ExportDelegationSnapshotByValidator(ctx context.Context, validatorAddress string, fn func(repository.GetDelegationSnapshotByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDelegationSnapshotByValidator", ctx, validatorAddress, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDelegationSnapshotByValidator indicates an expected call of ExportDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) ExportDelegationSnapshotByValidator(ctx, validatorAddress, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).ExportDelegationSnapshotByValidator), ctx, validatorAddress, fn)
+}
+
+// ExportDelegatorHistoryByValidator mocks base method.
+func (m *MockRepository) ExportDelegatorHistoryByValidator(ctx context.Context, arg repository.ExportDelegatorHistoryByValidatorParams, fn func(repository.GetDelegatorHistoryByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDelegatorHistoryByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDelegatorHistoryByValidator indicates an expected call of ExportDelegatorHistoryByValidator.
+func (mr *MockRepositoryMockRecorder) ExportDelegatorHistoryByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).ExportDelegatorHistoryByValidator), ctx, arg, fn)
+}
+
+// ExportReconstructedDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) ExportReconstructedDelegationSnapshotByValidator(ctx context.Context, arg repository.GetCountReconstructedDelegationSnapshotByValidatorParams, fn func(repository.GetReconstructedDelegationSnapshotByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReconstructedDelegationSnapshotByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReconstructedDelegationSnapshotByValidator indicates an expected call of ExportReconstructedDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) ExportReconstructedDelegationSnapshotByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReconstructedDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).ExportReconstructedDelegationSnapshotByValidator), ctx, arg, fn)
+}
+
+// ExportReconstructedDelegatorHistoryByValidator mocks base method.
+func (m *MockRepository) ExportReconstructedDelegatorHistoryByValidator(ctx context.Context, arg repository.ExportReconstructedDelegatorHistoryByValidatorParams, fn func(repository.GetReconstructedDelegatorHistoryByValidatorRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReconstructedDelegatorHistoryByValidator", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReconstructedDelegatorHistoryByValidator indicates an expected call of ExportReconstructedDelegatorHistoryByValidator.
+func (mr *MockRepositoryMockRecorder) ExportReconstructedDelegatorHistoryByValidator(ctx, arg, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReconstructedDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).ExportReconstructedDelegatorHistoryByValidator), ctx, arg, fn)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockRepository) GetAPIKeys(ctx context.Context) ([]repository.GetAPIKeysRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx)
+	ret0, _ := ret[0].([]repository.GetAPIKeysRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDailyAggregateByValidator indicates an expected call of GetDailyAggregateByValidator.
-func (mr *MockRepositoryMockRecorder) GetDailyAggregateByValidator(ctx, arg interface{}) *gomock.Call {
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockRepositoryMockRecorder) GetAPIKeys(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyAggregateByValidator", reflect.TypeOf((*MockRepository)(nil).GetDailyAggregateByValidator), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockRepository)(nil).GetAPIKeys), ctx)
 }
 
-// GetDelegationSnapshotByValidator mocks base method.
-func (m *MockRepository) GetDelegationSnapshotByValidator(ctx context.Context, arg repository.GetDelegationSnapshotByValidatorParams) ([]repository.GetDelegationSnapshotByValidatorRow, error) {
+// GetActiveAPIKeyByHash mocks base method.
+func (m *MockRepository) GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (repository.GetActiveAPIKeyByHashRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDelegationSnapshotByValidator", ctx, arg)
-	ret0, _ := ret[0].([]repository.GetDelegationSnapshotByValidatorRow)
+	ret := m.ctrl.Call(m, "GetActiveAPIKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(repository.GetActiveAPIKeyByHashRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDelegationSnapshotByValidator indicates an expected call of GetDelegationSnapshotByValidator.
-func (mr *MockRepositoryMockRecorder) GetDelegationSnapshotByValidator(ctx, arg interface{}) *gomock.Call {
+// GetActiveAPIKeyByHash indicates an expected call of GetActiveAPIKeyByHash.
+func (mr *MockRepositoryMockRecorder) GetActiveAPIKeyByHash(ctx, keyHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegationSnapshotByValidator), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveAPIKeyByHash", reflect.TypeOf((*MockRepository)(nil).GetActiveAPIKeyByHash), ctx, keyHash)
 }
 
-// GetDelegationSnapshotByValidatorAndDelegator mocks base method.
-func (m *MockRepository) GetDelegationSnapshotByValidatorAndDelegator(ctx context.Context, arg repository.GetDelegationSnapshotByValidatorAndDelegatorParams) (repository.GetDelegationSnapshotByValidatorAndDelegatorRow, error) {
+// GetCountDailyAggregateByValidator mocks base method.
+func (m *MockRepository) GetCountDailyAggregateByValidator(ctx context.Context, validatorAddress string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDelegationSnapshotByValidatorAndDelegator", ctx, arg)
-	ret0, _ := ret[0].(repository.GetDelegationSnapshotByValidatorAndDelegatorRow)
+	ret := m.ctrl.Call(m, "GetCountDailyAggregateByValidator", ctx, validatorAddress)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDelegationSnapshotByValidatorAndDelegator indicates an expected call of GetDelegationSnapshotByValidatorAndDelegator.
-func (mr *MockRepositoryMockRecorder) GetDelegationSnapshotByValidatorAndDelegator(ctx, arg interface{}) *gomock.Call {
+// GetCountDailyAggregateByValidator indicates an expected call of GetCountDailyAggregateByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDailyAggregateByValidator(ctx, validatorAddress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationSnapshotByValidatorAndDelegator", reflect.TypeOf((*MockRepository)(nil).GetDelegationSnapshotByValidatorAndDelegator), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDailyAggregateByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDailyAggregateByValidator), ctx, validatorAddress)
 }
 
-// GetDelegatorHistoryByValidator mocks base method.
-func (m *MockRepository) GetDelegatorHistoryByValidator(ctx context.Context, arg repository.GetDelegatorHistoryByValidatorParams) ([]repository.GetDelegatorHistoryByValidatorRow, error) {
+// GetCountDailyAggregateInTimezoneByValidator mocks base method.
+func (m *MockRepository) GetCountDailyAggregateInTimezoneByValidator(ctx context.Context, arg repository.GetCountDailyAggregateInTimezoneByValidatorParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDelegatorHistoryByValidator", ctx, arg)
-	ret0, _ := ret[0].([]repository.GetDelegatorHistoryByValidatorRow)
+	ret := m.ctrl.Call(m, "GetCountDailyAggregateInTimezoneByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDelegatorHistoryByValidator indicates an expected call of GetDelegatorHistoryByValidator.
-func (mr *MockRepositoryMockRecorder) GetDelegatorHistoryByValidator(ctx, arg interface{}) *gomock.Call {
+// GetCountDailyAggregateInTimezoneByValidator indicates an expected call of GetCountDailyAggregateInTimezoneByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDailyAggregateInTimezoneByValidator(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegatorHistoryByValidator), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDailyAggregateInTimezoneByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDailyAggregateInTimezoneByValidator), ctx, arg)
 }
 
-// GetLatestDelegationSnapshot mocks base method.
-func (m *MockRepository) GetLatestDelegationSnapshot(ctx context.Context) ([]repository.GetLatestDelegationSnapshotRow, error) {
+// GetCountDailyConcentrationMetricByValidator mocks base method.
+func (m *MockRepository) GetCountDailyConcentrationMetricByValidator(ctx context.Context, arg repository.GetCountDailyConcentrationMetricByValidatorParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestDelegationSnapshot", ctx)
-	ret0, _ := ret[0].([]repository.GetLatestDelegationSnapshotRow)
+	ret := m.ctrl.Call(m, "GetCountDailyConcentrationMetricByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLatestDelegationSnapshot indicates an expected call of GetLatestDelegationSnapshot.
-func (mr *MockRepositoryMockRecorder) GetLatestDelegationSnapshot(ctx interface{}) *gomock.Call {
+// GetCountDailyConcentrationMetricByValidator indicates an expected call of GetCountDailyConcentrationMetricByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDailyConcentrationMetricByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDailyConcentrationMetricByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDailyConcentrationMetricByValidator), ctx, arg)
+}
+
+// GetCountDelegationAsOfByValidator mocks base method.
+func (m *MockRepository) GetCountDelegationAsOfByValidator(ctx context.Context, arg repository.GetCountDelegationAsOfByValidatorParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegationAsOfByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegationAsOfByValidator indicates an expected call of GetCountDelegationAsOfByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDelegationAsOfByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegationAsOfByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegationAsOfByValidator), ctx, arg)
+}
+
+// GetCountDelegationDiffByValidator mocks base method.
+func (m *MockRepository) GetCountDelegationDiffByValidator(ctx context.Context, arg repository.GetCountDelegationDiffByValidatorParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegationDiffByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegationDiffByValidator indicates an expected call of GetCountDelegationDiffByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDelegationDiffByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegationDiffByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegationDiffByValidator), ctx, arg)
+}
+
+// GetCountDelegationSnapshotBefore mocks base method.
+func (m *MockRepository) GetCountDelegationSnapshotBefore(ctx context.Context, arg repository.GetCountDelegationSnapshotBeforeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegationSnapshotBefore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegationSnapshotBefore indicates an expected call of GetCountDelegationSnapshotBefore.
+func (mr *MockRepositoryMockRecorder) GetCountDelegationSnapshotBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegationSnapshotBefore", reflect.TypeOf((*MockRepository)(nil).GetCountDelegationSnapshotBefore), ctx, arg)
+}
+
+// GetCountDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) GetCountDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegationSnapshotByValidator", ctx, validatorAddress)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegationSnapshotByValidator indicates an expected call of GetCountDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDelegationSnapshotByValidator(ctx, validatorAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegationSnapshotByValidator), ctx, validatorAddress)
+}
+
+// GetCountDelegatorChangeHistory mocks base method.
+func (m *MockRepository) GetCountDelegatorChangeHistory(ctx context.Context, arg repository.GetCountDelegatorChangeHistoryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegatorChangeHistory", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegatorChangeHistory indicates an expected call of GetCountDelegatorChangeHistory.
+func (mr *MockRepositoryMockRecorder) GetCountDelegatorChangeHistory(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegatorChangeHistory", reflect.TypeOf((*MockRepository)(nil).GetCountDelegatorChangeHistory), ctx, arg)
+}
+
+// GetCountDelegatorEventByValidator mocks base method.
+func (m *MockRepository) GetCountDelegatorEventByValidator(ctx context.Context, arg repository.GetCountDelegatorEventByValidatorParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegatorEventByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegatorEventByValidator indicates an expected call of GetCountDelegatorEventByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDelegatorEventByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegatorEventByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegatorEventByValidator), ctx, arg)
+}
+
+// GetCountDelegatorHistoryByValidator mocks base method.
+func (m *MockRepository) GetCountDelegatorHistoryByValidator(ctx context.Context, arg repository.GetCountDelegatorHistoryByValidatorParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountDelegatorHistoryByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountDelegatorHistoryByValidator indicates an expected call of GetCountDelegatorHistoryByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountDelegatorHistoryByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountDelegatorHistoryByValidator), ctx, arg)
+}
+
+// GetCountReconstructedDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) GetCountReconstructedDelegationSnapshotByValidator(ctx context.Context, arg repository.GetCountReconstructedDelegationSnapshotByValidatorParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountReconstructedDelegationSnapshotByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountReconstructedDelegationSnapshotByValidator indicates an expected call of GetCountReconstructedDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountReconstructedDelegationSnapshotByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountReconstructedDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountReconstructedDelegationSnapshotByValidator), ctx, arg)
+}
+
+// GetCountReconstructedDelegatorHistoryByValidator mocks base method.
+func (m *MockRepository) GetCountReconstructedDelegatorHistoryByValidator(ctx context.Context, arg repository.GetCountReconstructedDelegatorHistoryByValidatorParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountReconstructedDelegatorHistoryByValidator", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountReconstructedDelegatorHistoryByValidator indicates an expected call of GetCountReconstructedDelegatorHistoryByValidator.
+func (mr *MockRepositoryMockRecorder) GetCountReconstructedDelegatorHistoryByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountReconstructedDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).GetCountReconstructedDelegatorHistoryByValidator), ctx, arg)
+}
+
+// GetCurrentDelegationByDelegator mocks base method.
+func (m *MockRepository) GetCurrentDelegationByDelegator(ctx context.Context, arg repository.GetCurrentDelegationByDelegatorParams) ([]repository.GetCurrentDelegationByDelegatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentDelegationByDelegator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetCurrentDelegationByDelegatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentDelegationByDelegator indicates an expected call of GetCurrentDelegationByDelegator.
+func (mr *MockRepositoryMockRecorder) GetCurrentDelegationByDelegator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentDelegationByDelegator", reflect.TypeOf((*MockRepository)(nil).GetCurrentDelegationByDelegator), ctx, arg)
+}
+
+// GetCurrentDelegatorDistributionByValidator mocks base method.
+func (m *MockRepository) GetCurrentDelegatorDistributionByValidator(ctx context.Context, arg repository.GetCurrentDelegatorDistributionByValidatorParams) ([]repository.GetCurrentDelegatorDistributionByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentDelegatorDistributionByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetCurrentDelegatorDistributionByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentDelegatorDistributionByValidator indicates an expected call of GetCurrentDelegatorDistributionByValidator.
+func (mr *MockRepositoryMockRecorder) GetCurrentDelegatorDistributionByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentDelegatorDistributionByValidator", reflect.TypeOf((*MockRepository)(nil).GetCurrentDelegatorDistributionByValidator), ctx, arg)
+}
+
+// GetDB mocks base method.
+func (m *MockRepository) GetDB() utils.PGXPool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(utils.PGXPool)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockRepository)(nil).GetDB))
+}
+
+// GetDailyAggregateByValidator mocks base method.
+func (m *MockRepository) GetDailyAggregateByValidator(ctx context.Context, arg repository.GetDailyAggregateByValidatorParams) ([]repository.GetDailyAggregateByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyAggregateByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyAggregateByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyAggregateByValidator indicates an expected call of GetDailyAggregateByValidator.
+func (mr *MockRepositoryMockRecorder) GetDailyAggregateByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyAggregateByValidator", reflect.TypeOf((*MockRepository)(nil).GetDailyAggregateByValidator), ctx, arg)
+}
+
+// GetDailyAggregateByValidatorAndDate mocks base method.
+func (m *MockRepository) GetDailyAggregateByValidatorAndDate(ctx context.Context, arg repository.GetDailyAggregateByValidatorAndDateParams) ([]repository.GetDailyAggregateByValidatorAndDateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyAggregateByValidatorAndDate", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyAggregateByValidatorAndDateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyAggregateByValidatorAndDate indicates an expected call of GetDailyAggregateByValidatorAndDate.
+func (mr *MockRepositoryMockRecorder) GetDailyAggregateByValidatorAndDate(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyAggregateByValidatorAndDate", reflect.TypeOf((*MockRepository)(nil).GetDailyAggregateByValidatorAndDate), ctx, arg)
+}
+
+// GetDailyAggregateInTimezoneByValidator mocks base method.
+func (m *MockRepository) GetDailyAggregateInTimezoneByValidator(ctx context.Context, arg repository.GetDailyAggregateInTimezoneByValidatorParams) ([]repository.GetDailyAggregateInTimezoneByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyAggregateInTimezoneByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyAggregateInTimezoneByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyAggregateInTimezoneByValidator indicates an expected call of GetDailyAggregateInTimezoneByValidator.
+func (mr *MockRepositoryMockRecorder) GetDailyAggregateInTimezoneByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyAggregateInTimezoneByValidator", reflect.TypeOf((*MockRepository)(nil).GetDailyAggregateInTimezoneByValidator), ctx, arg)
+}
+
+// GetDailyConcentrationMetricByValidator mocks base method.
+func (m *MockRepository) GetDailyConcentrationMetricByValidator(ctx context.Context, arg repository.GetDailyConcentrationMetricByValidatorParams) ([]repository.GetDailyConcentrationMetricByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyConcentrationMetricByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyConcentrationMetricByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyConcentrationMetricByValidator indicates an expected call of GetDailyConcentrationMetricByValidator.
+func (mr *MockRepositoryMockRecorder) GetDailyConcentrationMetricByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyConcentrationMetricByValidator", reflect.TypeOf((*MockRepository)(nil).GetDailyConcentrationMetricByValidator), ctx, arg)
+}
+
+// GetDailyDelegatorDistributionByValidator mocks base method.
+func (m *MockRepository) GetDailyDelegatorDistributionByValidator(ctx context.Context, arg repository.GetDailyDelegatorDistributionByValidatorParams) ([]repository.GetDailyDelegatorDistributionByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyDelegatorDistributionByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyDelegatorDistributionByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyDelegatorDistributionByValidator indicates an expected call of GetDailyDelegatorDistributionByValidator.
+func (mr *MockRepositoryMockRecorder) GetDailyDelegatorDistributionByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyDelegatorDistributionByValidator", reflect.TypeOf((*MockRepository)(nil).GetDailyDelegatorDistributionByValidator), ctx, arg)
+}
+
+// GetDailyDelegatorEventCountByValidator mocks base method.
+func (m *MockRepository) GetDailyDelegatorEventCountByValidator(ctx context.Context, arg repository.GetDailyDelegatorEventCountByValidatorParams) ([]repository.GetDailyDelegatorEventCountByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyDelegatorEventCountByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyDelegatorEventCountByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyDelegatorEventCountByValidator indicates an expected call of GetDailyDelegatorEventCountByValidator.
+func (mr *MockRepositoryMockRecorder) GetDailyDelegatorEventCountByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyDelegatorEventCountByValidator", reflect.TypeOf((*MockRepository)(nil).GetDailyDelegatorEventCountByValidator), ctx, arg)
+}
+
+// GetDailyTotalByValidators mocks base method.
+func (m *MockRepository) GetDailyTotalByValidators(ctx context.Context, arg repository.GetDailyTotalByValidatorsParams) ([]repository.GetDailyTotalByValidatorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyTotalByValidators", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDailyTotalByValidatorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyTotalByValidators indicates an expected call of GetDailyTotalByValidators.
+func (mr *MockRepositoryMockRecorder) GetDailyTotalByValidators(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyTotalByValidators", reflect.TypeOf((*MockRepository)(nil).GetDailyTotalByValidators), ctx, arg)
+}
+
+// GetDelegationAsOfByValidator mocks base method.
+func (m *MockRepository) GetDelegationAsOfByValidator(ctx context.Context, arg repository.GetDelegationAsOfByValidatorParams) ([]repository.GetDelegationAsOfByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationAsOfByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegationAsOfByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationAsOfByValidator indicates an expected call of GetDelegationAsOfByValidator.
+func (mr *MockRepositoryMockRecorder) GetDelegationAsOfByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationAsOfByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegationAsOfByValidator), ctx, arg)
+}
+
+// GetDelegationDiffByValidator mocks base method.
+func (m *MockRepository) GetDelegationDiffByValidator(ctx context.Context, arg repository.GetDelegationDiffByValidatorParams) ([]repository.GetDelegationDiffByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationDiffByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegationDiffByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationDiffByValidator indicates an expected call of GetDelegationDiffByValidator.
+func (mr *MockRepositoryMockRecorder) GetDelegationDiffByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationDiffByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegationDiffByValidator), ctx, arg)
+}
+
+// GetDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) GetDelegationSnapshotByValidator(ctx context.Context, arg repository.GetDelegationSnapshotByValidatorParams) ([]repository.GetDelegationSnapshotByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationSnapshotByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegationSnapshotByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationSnapshotByValidator indicates an expected call of GetDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) GetDelegationSnapshotByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegationSnapshotByValidator), ctx, arg)
+}
+
+// GetDelegationSnapshotByValidatorAndDelegator mocks base method.
+func (m *MockRepository) GetDelegationSnapshotByValidatorAndDelegator(ctx context.Context, arg repository.GetDelegationSnapshotByValidatorAndDelegatorParams) (repository.GetDelegationSnapshotByValidatorAndDelegatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationSnapshotByValidatorAndDelegator", ctx, arg)
+	ret0, _ := ret[0].(repository.GetDelegationSnapshotByValidatorAndDelegatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationSnapshotByValidatorAndDelegator indicates an expected call of GetDelegationSnapshotByValidatorAndDelegator.
+func (mr *MockRepositoryMockRecorder) GetDelegationSnapshotByValidatorAndDelegator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationSnapshotByValidatorAndDelegator", reflect.TypeOf((*MockRepository)(nil).GetDelegationSnapshotByValidatorAndDelegator), ctx, arg)
+}
+
+// GetDelegationSnapshotByValidatorAndTimeRange mocks base method.
+func (m *MockRepository) GetDelegationSnapshotByValidatorAndTimeRange(ctx context.Context, arg repository.GetDelegationSnapshotByValidatorAndTimeRangeParams) ([]repository.GetDelegationSnapshotByValidatorAndTimeRangeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationSnapshotByValidatorAndTimeRange", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegationSnapshotByValidatorAndTimeRangeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationSnapshotByValidatorAndTimeRange indicates an expected call of GetDelegationSnapshotByValidatorAndTimeRange.
+func (mr *MockRepositoryMockRecorder) GetDelegationSnapshotByValidatorAndTimeRange(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationSnapshotByValidatorAndTimeRange", reflect.TypeOf((*MockRepository)(nil).GetDelegationSnapshotByValidatorAndTimeRange), ctx, arg)
+}
+
+// GetDelegatorChangeHistory mocks base method.
+func (m *MockRepository) GetDelegatorChangeHistory(ctx context.Context, arg repository.GetDelegatorChangeHistoryParams) ([]repository.GetDelegatorChangeHistoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorChangeHistory", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegatorChangeHistoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorChangeHistory indicates an expected call of GetDelegatorChangeHistory.
+func (mr *MockRepositoryMockRecorder) GetDelegatorChangeHistory(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorChangeHistory", reflect.TypeOf((*MockRepository)(nil).GetDelegatorChangeHistory), ctx, arg)
+}
+
+// GetDelegatorCohortRetentionByValidator mocks base method.
+func (m *MockRepository) GetDelegatorCohortRetentionByValidator(ctx context.Context, arg repository.GetDelegatorCohortRetentionByValidatorParams) ([]repository.GetDelegatorCohortRetentionByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorCohortRetentionByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegatorCohortRetentionByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorCohortRetentionByValidator indicates an expected call of GetDelegatorCohortRetentionByValidator.
+func (mr *MockRepositoryMockRecorder) GetDelegatorCohortRetentionByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorCohortRetentionByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegatorCohortRetentionByValidator), ctx, arg)
+}
+
+// GetDelegatorEventByValidator mocks base method.
+func (m *MockRepository) GetDelegatorEventByValidator(ctx context.Context, arg repository.GetDelegatorEventByValidatorParams) ([]repository.GetDelegatorEventByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorEventByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegatorEventByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorEventByValidator indicates an expected call of GetDelegatorEventByValidator.
+func (mr *MockRepositoryMockRecorder) GetDelegatorEventByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorEventByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegatorEventByValidator), ctx, arg)
+}
+
+// GetDelegatorHistoryByValidator mocks base method.
+func (m *MockRepository) GetDelegatorHistoryByValidator(ctx context.Context, arg repository.GetDelegatorHistoryByValidatorParams) ([]repository.GetDelegatorHistoryByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorHistoryByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetDelegatorHistoryByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorHistoryByValidator indicates an expected call of GetDelegatorHistoryByValidator.
+func (mr *MockRepositoryMockRecorder) GetDelegatorHistoryByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).GetDelegatorHistoryByValidator), ctx, arg)
+}
+
+// GetExistsSchedulerRunWithRowsAffected mocks base method.
+func (m *MockRepository) GetExistsSchedulerRunWithRowsAffected(ctx context.Context, arg repository.GetExistsSchedulerRunWithRowsAffectedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExistsSchedulerRunWithRowsAffected", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExistsSchedulerRunWithRowsAffected indicates an expected call of GetExistsSchedulerRunWithRowsAffected.
+func (mr *MockRepositoryMockRecorder) GetExistsSchedulerRunWithRowsAffected(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExistsSchedulerRunWithRowsAffected", reflect.TypeOf((*MockRepository)(nil).GetExistsSchedulerRunWithRowsAffected), ctx, arg)
+}
+
+// GetLatestCheckpointSchedulerRun mocks base method.
+func (m *MockRepository) GetLatestCheckpointSchedulerRun(ctx context.Context, arg repository.GetLatestCheckpointSchedulerRunParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestCheckpointSchedulerRun", ctx, arg)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestCheckpointSchedulerRun indicates an expected call of GetLatestCheckpointSchedulerRun.
+func (mr *MockRepositoryMockRecorder) GetLatestCheckpointSchedulerRun(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestCheckpointSchedulerRun", reflect.TypeOf((*MockRepository)(nil).GetLatestCheckpointSchedulerRun), ctx, arg)
+}
+
+// GetLatestCheckpointSchedulerRunBefore mocks base method.
+func (m *MockRepository) GetLatestCheckpointSchedulerRunBefore(ctx context.Context, arg repository.GetLatestCheckpointSchedulerRunBeforeParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestCheckpointSchedulerRunBefore", ctx, arg)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestCheckpointSchedulerRunBefore indicates an expected call of GetLatestCheckpointSchedulerRunBefore.
+func (mr *MockRepositoryMockRecorder) GetLatestCheckpointSchedulerRunBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestCheckpointSchedulerRunBefore", reflect.TypeOf((*MockRepository)(nil).GetLatestCheckpointSchedulerRunBefore), ctx, arg)
+}
+
+// GetLatestDelegationSnapshot mocks base method.
+func (m *MockRepository) GetLatestDelegationSnapshot(ctx context.Context, arg repository.GetLatestDelegationSnapshotParams) ([]repository.GetLatestDelegationSnapshotRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestDelegationSnapshot", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetLatestDelegationSnapshotRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestDelegationSnapshot indicates an expected call of GetLatestDelegationSnapshot.
+func (mr *MockRepositoryMockRecorder) GetLatestDelegationSnapshot(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDelegationSnapshot", reflect.TypeOf((*MockRepository)(nil).GetLatestDelegationSnapshot), ctx, arg)
+}
+
+// GetLatestDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) GetLatestDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) ([]repository.GetLatestDelegationSnapshotByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestDelegationSnapshotByValidator", ctx, validatorAddress)
+	ret0, _ := ret[0].([]repository.GetLatestDelegationSnapshotByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestDelegationSnapshotByValidator indicates an expected call of GetLatestDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) GetLatestDelegationSnapshotByValidator(ctx, validatorAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetLatestDelegationSnapshotByValidator), ctx, validatorAddress)
+}
+
+// GetLatestSchedulerRun mocks base method.
+func (m *MockRepository) GetLatestSchedulerRun(ctx context.Context, jobName string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSchedulerRun", ctx, jobName)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSchedulerRun indicates an expected call of GetLatestSchedulerRun.
+func (mr *MockRepositoryMockRecorder) GetLatestSchedulerRun(ctx, jobName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSchedulerRun", reflect.TypeOf((*MockRepository)(nil).GetLatestSchedulerRun), ctx, jobName)
+}
+
+// GetMigrationVersion mocks base method.
+func (m *MockRepository) GetMigrationVersion(ctx context.Context) (repository.GetMigrationVersionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationVersion", ctx)
+	ret0, _ := ret[0].(repository.GetMigrationVersionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrationVersion indicates an expected call of GetMigrationVersion.
+func (mr *MockRepositoryMockRecorder) GetMigrationVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationVersion", reflect.TypeOf((*MockRepository)(nil).GetMigrationVersion), ctx)
+}
+
+// GetReconstructedDelegationSnapshotByValidator mocks base method.
+func (m *MockRepository) GetReconstructedDelegationSnapshotByValidator(ctx context.Context, arg repository.GetReconstructedDelegationSnapshotByValidatorParams) ([]repository.GetReconstructedDelegationSnapshotByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconstructedDelegationSnapshotByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetReconstructedDelegationSnapshotByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconstructedDelegationSnapshotByValidator indicates an expected call of GetReconstructedDelegationSnapshotByValidator.
+func (mr *MockRepositoryMockRecorder) GetReconstructedDelegationSnapshotByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconstructedDelegationSnapshotByValidator", reflect.TypeOf((*MockRepository)(nil).GetReconstructedDelegationSnapshotByValidator), ctx, arg)
+}
+
+// GetReconstructedDelegatorHistoryByValidator mocks base method.
+func (m *MockRepository) GetReconstructedDelegatorHistoryByValidator(ctx context.Context, arg repository.GetReconstructedDelegatorHistoryByValidatorParams) ([]repository.GetReconstructedDelegatorHistoryByValidatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconstructedDelegatorHistoryByValidator", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetReconstructedDelegatorHistoryByValidatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconstructedDelegatorHistoryByValidator indicates an expected call of GetReconstructedDelegatorHistoryByValidator.
+func (mr *MockRepositoryMockRecorder) GetReconstructedDelegatorHistoryByValidator(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconstructedDelegatorHistoryByValidator", reflect.TypeOf((*MockRepository)(nil).GetReconstructedDelegatorHistoryByValidator), ctx, arg)
+}
+
+// GetUnexportedDailyAggregateDates mocks base method.
+func (m *MockRepository) GetUnexportedDailyAggregateDates(ctx context.Context, arg repository.GetUnexportedDailyAggregateDatesParams) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnexportedDailyAggregateDates", ctx, arg)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnexportedDailyAggregateDates indicates an expected call of GetUnexportedDailyAggregateDates.
+func (mr *MockRepositoryMockRecorder) GetUnexportedDailyAggregateDates(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnexportedDailyAggregateDates", reflect.TypeOf((*MockRepository)(nil).GetUnexportedDailyAggregateDates), ctx, arg)
+}
+
+// GetUnexportedSnapshotDates mocks base method.
+func (m *MockRepository) GetUnexportedSnapshotDates(ctx context.Context, arg repository.GetUnexportedSnapshotDatesParams) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnexportedSnapshotDates", ctx, arg)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnexportedSnapshotDates indicates an expected call of GetUnexportedSnapshotDates.
+func (mr *MockRepositoryMockRecorder) GetUnexportedSnapshotDates(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnexportedSnapshotDates", reflect.TypeOf((*MockRepository)(nil).GetUnexportedSnapshotDates), ctx, arg)
+}
+
+// GetUnprocessedDelegatorEventDates mocks base method.
+func (m *MockRepository) GetUnprocessedDelegatorEventDates(ctx context.Context, arg repository.GetUnprocessedDelegatorEventDatesParams) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnprocessedDelegatorEventDates", ctx, arg)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnprocessedDelegatorEventDates indicates an expected call of GetUnprocessedDelegatorEventDates.
+func (mr *MockRepositoryMockRecorder) GetUnprocessedDelegatorEventDates(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnprocessedDelegatorEventDates", reflect.TypeOf((*MockRepository)(nil).GetUnprocessedDelegatorEventDates), ctx, arg)
+}
+
+// GetValidatorAddressesBySchedulerRun mocks base method.
+func (m *MockRepository) GetValidatorAddressesBySchedulerRun(ctx context.Context, jobName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorAddressesBySchedulerRun", ctx, jobName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorAddressesBySchedulerRun indicates an expected call of GetValidatorAddressesBySchedulerRun.
+func (mr *MockRepositoryMockRecorder) GetValidatorAddressesBySchedulerRun(ctx, jobName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorAddressesBySchedulerRun", reflect.TypeOf((*MockRepository)(nil).GetValidatorAddressesBySchedulerRun), ctx, jobName)
+}
+
+// GetValidatorCommissionByValidators mocks base method.
+func (m *MockRepository) GetValidatorCommissionByValidators(ctx context.Context, arg repository.GetValidatorCommissionByValidatorsParams) ([]repository.GetValidatorCommissionByValidatorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorCommissionByValidators", ctx, arg)
+	ret0, _ := ret[0].([]repository.GetValidatorCommissionByValidatorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorCommissionByValidators indicates an expected call of GetValidatorCommissionByValidators.
+func (mr *MockRepositoryMockRecorder) GetValidatorCommissionByValidators(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorCommissionByValidators", reflect.TypeOf((*MockRepository)(nil).GetValidatorCommissionByValidators), ctx, arg)
+}
+
+// Ping mocks base method.
+func (m *MockRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepository)(nil).Ping), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockRepositoryMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockRepository)(nil).RevokeAPIKey), ctx, id)
+}
+
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockRepository) UpdateAPIKeyLastUsed(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockRepositoryMockRecorder) UpdateAPIKeyLastUsed(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDelegationSnapshot", reflect.TypeOf((*MockRepository)(nil).GetLatestDelegationSnapshot), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockRepository)(nil).UpdateAPIKeyLastUsed), ctx, id)
 }
 
 // WithTx mocks base method.
@@ -5,11 +5,24 @@
 package querier
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type ApiKey struct {
+	ID         uuid.UUID    `json:"id"`
+	Name       string       `json:"name"`
+	KeyPrefix  string       `json:"key_prefix"`
+	KeyHash    string       `json:"key_hash"`
+	Scopes     []string     `json:"scopes"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
 type DailyAggregate struct {
 	ID               uuid.UUID `json:"id"`
 	ValidatorAddress string    `json:"validator_address"`
@@ -20,6 +33,22 @@ type DailyAggregate struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 }
 
+type DailyConcentrationMetric struct {
+	ID               uuid.UUID `json:"id"`
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	DelegatorCount   int64     `json:"delegator_count"`
+	TotalAmount      int64     `json:"total_amount"`
+	GiniCoefficient  float64   `json:"gini_coefficient"`
+	HerfindahlIndex  float64   `json:"herfindahl_index"`
+	Top10Share       float64   `json:"top_10_share"`
+	Top100Share      float64   `json:"top_100_share"`
+	Nakamoto33       int64     `json:"nakamoto_33"`
+	Nakamoto50       int64     `json:"nakamoto_50"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
 type DelegationSnapshot struct {
 	ID               uuid.UUID `json:"id"`
 	ValidatorAddress string    `json:"validator_address"`
@@ -30,3 +59,45 @@ type DelegationSnapshot struct {
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 }
+
+type DelegatorEvent struct {
+	ID               uuid.UUID `json:"id"`
+	ValidatorAddress string    `json:"validator_address"`
+	DelegatorAddress string    `json:"delegator_address"`
+	Date             time.Time `json:"date"`
+	Type             string    `json:"type"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type ExportManifest struct {
+	ID               uuid.UUID `json:"id"`
+	Dataset          string    `json:"dataset"`
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	ObjectKey        string    `json:"object_key"`
+	RowsExported     int64     `json:"rows_exported"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type SchedulerRun struct {
+	ID               uuid.UUID `json:"id"`
+	JobName          string    `json:"job_name"`
+	ValidatorAddress string    `json:"validator_address"`
+	Timestamp        time.Time `json:"timestamp"`
+	IsCheckpoint     bool      `json:"is_checkpoint"`
+	RowsAffected     int64     `json:"rows_affected"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type ValidatorCommission struct {
+	ID               uuid.UUID `json:"id"`
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	CommissionRate   float64   `json:"commission_rate"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
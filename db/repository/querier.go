@@ -6,21 +6,74 @@ package querier
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (CreateAPIKeyRow, error)
 	CreateDailyAggregate(ctx context.Context, arg CreateDailyAggregateParams) (uuid.UUID, error)
+	CreateDailyConcentrationMetrics(ctx context.Context, date time.Time) (int64, error)
 	CreateDelegationSnapshot(ctx context.Context, arg CreateDelegationSnapshotParams) (uuid.UUID, error)
+	CreateDelegationSnapshotPartitions(ctx context.Context, arg CreateDelegationSnapshotPartitionsParams) (int32, error)
+	CreateDelegationSnapshots(ctx context.Context, arg []CreateDelegationSnapshotsParams) (int64, error)
+	// Compares the balances at the last run of the day with the ones at the last run before it.
+	// Only delegators with a row in between, or at the previous run, can have changed.
+	CreateDelegatorEvents(ctx context.Context, arg CreateDelegatorEventsParams) (int64, error)
+	CreateDownsampledDailyAggregates(ctx context.Context, arg CreateDownsampledDailyAggregatesParams) (int64, error)
+	CreateExportManifest(ctx context.Context, arg CreateExportManifestParams) (uuid.UUID, error)
+	CreateSchedulerRun(ctx context.Context, arg CreateSchedulerRunParams) (uuid.UUID, error)
+	CreateValidatorCommission(ctx context.Context, arg CreateValidatorCommissionParams) (uuid.UUID, error)
+	DeleteDelegationSnapshotsBefore(ctx context.Context, arg DeleteDelegationSnapshotsBeforeParams) (int64, error)
+	DeleteSchedulerRunsBefore(ctx context.Context, arg DeleteSchedulerRunsBeforeParams) (int64, error)
+	GetAPIKeys(ctx context.Context) ([]GetAPIKeysRow, error)
+	GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (GetActiveAPIKeyByHashRow, error)
 	GetCountDailyAggregateByValidator(ctx context.Context, validatorAddress string) (int64, error)
+	GetCountDailyAggregateInTimezoneByValidator(ctx context.Context, arg GetCountDailyAggregateInTimezoneByValidatorParams) (int64, error)
+	GetCountDailyConcentrationMetricByValidator(ctx context.Context, arg GetCountDailyConcentrationMetricByValidatorParams) (int64, error)
+	GetCountDelegationAsOfByValidator(ctx context.Context, arg GetCountDelegationAsOfByValidatorParams) (int64, error)
+	GetCountDelegationDiffByValidator(ctx context.Context, arg GetCountDelegationDiffByValidatorParams) (int64, error)
+	GetCountDelegationSnapshotBefore(ctx context.Context, arg GetCountDelegationSnapshotBeforeParams) (int64, error)
 	GetCountDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) (int64, error)
+	GetCountDelegatorChangeHistory(ctx context.Context, arg GetCountDelegatorChangeHistoryParams) (int64, error)
+	GetCountDelegatorEventByValidator(ctx context.Context, arg GetCountDelegatorEventByValidatorParams) (int64, error)
 	GetCountDelegatorHistoryByValidator(ctx context.Context, arg GetCountDelegatorHistoryByValidatorParams) (int64, error)
+	GetCountReconstructedDelegationSnapshotByValidator(ctx context.Context, arg GetCountReconstructedDelegationSnapshotByValidatorParams) (int64, error)
+	GetCountReconstructedDelegatorHistoryByValidator(ctx context.Context, arg GetCountReconstructedDelegatorHistoryByValidatorParams) (int64, error)
+	GetCurrentDelegationByDelegator(ctx context.Context, arg GetCurrentDelegationByDelegatorParams) ([]GetCurrentDelegationByDelegatorRow, error)
+	GetCurrentDelegatorDistributionByValidator(ctx context.Context, arg GetCurrentDelegatorDistributionByValidatorParams) ([]GetCurrentDelegatorDistributionByValidatorRow, error)
 	GetDailyAggregateByValidator(ctx context.Context, arg GetDailyAggregateByValidatorParams) ([]GetDailyAggregateByValidatorRow, error)
+	GetDailyAggregateByValidatorAndDate(ctx context.Context, arg GetDailyAggregateByValidatorAndDateParams) ([]GetDailyAggregateByValidatorAndDateRow, error)
+	GetDailyAggregateInTimezoneByValidator(ctx context.Context, arg GetDailyAggregateInTimezoneByValidatorParams) ([]GetDailyAggregateInTimezoneByValidatorRow, error)
+	GetDailyConcentrationMetricByValidator(ctx context.Context, arg GetDailyConcentrationMetricByValidatorParams) ([]GetDailyConcentrationMetricByValidatorRow, error)
+	GetDailyDelegatorDistributionByValidator(ctx context.Context, arg GetDailyDelegatorDistributionByValidatorParams) ([]GetDailyDelegatorDistributionByValidatorRow, error)
+	GetDailyDelegatorEventCountByValidator(ctx context.Context, arg GetDailyDelegatorEventCountByValidatorParams) ([]GetDailyDelegatorEventCountByValidatorRow, error)
+	GetDailyTotalByValidators(ctx context.Context, arg GetDailyTotalByValidatorsParams) ([]GetDailyTotalByValidatorsRow, error)
+	GetDelegationAsOfByValidator(ctx context.Context, arg GetDelegationAsOfByValidatorParams) ([]GetDelegationAsOfByValidatorRow, error)
+	GetDelegationDiffByValidator(ctx context.Context, arg GetDelegationDiffByValidatorParams) ([]GetDelegationDiffByValidatorRow, error)
 	GetDelegationSnapshotByValidator(ctx context.Context, arg GetDelegationSnapshotByValidatorParams) ([]GetDelegationSnapshotByValidatorRow, error)
 	GetDelegationSnapshotByValidatorAndDelegator(ctx context.Context, arg GetDelegationSnapshotByValidatorAndDelegatorParams) (GetDelegationSnapshotByValidatorAndDelegatorRow, error)
+	GetDelegationSnapshotByValidatorAndTimeRange(ctx context.Context, arg GetDelegationSnapshotByValidatorAndTimeRangeParams) ([]GetDelegationSnapshotByValidatorAndTimeRangeRow, error)
+	GetDelegatorChangeHistory(ctx context.Context, arg GetDelegatorChangeHistoryParams) ([]GetDelegatorChangeHistoryRow, error)
+	GetDelegatorCohortRetentionByValidator(ctx context.Context, arg GetDelegatorCohortRetentionByValidatorParams) ([]GetDelegatorCohortRetentionByValidatorRow, error)
+	GetDelegatorEventByValidator(ctx context.Context, arg GetDelegatorEventByValidatorParams) ([]GetDelegatorEventByValidatorRow, error)
 	GetDelegatorHistoryByValidator(ctx context.Context, arg GetDelegatorHistoryByValidatorParams) ([]GetDelegatorHistoryByValidatorRow, error)
-	GetLatestDelegationSnapshot(ctx context.Context) ([]GetLatestDelegationSnapshotRow, error)
+	GetExistsSchedulerRunWithRowsAffected(ctx context.Context, arg GetExistsSchedulerRunWithRowsAffectedParams) (bool, error)
+	GetLatestCheckpointSchedulerRun(ctx context.Context, arg GetLatestCheckpointSchedulerRunParams) (time.Time, error)
+	GetLatestCheckpointSchedulerRunBefore(ctx context.Context, arg GetLatestCheckpointSchedulerRunBeforeParams) (time.Time, error)
+	GetLatestDelegationSnapshot(ctx context.Context, arg GetLatestDelegationSnapshotParams) ([]GetLatestDelegationSnapshotRow, error)
+	GetLatestDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) ([]GetLatestDelegationSnapshotByValidatorRow, error)
+	GetLatestSchedulerRun(ctx context.Context, jobName string) (time.Time, error)
+	GetReconstructedDelegationSnapshotByValidator(ctx context.Context, arg GetReconstructedDelegationSnapshotByValidatorParams) ([]GetReconstructedDelegationSnapshotByValidatorRow, error)
+	GetReconstructedDelegatorHistoryByValidator(ctx context.Context, arg GetReconstructedDelegatorHistoryByValidatorParams) ([]GetReconstructedDelegatorHistoryByValidatorRow, error)
+	GetUnexportedDailyAggregateDates(ctx context.Context, arg GetUnexportedDailyAggregateDatesParams) ([]time.Time, error)
+	GetUnexportedSnapshotDates(ctx context.Context, arg GetUnexportedSnapshotDatesParams) ([]time.Time, error)
+	GetUnprocessedDelegatorEventDates(ctx context.Context, arg GetUnprocessedDelegatorEventDatesParams) ([]time.Time, error)
+	GetValidatorAddressesBySchedulerRun(ctx context.Context, jobName string) ([]string, error)
+	GetValidatorCommissionByValidators(ctx context.Context, arg GetValidatorCommissionByValidatorsParams) ([]GetValidatorCommissionByValidatorsRow, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error)
+	UpdateAPIKeyLastUsed(ctx context.Context, id uuid.UUID) error
 }
 
 var _ Querier = (*Queries)(nil)
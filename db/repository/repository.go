@@ -1,12 +1,30 @@
 package querier
 
 import (
+	"context"
+
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	"github.com/jackc/pgx/v5"
 )
 
+type Exporter interface {
+	ExportDelegationSnapshotByValidator(ctx context.Context, validatorAddress string, fn func(GetDelegationSnapshotByValidatorRow) error) error
+	ExportReconstructedDelegationSnapshotByValidator(ctx context.Context, arg GetCountReconstructedDelegationSnapshotByValidatorParams, fn func(GetReconstructedDelegationSnapshotByValidatorRow) error) error
+	ExportDailyAggregateByValidator(ctx context.Context, validatorAddress string, fn func(GetDailyAggregateByValidatorRow) error) error
+	ExportDailyAggregateInTimezoneByValidator(ctx context.Context, arg GetCountDailyAggregateInTimezoneByValidatorParams, fn func(GetDailyAggregateInTimezoneByValidatorRow) error) error
+	ExportDelegatorHistoryByValidator(ctx context.Context, arg ExportDelegatorHistoryByValidatorParams, fn func(GetDelegatorHistoryByValidatorRow) error) error
+	ExportReconstructedDelegatorHistoryByValidator(ctx context.Context, arg ExportReconstructedDelegatorHistoryByValidatorParams, fn func(GetReconstructedDelegatorHistoryByValidatorRow) error) error
+}
+
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+	GetMigrationVersion(ctx context.Context) (GetMigrationVersionRow, error)
+}
+
 type Repository interface {
 	Querier
+	Exporter
+	HealthChecker
 
 	WithTx(tx pgx.Tx) Querier
 	GetDB() utils.PGXPool
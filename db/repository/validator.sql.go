@@ -7,6 +7,7 @@ package querier
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,7 +15,11 @@ import (
 
 const createDailyAggregate = `-- name: CreateDailyAggregate :one
 INSERT INTO daily_aggregates (validator_address, delegator_address, date, total_amount)
-VALUES ($1, $2, $3, $4) RETURNING id
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (validator_address, delegator_address, date) DO UPDATE SET
+    total_amount = EXCLUDED.total_amount,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id
 `
 
 type CreateDailyAggregateParams struct {
@@ -36,6 +41,72 @@ func (q *Queries) CreateDailyAggregate(ctx context.Context, arg CreateDailyAggre
 	return id, err
 }
 
+const createDailyConcentrationMetrics = `-- name: CreateDailyConcentrationMetrics :execrows
+WITH balances AS (
+    SELECT a.validator_address, a.delegator_address, a.date, a.total_amount
+        FROM daily_aggregates a
+        WHERE a.total_amount > 0
+          AND (a.date = $1 OR NOT EXISTS (
+              SELECT 1 FROM daily_concentration_metrics m
+                  WHERE m.validator_address = a.validator_address AND m.date = a.date
+          ))
+),
+ranked AS (
+    SELECT b.validator_address, b.date, b.total_amount::numeric AS amount,
+           ROW_NUMBER() OVER (PARTITION BY b.validator_address, b.date ORDER BY b.total_amount ASC, b.delegator_address ASC) AS asc_rank,
+           ROW_NUMBER() OVER (PARTITION BY b.validator_address, b.date ORDER BY b.total_amount DESC, b.delegator_address ASC) AS desc_rank,
+           SUM(b.total_amount) OVER (
+               PARTITION BY b.validator_address, b.date
+               ORDER BY b.total_amount DESC, b.delegator_address ASC
+               ROWS UNBOUNDED PRECEDING
+           )::numeric AS cumulative_amount,
+           SUM(b.total_amount) OVER (PARTITION BY b.validator_address, b.date)::numeric AS day_total
+        FROM balances b
+)
+INSERT INTO daily_concentration_metrics (
+    validator_address,
+    date,
+    delegator_count,
+    total_amount,
+    gini_coefficient,
+    herfindahl_index,
+    top_10_share,
+    top_100_share,
+    nakamoto_33,
+    nakamoto_50
+)
+SELECT r.validator_address,
+       r.date,
+       COUNT(*),
+       SUM(r.amount)::bigint,
+       (2 * SUM(r.asc_rank * r.amount) / (COUNT(*) * SUM(r.amount)) - (COUNT(*) + 1)::numeric / COUNT(*))::float8,
+       SUM((r.amount / r.day_total) ^ 2)::float8,
+       (COALESCE(SUM(r.amount) FILTER (WHERE r.desc_rank <= 10), 0) / SUM(r.amount))::float8,
+       (COALESCE(SUM(r.amount) FILTER (WHERE r.desc_rank <= 100), 0) / SUM(r.amount))::float8,
+       MIN(r.desc_rank) FILTER (WHERE r.cumulative_amount * 3 >= r.day_total),
+       MIN(r.desc_rank) FILTER (WHERE r.cumulative_amount * 2 >= r.day_total)
+    FROM ranked r
+    GROUP BY r.validator_address, r.date
+ON CONFLICT (validator_address, date) DO UPDATE SET
+    delegator_count = EXCLUDED.delegator_count,
+    total_amount = EXCLUDED.total_amount,
+    gini_coefficient = EXCLUDED.gini_coefficient,
+    herfindahl_index = EXCLUDED.herfindahl_index,
+    top_10_share = EXCLUDED.top_10_share,
+    top_100_share = EXCLUDED.top_100_share,
+    nakamoto_33 = EXCLUDED.nakamoto_33,
+    nakamoto_50 = EXCLUDED.nakamoto_50,
+    updated_at = CURRENT_TIMESTAMP
+`
+
+func (q *Queries) CreateDailyConcentrationMetrics(ctx context.Context, date time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, createDailyConcentrationMetrics, date)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createDelegationSnapshot = `-- name: CreateDelegationSnapshot :one
 INSERT INTO delegation_snapshots (
     validator_address,
@@ -69,6 +140,277 @@ func (q *Queries) CreateDelegationSnapshot(ctx context.Context, arg CreateDelega
 	return id, err
 }
 
+const createDelegationSnapshotPartitions = `-- name: CreateDelegationSnapshotPartitions :one
+SELECT create_delegation_snapshot_partitions($1::date, $2::int)::int AS created_count
+`
+
+type CreateDelegationSnapshotPartitionsParams struct {
+	FromMonth  time.Time `json:"from_month"`
+	MonthCount int32     `json:"month_count"`
+}
+
+func (q *Queries) CreateDelegationSnapshotPartitions(ctx context.Context, arg CreateDelegationSnapshotPartitionsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createDelegationSnapshotPartitions, arg.FromMonth, arg.MonthCount)
+	var created_count int32
+	err := row.Scan(&created_count)
+	return created_count, err
+}
+
+type CreateDelegationSnapshotsParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	DelegatorAddress string    `json:"delegator_address"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	ChangeUatom      int64     `json:"change_uatom"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+const createDelegatorEvents = `-- name: CreateDelegatorEvents :execrows
+WITH runs AS (
+    SELECT
+        (SELECT MAX(r.timestamp) FROM scheduler_runs r
+            WHERE r.validator_address = $1 AND r.job_name = $3
+              AND r.timestamp >= $4::timestamptz AND r.timestamp < $5::timestamptz) AS end_run,
+        COALESCE((SELECT MAX(r.timestamp) FROM scheduler_runs r
+            WHERE r.validator_address = $1 AND r.job_name = $3
+              AND r.timestamp < $4::timestamptz), '-infinity'::timestamptz) AS previous_run
+),
+balances AS (
+    SELECT c.delegator_address,
+           COALESCE((
+               SELECT CASE WHEN NOT $6::boolean OR d.timestamp = runs.previous_run THEN d.amount_uatom ELSE 0 END
+                   FROM delegation_snapshots d
+                   WHERE d.validator_address = $1 AND d.delegator_address = c.delegator_address
+                     AND d.timestamp <= runs.previous_run
+                   ORDER BY d.timestamp DESC LIMIT 1
+           ), 0)::bigint AS previous_amount,
+           COALESCE((
+               SELECT CASE WHEN NOT $6::boolean OR d.timestamp = runs.end_run THEN d.amount_uatom ELSE 0 END
+                   FROM delegation_snapshots d
+                   WHERE d.validator_address = $1 AND d.delegator_address = c.delegator_address
+                     AND d.timestamp <= runs.end_run
+                   ORDER BY d.timestamp DESC LIMIT 1
+           ), 0)::bigint AS end_amount,
+           runs.previous_run
+        FROM runs
+        CROSS JOIN LATERAL (
+            SELECT DISTINCT d.delegator_address
+                FROM delegation_snapshots d
+                WHERE d.validator_address = $1
+                  AND d.timestamp >= runs.previous_run AND d.timestamp <= runs.end_run
+        ) c
+)
+INSERT INTO delegator_events (validator_address, delegator_address, date, type, amount_uatom)
+SELECT $1, b.delegator_address, $2::date,
+       CASE
+           WHEN b.previous_amount > 0 THEN 'churned'
+           WHEN EXISTS (
+               SELECT 1 FROM delegation_snapshots d
+                   WHERE d.validator_address = $1 AND d.delegator_address = b.delegator_address
+                     AND d.timestamp <= b.previous_run AND d.amount_uatom > 0
+           ) OR EXISTS (
+               SELECT 1 FROM delegator_events e
+                   WHERE e.validator_address = $1 AND e.delegator_address = b.delegator_address
+                     AND e.date < $2::date
+           ) THEN 'returned'
+           ELSE 'new'
+       END,
+       GREATEST(b.previous_amount, b.end_amount)
+    FROM balances b
+    WHERE (b.previous_amount = 0) <> (b.end_amount = 0)
+ON CONFLICT (validator_address, delegator_address, date, type) DO UPDATE SET
+    amount_uatom = EXCLUDED.amount_uatom,
+    updated_at = CURRENT_TIMESTAMP
+`
+
+type CreateDelegatorEventsParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	JobName          string    `json:"job_name"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+	LatestRunOnly    bool      `json:"latest_run_only"`
+}
+
+// Compares the balances at the last run of the day with the ones at the last run before it.
+// Only delegators with a row in between, or at the previous run, can have changed.
+func (q *Queries) CreateDelegatorEvents(ctx context.Context, arg CreateDelegatorEventsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createDelegatorEvents,
+		arg.ValidatorAddress,
+		arg.Date,
+		arg.JobName,
+		arg.StartTime,
+		arg.EndTime,
+		arg.LatestRunOnly,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createDownsampledDailyAggregates = `-- name: CreateDownsampledDailyAggregates :execrows
+INSERT INTO daily_aggregates (validator_address, delegator_address, date, total_amount)
+SELECT r.validator_address, s.delegator_address, r.date, s.amount_uatom
+    FROM (
+        SELECT DISTINCT ON ((sr.timestamp AT TIME ZONE $1::text)::date)
+               sr.validator_address, sr.job_name, sr.timestamp, (sr.timestamp AT TIME ZONE $1::text)::date AS date
+            FROM scheduler_runs sr
+            WHERE sr.validator_address = $2 AND sr.job_name = $3 AND sr.timestamp < $4::timestamptz
+            ORDER BY (sr.timestamp AT TIME ZONE $1::text)::date, sr.timestamp DESC
+    ) r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+ON CONFLICT (validator_address, delegator_address, date) DO NOTHING
+`
+
+type CreateDownsampledDailyAggregatesParams struct {
+	DayTimezone      string    `json:"day_timezone"`
+	ValidatorAddress string    `json:"validator_address"`
+	JobName          string    `json:"job_name"`
+	Before           time.Time `json:"before"`
+}
+
+func (q *Queries) CreateDownsampledDailyAggregates(ctx context.Context, arg CreateDownsampledDailyAggregatesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createDownsampledDailyAggregates,
+		arg.DayTimezone,
+		arg.ValidatorAddress,
+		arg.JobName,
+		arg.Before,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createExportManifest = `-- name: CreateExportManifest :one
+INSERT INTO export_manifest (dataset, validator_address, date, object_key, rows_exported)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (dataset, validator_address, date)
+DO UPDATE SET object_key = EXCLUDED.object_key, rows_exported = EXCLUDED.rows_exported, updated_at = CURRENT_TIMESTAMP
+RETURNING id
+`
+
+type CreateExportManifestParams struct {
+	Dataset          string    `json:"dataset"`
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	ObjectKey        string    `json:"object_key"`
+	RowsExported     int64     `json:"rows_exported"`
+}
+
+func (q *Queries) CreateExportManifest(ctx context.Context, arg CreateExportManifestParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createExportManifest,
+		arg.Dataset,
+		arg.ValidatorAddress,
+		arg.Date,
+		arg.ObjectKey,
+		arg.RowsExported,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createSchedulerRun = `-- name: CreateSchedulerRun :one
+INSERT INTO scheduler_runs (job_name, validator_address, timestamp, is_checkpoint, rows_affected)
+VALUES ($1, $2, $3, $4, $5) RETURNING id
+`
+
+type CreateSchedulerRunParams struct {
+	JobName          string    `json:"job_name"`
+	ValidatorAddress string    `json:"validator_address"`
+	Timestamp        time.Time `json:"timestamp"`
+	IsCheckpoint     bool      `json:"is_checkpoint"`
+	RowsAffected     int64     `json:"rows_affected"`
+}
+
+func (q *Queries) CreateSchedulerRun(ctx context.Context, arg CreateSchedulerRunParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createSchedulerRun,
+		arg.JobName,
+		arg.ValidatorAddress,
+		arg.Timestamp,
+		arg.IsCheckpoint,
+		arg.RowsAffected,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createValidatorCommission = `-- name: CreateValidatorCommission :one
+INSERT INTO validator_commissions (validator_address, date, commission_rate)
+VALUES ($1, $2, $3)
+ON CONFLICT (validator_address, date) DO UPDATE SET
+    commission_rate = EXCLUDED.commission_rate,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id
+`
+
+type CreateValidatorCommissionParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	CommissionRate   float64   `json:"commission_rate"`
+}
+
+func (q *Queries) CreateValidatorCommission(ctx context.Context, arg CreateValidatorCommissionParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createValidatorCommission, arg.ValidatorAddress, arg.Date, arg.CommissionRate)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteDelegationSnapshotsBefore = `-- name: DeleteDelegationSnapshotsBefore :execrows
+DELETE FROM delegation_snapshots
+    WHERE validator_address = $1 AND timestamp < $2::timestamptz
+`
+
+type DeleteDelegationSnapshotsBeforeParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Before           time.Time `json:"before"`
+}
+
+func (q *Queries) DeleteDelegationSnapshotsBefore(ctx context.Context, arg DeleteDelegationSnapshotsBeforeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDelegationSnapshotsBefore, arg.ValidatorAddress, arg.Before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSchedulerRunsBefore = `-- name: DeleteSchedulerRunsBefore :execrows
+DELETE FROM scheduler_runs
+    WHERE validator_address = $1 AND job_name = $2 AND timestamp < $3::timestamptz
+`
+
+type DeleteSchedulerRunsBeforeParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	JobName          string    `json:"job_name"`
+	Before           time.Time `json:"before"`
+}
+
+func (q *Queries) DeleteSchedulerRunsBefore(ctx context.Context, arg DeleteSchedulerRunsBeforeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSchedulerRunsBefore, arg.ValidatorAddress, arg.JobName, arg.Before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getCountDailyAggregateByValidator = `-- name: GetCountDailyAggregateByValidator :one
  SELECT COUNT(*)
     FROM daily_aggregates
@@ -82,6 +424,178 @@ func (q *Queries) GetCountDailyAggregateByValidator(ctx context.Context, validat
 	return count, err
 }
 
+const getCountDailyAggregateInTimezoneByValidator = `-- name: GetCountDailyAggregateInTimezoneByValidator :one
+SELECT COUNT(*)
+    FROM (
+        SELECT DISTINCT ON ((sr.timestamp AT TIME ZONE $1::text)::date)
+               sr.validator_address, sr.job_name, sr.timestamp
+            FROM scheduler_runs sr
+            WHERE sr.validator_address = $2 AND sr.job_name = $3
+            ORDER BY (sr.timestamp AT TIME ZONE $1::text)::date, sr.timestamp DESC
+    ) r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+`
+
+type GetCountDailyAggregateInTimezoneByValidatorParams struct {
+	DayTimezone      string `json:"day_timezone"`
+	ValidatorAddress string `json:"validator_address"`
+	JobName          string `json:"job_name"`
+}
+
+func (q *Queries) GetCountDailyAggregateInTimezoneByValidator(ctx context.Context, arg GetCountDailyAggregateInTimezoneByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDailyAggregateInTimezoneByValidator, arg.DayTimezone, arg.ValidatorAddress, arg.JobName)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCountDailyConcentrationMetricByValidator = `-- name: GetCountDailyConcentrationMetricByValidator :one
+SELECT COUNT(*)
+    FROM daily_concentration_metrics
+    WHERE validator_address = $1
+      AND ($2::date IS NULL OR date >= $2)
+      AND ($3::date IS NULL OR date <= $3)
+`
+
+type GetCountDailyConcentrationMetricByValidatorParams struct {
+	ValidatorAddress string       `json:"validator_address"`
+	StartDate        sql.NullTime `json:"start_date"`
+	EndDate          sql.NullTime `json:"end_date"`
+}
+
+func (q *Queries) GetCountDailyConcentrationMetricByValidator(ctx context.Context, arg GetCountDailyConcentrationMetricByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDailyConcentrationMetricByValidator, arg.ValidatorAddress, arg.StartDate, arg.EndDate)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCountDelegationAsOfByValidator = `-- name: GetCountDelegationAsOfByValidator :one
+SELECT COUNT(*)
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = $1 AND d.timestamp <= $2::timestamptz
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) b
+    WHERE b.amount_uatom <> 0
+      AND (NOT $3::boolean OR b.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = $1 AND r.job_name = $4 AND r.timestamp <= $2::timestamptz
+      ))
+`
+
+type GetCountDelegationAsOfByValidatorParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	At               time.Time `json:"at"`
+	LatestRunOnly    bool      `json:"latest_run_only"`
+	JobName          string    `json:"job_name"`
+}
+
+func (q *Queries) GetCountDelegationAsOfByValidator(ctx context.Context, arg GetCountDelegationAsOfByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDelegationAsOfByValidator,
+		arg.ValidatorAddress,
+		arg.At,
+		arg.LatestRunOnly,
+		arg.JobName,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCountDelegationDiffByValidator = `-- name: GetCountDelegationDiffByValidator :one
+WITH at_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = $1 AND d.timestamp <= $2::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT $3::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = $1 AND r.job_name = $4 AND r.timestamp <= $2::timestamptz
+          ))
+),
+compare_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = $1 AND d.timestamp <= $5::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT $3::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = $1 AND r.job_name = $4 AND r.timestamp <= $5::timestamptz
+          ))
+)
+SELECT COUNT(*)
+    FROM at_balances a
+    FULL OUTER JOIN compare_balances c ON c.delegator_address = a.delegator_address
+    WHERE COALESCE(a.amount_uatom, 0) <> COALESCE(c.amount_uatom, 0)
+`
+
+type GetCountDelegationDiffByValidatorParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	At               time.Time `json:"at"`
+	LatestRunOnly    bool      `json:"latest_run_only"`
+	JobName          string    `json:"job_name"`
+	CompareTo        time.Time `json:"compare_to"`
+}
+
+func (q *Queries) GetCountDelegationDiffByValidator(ctx context.Context, arg GetCountDelegationDiffByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDelegationDiffByValidator,
+		arg.ValidatorAddress,
+		arg.At,
+		arg.LatestRunOnly,
+		arg.JobName,
+		arg.CompareTo,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCountDelegationSnapshotBefore = `-- name: GetCountDelegationSnapshotBefore :one
+SELECT COUNT(*)
+    FROM delegation_snapshots
+    WHERE validator_address = $1 AND timestamp < $2::timestamptz
+`
+
+type GetCountDelegationSnapshotBeforeParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Before           time.Time `json:"before"`
+}
+
+func (q *Queries) GetCountDelegationSnapshotBefore(ctx context.Context, arg GetCountDelegationSnapshotBeforeParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDelegationSnapshotBefore, arg.ValidatorAddress, arg.Before)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getCountDelegationSnapshotByValidator = `-- name: GetCountDelegationSnapshotByValidator :one
  SELECT COUNT(*)
     FROM delegation_snapshots
@@ -95,6 +609,89 @@ func (q *Queries) GetCountDelegationSnapshotByValidator(ctx context.Context, val
 	return count, err
 }
 
+const getCountDelegatorChangeHistory = `-- name: GetCountDelegatorChangeHistory :one
+WITH changes AS (
+    SELECT d.validator_address, d.timestamp, d.amount_uatom, d.change_uatom
+        FROM delegation_snapshots d
+        WHERE d.delegator_address = $3 AND d.change_uatom <> 0
+    UNION ALL
+    -- In full storage a delegator who left is missing from the next run of the validator instead of
+    -- having a row of their own, so the exit is rebuilt from the gap.
+    SELECT d.validator_address, n.timestamp, 0::bigint AS amount_uatom, -d.amount_uatom AS change_uatom
+        FROM delegation_snapshots d
+        CROSS JOIN LATERAL (
+            SELECT r.timestamp FROM scheduler_runs r
+                WHERE r.validator_address = d.validator_address
+                  AND r.job_name = $4
+                  AND r.timestamp > d.timestamp
+                ORDER BY r.timestamp ASC
+                LIMIT 1
+        ) n
+        WHERE $5::boolean
+          AND d.delegator_address = $3
+          AND d.amount_uatom > 0
+          AND NOT EXISTS (
+              SELECT 1 FROM delegation_snapshots e
+                  WHERE e.validator_address = d.validator_address
+                    AND e.delegator_address = d.delegator_address
+                    AND e.timestamp = n.timestamp
+          )
+)
+SELECT COUNT(*)
+    FROM changes
+    WHERE ($1::timestamptz IS NULL OR timestamp >= $1)
+      AND ($2::timestamptz IS NULL OR timestamp < $2)
+`
+
+type GetCountDelegatorChangeHistoryParams struct {
+	StartTime        sql.NullTime `json:"start_time"`
+	EndTime          sql.NullTime `json:"end_time"`
+	DelegatorAddress string       `json:"delegator_address"`
+	JobName          string       `json:"job_name"`
+	SynthesizeExits  bool         `json:"synthesize_exits"`
+}
+
+func (q *Queries) GetCountDelegatorChangeHistory(ctx context.Context, arg GetCountDelegatorChangeHistoryParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDelegatorChangeHistory,
+		arg.StartTime,
+		arg.EndTime,
+		arg.DelegatorAddress,
+		arg.JobName,
+		arg.SynthesizeExits,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCountDelegatorEventByValidator = `-- name: GetCountDelegatorEventByValidator :one
+SELECT COUNT(*)
+    FROM delegator_events
+    WHERE validator_address = $1
+      AND ($2::text = '' OR type = $2::text)
+      AND ($3::date IS NULL OR date >= $3)
+      AND ($4::date IS NULL OR date <= $4)
+`
+
+type GetCountDelegatorEventByValidatorParams struct {
+	ValidatorAddress string       `json:"validator_address"`
+	Type             string       `json:"type"`
+	StartDate        sql.NullTime `json:"start_date"`
+	EndDate          sql.NullTime `json:"end_date"`
+}
+
+func (q *Queries) GetCountDelegatorEventByValidator(ctx context.Context, arg GetCountDelegatorEventByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountDelegatorEventByValidator,
+		arg.ValidatorAddress,
+		arg.Type,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getCountDelegatorHistoryByValidator = `-- name: GetCountDelegatorHistoryByValidator :one
 SELECT COUNT(*)
     FROM delegation_snapshots
@@ -113,37 +710,105 @@ func (q *Queries) GetCountDelegatorHistoryByValidator(ctx context.Context, arg G
 	return count, err
 }
 
-const getDailyAggregateByValidator = `-- name: GetDailyAggregateByValidator :many
- SELECT delegator_address, date, total_amount
-    FROM daily_aggregates
-    WHERE validator_address = $1
-    ORDER BY date ASC
-    LIMIT $2
-    OFFSET $3
+const getCountReconstructedDelegationSnapshotByValidator = `-- name: GetCountReconstructedDelegationSnapshotByValidator :one
+SELECT COUNT(*)
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $2 AND s.amount_uatom <> 0
 `
 
-type GetDailyAggregateByValidatorParams struct {
+type GetCountReconstructedDelegationSnapshotByValidatorParams struct {
 	ValidatorAddress string `json:"validator_address"`
-	Limit            int32  `json:"limit"`
-	Offset           int32  `json:"offset"`
+	JobName          string `json:"job_name"`
 }
 
-type GetDailyAggregateByValidatorRow struct {
-	DelegatorAddress string    `json:"delegator_address"`
-	Date             time.Time `json:"date"`
-	TotalAmount      int64     `json:"total_amount"`
+func (q *Queries) GetCountReconstructedDelegationSnapshotByValidator(ctx context.Context, arg GetCountReconstructedDelegationSnapshotByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountReconstructedDelegationSnapshotByValidator, arg.ValidatorAddress, arg.JobName)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) GetDailyAggregateByValidator(ctx context.Context, arg GetDailyAggregateByValidatorParams) ([]GetDailyAggregateByValidatorRow, error) {
-	rows, err := q.db.Query(ctx, getDailyAggregateByValidator, arg.ValidatorAddress, arg.Limit, arg.Offset)
+const getCountReconstructedDelegatorHistoryByValidator = `-- name: GetCountReconstructedDelegatorHistoryByValidator :one
+SELECT COUNT(*)
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.delegator_address = $2
+              AND d.timestamp <= r.timestamp
+            ORDER BY d.timestamp DESC LIMIT 1
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $3 AND s.amount_uatom <> 0
+`
+
+type GetCountReconstructedDelegatorHistoryByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	DelegatorAddress string `json:"delegator_address"`
+	JobName          string `json:"job_name"`
+}
+
+func (q *Queries) GetCountReconstructedDelegatorHistoryByValidator(ctx context.Context, arg GetCountReconstructedDelegatorHistoryByValidatorParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCountReconstructedDelegatorHistoryByValidator, arg.ValidatorAddress, arg.DelegatorAddress, arg.JobName)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCurrentDelegationByDelegator = `-- name: GetCurrentDelegationByDelegator :many
+SELECT s.validator_address, s.amount_uatom, s.timestamp
+    FROM (
+        SELECT DISTINCT ON (d.validator_address)
+               d.validator_address, d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.delegator_address = $1
+            ORDER BY d.validator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+      AND (NOT $2::boolean OR s.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = s.validator_address AND r.job_name = $3
+      ))
+    ORDER BY s.amount_uatom DESC, s.validator_address ASC
+`
+
+type GetCurrentDelegationByDelegatorParams struct {
+	DelegatorAddress string `json:"delegator_address"`
+	LatestRunOnly    bool   `json:"latest_run_only"`
+	JobName          string `json:"job_name"`
+}
+
+type GetCurrentDelegationByDelegatorRow struct {
+	ValidatorAddress string    `json:"validator_address"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (q *Queries) GetCurrentDelegationByDelegator(ctx context.Context, arg GetCurrentDelegationByDelegatorParams) ([]GetCurrentDelegationByDelegatorRow, error) {
+	rows, err := q.db.Query(ctx, getCurrentDelegationByDelegator, arg.DelegatorAddress, arg.LatestRunOnly, arg.JobName)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetDailyAggregateByValidatorRow{}
+	items := []GetCurrentDelegationByDelegatorRow{}
 	for rows.Next() {
-		var i GetDailyAggregateByValidatorRow
-		if err := rows.Scan(&i.DelegatorAddress, &i.Date, &i.TotalAmount); err != nil {
+		var i GetCurrentDelegationByDelegatorRow
+		if err := rows.Scan(&i.ValidatorAddress, &i.AmountUatom, &i.Timestamp); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -154,43 +819,1024 @@ func (q *Queries) GetDailyAggregateByValidator(ctx context.Context, arg GetDaily
 	return items, nil
 }
 
-const getDelegationSnapshotByValidator = `-- name: GetDelegationSnapshotByValidator :many
- SELECT delegator_address, amount_uatom, timestamp, change_uatom
-    FROM delegation_snapshots
-    WHERE validator_address = $1
-    ORDER BY timestamp ASC
-    LIMIT $2
-    OFFSET $3
+const getCurrentDelegatorDistributionByValidator = `-- name: GetCurrentDelegatorDistributionByValidator :many
+SELECT width_bucket(s.amount_uatom, $1::bigint[])::int AS bucket,
+       COUNT(*) AS delegator_count,
+       SUM(s.amount_uatom)::bigint AS total_amount
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = $2
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+      AND (NOT $3::boolean OR s.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = $2 AND r.job_name = $4
+      ))
+    GROUP BY bucket
+    ORDER BY bucket ASC
 `
 
-type GetDelegationSnapshotByValidatorParams struct {
-	ValidatorAddress string `json:"validator_address"`
-	Limit            int32  `json:"limit"`
-	Offset           int32  `json:"offset"`
+type GetCurrentDelegatorDistributionByValidatorParams struct {
+	Boundaries       []int64 `json:"boundaries"`
+	ValidatorAddress string  `json:"validator_address"`
+	LatestRunOnly    bool    `json:"latest_run_only"`
+	JobName          string  `json:"job_name"`
 }
 
-type GetDelegationSnapshotByValidatorRow struct {
-	DelegatorAddress string    `json:"delegator_address"`
-	AmountUatom      int64     `json:"amount_uatom"`
-	Timestamp        time.Time `json:"timestamp"`
+type GetCurrentDelegatorDistributionByValidatorRow struct {
+	Bucket         int32 `json:"bucket"`
+	DelegatorCount int64 `json:"delegator_count"`
+	TotalAmount    int64 `json:"total_amount"`
+}
+
+func (q *Queries) GetCurrentDelegatorDistributionByValidator(ctx context.Context, arg GetCurrentDelegatorDistributionByValidatorParams) ([]GetCurrentDelegatorDistributionByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getCurrentDelegatorDistributionByValidator,
+		arg.Boundaries,
+		arg.ValidatorAddress,
+		arg.LatestRunOnly,
+		arg.JobName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCurrentDelegatorDistributionByValidatorRow{}
+	for rows.Next() {
+		var i GetCurrentDelegatorDistributionByValidatorRow
+		if err := rows.Scan(&i.Bucket, &i.DelegatorCount, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyAggregateByValidator = `-- name: GetDailyAggregateByValidator :many
+ SELECT delegator_address, date, total_amount
+    FROM daily_aggregates
+    WHERE validator_address = $1
+    ORDER BY date ASC
+    LIMIT $2
+    OFFSET $3
+`
+
+type GetDailyAggregateByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	Limit            int32  `json:"limit"`
+	Offset           int32  `json:"offset"`
+}
+
+type GetDailyAggregateByValidatorRow struct {
+	DelegatorAddress string    `json:"delegator_address"`
+	Date             time.Time `json:"date"`
+	TotalAmount      int64     `json:"total_amount"`
+}
+
+func (q *Queries) GetDailyAggregateByValidator(ctx context.Context, arg GetDailyAggregateByValidatorParams) ([]GetDailyAggregateByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDailyAggregateByValidator, arg.ValidatorAddress, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyAggregateByValidatorRow{}
+	for rows.Next() {
+		var i GetDailyAggregateByValidatorRow
+		if err := rows.Scan(&i.DelegatorAddress, &i.Date, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyAggregateByValidatorAndDate = `-- name: GetDailyAggregateByValidatorAndDate :many
+SELECT validator_address, delegator_address, date, total_amount
+    FROM daily_aggregates
+    WHERE validator_address = $1 AND date = $2
+    ORDER BY delegator_address ASC
+`
+
+type GetDailyAggregateByValidatorAndDateParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+}
+
+type GetDailyAggregateByValidatorAndDateRow struct {
+	ValidatorAddress string    `json:"validator_address"`
+	DelegatorAddress string    `json:"delegator_address"`
+	Date             time.Time `json:"date"`
+	TotalAmount      int64     `json:"total_amount"`
+}
+
+func (q *Queries) GetDailyAggregateByValidatorAndDate(ctx context.Context, arg GetDailyAggregateByValidatorAndDateParams) ([]GetDailyAggregateByValidatorAndDateRow, error) {
+	rows, err := q.db.Query(ctx, getDailyAggregateByValidatorAndDate, arg.ValidatorAddress, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyAggregateByValidatorAndDateRow{}
+	for rows.Next() {
+		var i GetDailyAggregateByValidatorAndDateRow
+		if err := rows.Scan(
+			&i.ValidatorAddress,
+			&i.DelegatorAddress,
+			&i.Date,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyAggregateInTimezoneByValidator = `-- name: GetDailyAggregateInTimezoneByValidator :many
+SELECT s.delegator_address, r.date, s.amount_uatom AS total_amount
+    FROM (
+        SELECT DISTINCT ON ((sr.timestamp AT TIME ZONE $1::text)::date)
+               sr.validator_address, sr.job_name, sr.timestamp, (sr.timestamp AT TIME ZONE $1::text)::date AS date
+            FROM scheduler_runs sr
+            WHERE sr.validator_address = $2 AND sr.job_name = $3
+            ORDER BY (sr.timestamp AT TIME ZONE $1::text)::date, sr.timestamp DESC
+    ) r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE s.amount_uatom <> 0
+    ORDER BY r.date ASC, s.delegator_address ASC
+    LIMIT $5
+    OFFSET $4
+`
+
+type GetDailyAggregateInTimezoneByValidatorParams struct {
+	DayTimezone      string `json:"day_timezone"`
+	ValidatorAddress string `json:"validator_address"`
+	JobName          string `json:"job_name"`
+	Offset           int32  `json:"offset"`
+	Limit            int32  `json:"limit"`
+}
+
+type GetDailyAggregateInTimezoneByValidatorRow struct {
+	DelegatorAddress string    `json:"delegator_address"`
+	Date             time.Time `json:"date"`
+	TotalAmount      int64     `json:"total_amount"`
+}
+
+func (q *Queries) GetDailyAggregateInTimezoneByValidator(ctx context.Context, arg GetDailyAggregateInTimezoneByValidatorParams) ([]GetDailyAggregateInTimezoneByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDailyAggregateInTimezoneByValidator,
+		arg.DayTimezone,
+		arg.ValidatorAddress,
+		arg.JobName,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyAggregateInTimezoneByValidatorRow{}
+	for rows.Next() {
+		var i GetDailyAggregateInTimezoneByValidatorRow
+		if err := rows.Scan(&i.DelegatorAddress, &i.Date, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyConcentrationMetricByValidator = `-- name: GetDailyConcentrationMetricByValidator :many
+SELECT date, delegator_count, total_amount, gini_coefficient, herfindahl_index,
+       top_10_share, top_100_share, nakamoto_33, nakamoto_50
+    FROM daily_concentration_metrics
+    WHERE validator_address = $1
+      AND ($2::date IS NULL OR date >= $2)
+      AND ($3::date IS NULL OR date <= $3)
+    ORDER BY date ASC
+    LIMIT $5
+    OFFSET $4
+`
+
+type GetDailyConcentrationMetricByValidatorParams struct {
+	ValidatorAddress string       `json:"validator_address"`
+	StartDate        sql.NullTime `json:"start_date"`
+	EndDate          sql.NullTime `json:"end_date"`
+	Offset           int32        `json:"offset"`
+	Limit            int32        `json:"limit"`
+}
+
+type GetDailyConcentrationMetricByValidatorRow struct {
+	Date            time.Time `json:"date"`
+	DelegatorCount  int64     `json:"delegator_count"`
+	TotalAmount     int64     `json:"total_amount"`
+	GiniCoefficient float64   `json:"gini_coefficient"`
+	HerfindahlIndex float64   `json:"herfindahl_index"`
+	Top10Share      float64   `json:"top_10_share"`
+	Top100Share     float64   `json:"top_100_share"`
+	Nakamoto33      int64     `json:"nakamoto_33"`
+	Nakamoto50      int64     `json:"nakamoto_50"`
+}
+
+func (q *Queries) GetDailyConcentrationMetricByValidator(ctx context.Context, arg GetDailyConcentrationMetricByValidatorParams) ([]GetDailyConcentrationMetricByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDailyConcentrationMetricByValidator,
+		arg.ValidatorAddress,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyConcentrationMetricByValidatorRow{}
+	for rows.Next() {
+		var i GetDailyConcentrationMetricByValidatorRow
+		if err := rows.Scan(
+			&i.Date,
+			&i.DelegatorCount,
+			&i.TotalAmount,
+			&i.GiniCoefficient,
+			&i.HerfindahlIndex,
+			&i.Top10Share,
+			&i.Top100Share,
+			&i.Nakamoto33,
+			&i.Nakamoto50,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyDelegatorDistributionByValidator = `-- name: GetDailyDelegatorDistributionByValidator :many
+SELECT width_bucket(a.total_amount, $1::bigint[])::int AS bucket,
+       COUNT(*) AS delegator_count,
+       SUM(a.total_amount)::bigint AS total_amount
+    FROM daily_aggregates a
+    WHERE a.validator_address = $2 AND a.date = $3::date
+      AND a.total_amount <> 0
+    GROUP BY bucket
+    ORDER BY bucket ASC
+`
+
+type GetDailyDelegatorDistributionByValidatorParams struct {
+	Boundaries       []int64   `json:"boundaries"`
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+}
+
+type GetDailyDelegatorDistributionByValidatorRow struct {
+	Bucket         int32 `json:"bucket"`
+	DelegatorCount int64 `json:"delegator_count"`
+	TotalAmount    int64 `json:"total_amount"`
+}
+
+func (q *Queries) GetDailyDelegatorDistributionByValidator(ctx context.Context, arg GetDailyDelegatorDistributionByValidatorParams) ([]GetDailyDelegatorDistributionByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDailyDelegatorDistributionByValidator, arg.Boundaries, arg.ValidatorAddress, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyDelegatorDistributionByValidatorRow{}
+	for rows.Next() {
+		var i GetDailyDelegatorDistributionByValidatorRow
+		if err := rows.Scan(&i.Bucket, &i.DelegatorCount, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyDelegatorEventCountByValidator = `-- name: GetDailyDelegatorEventCountByValidator :many
+SELECT date, type, COUNT(*) AS delegator_count, SUM(amount_uatom)::bigint AS total_amount
+    FROM delegator_events
+    WHERE validator_address = $1
+      AND ($2::text = '' OR type = $2::text)
+      AND ($3::date IS NULL OR date >= $3)
+      AND ($4::date IS NULL OR date <= $4)
+    GROUP BY date, type
+    ORDER BY date DESC, type ASC
+`
+
+type GetDailyDelegatorEventCountByValidatorParams struct {
+	ValidatorAddress string       `json:"validator_address"`
+	Type             string       `json:"type"`
+	StartDate        sql.NullTime `json:"start_date"`
+	EndDate          sql.NullTime `json:"end_date"`
+}
+
+type GetDailyDelegatorEventCountByValidatorRow struct {
+	Date           time.Time `json:"date"`
+	Type           string    `json:"type"`
+	DelegatorCount int64     `json:"delegator_count"`
+	TotalAmount    int64     `json:"total_amount"`
+}
+
+func (q *Queries) GetDailyDelegatorEventCountByValidator(ctx context.Context, arg GetDailyDelegatorEventCountByValidatorParams) ([]GetDailyDelegatorEventCountByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDailyDelegatorEventCountByValidator,
+		arg.ValidatorAddress,
+		arg.Type,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyDelegatorEventCountByValidatorRow{}
+	for rows.Next() {
+		var i GetDailyDelegatorEventCountByValidatorRow
+		if err := rows.Scan(
+			&i.Date,
+			&i.Type,
+			&i.DelegatorCount,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyTotalByValidators = `-- name: GetDailyTotalByValidators :many
+SELECT a.validator_address, a.date, COUNT(*) AS delegator_count, SUM(a.total_amount)::bigint AS total_amount
+    FROM daily_aggregates a
+    WHERE a.validator_address = ANY($1::text[])
+      AND a.date >= $2::date AND a.date <= $3::date
+      AND a.total_amount <> 0
+    GROUP BY a.validator_address, a.date
+    ORDER BY a.validator_address, a.date
+`
+
+type GetDailyTotalByValidatorsParams struct {
+	ValidatorAddresses []string  `json:"validator_addresses"`
+	StartDate          time.Time `json:"start_date"`
+	EndDate            time.Time `json:"end_date"`
+}
+
+type GetDailyTotalByValidatorsRow struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	DelegatorCount   int64     `json:"delegator_count"`
+	TotalAmount      int64     `json:"total_amount"`
+}
+
+func (q *Queries) GetDailyTotalByValidators(ctx context.Context, arg GetDailyTotalByValidatorsParams) ([]GetDailyTotalByValidatorsRow, error) {
+	rows, err := q.db.Query(ctx, getDailyTotalByValidators, arg.ValidatorAddresses, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyTotalByValidatorsRow{}
+	for rows.Next() {
+		var i GetDailyTotalByValidatorsRow
+		if err := rows.Scan(
+			&i.ValidatorAddress,
+			&i.Date,
+			&i.DelegatorCount,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegationAsOfByValidator = `-- name: GetDelegationAsOfByValidator :many
+SELECT b.delegator_address, b.amount_uatom, b.timestamp
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = $1 AND d.timestamp <= $2::timestamptz
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) b
+    WHERE b.amount_uatom <> 0
+      AND (NOT $3::boolean OR b.timestamp = (
+          SELECT MAX(r.timestamp) FROM scheduler_runs r
+              WHERE r.validator_address = $1 AND r.job_name = $4 AND r.timestamp <= $2::timestamptz
+      ))
+    ORDER BY
+    CASE WHEN $5::text = '-amount' THEN b.amount_uatom END DESC,
+    CASE WHEN $5::text = 'amount' THEN b.amount_uatom END ASC,
+    b.delegator_address ASC
+    LIMIT $7
+    OFFSET $6
+`
+
+type GetDelegationAsOfByValidatorParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	At               time.Time `json:"at"`
+	LatestRunOnly    bool      `json:"latest_run_only"`
+	JobName          string    `json:"job_name"`
+	SortBy           string    `json:"sort_by"`
+	Offset           int32     `json:"offset"`
+	Limit            int32     `json:"limit"`
+}
+
+type GetDelegationAsOfByValidatorRow struct {
+	DelegatorAddress string    `json:"delegator_address"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (q *Queries) GetDelegationAsOfByValidator(ctx context.Context, arg GetDelegationAsOfByValidatorParams) ([]GetDelegationAsOfByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDelegationAsOfByValidator,
+		arg.ValidatorAddress,
+		arg.At,
+		arg.LatestRunOnly,
+		arg.JobName,
+		arg.SortBy,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegationAsOfByValidatorRow{}
+	for rows.Next() {
+		var i GetDelegationAsOfByValidatorRow
+		if err := rows.Scan(&i.DelegatorAddress, &i.AmountUatom, &i.Timestamp); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegationDiffByValidator = `-- name: GetDelegationDiffByValidator :many
+WITH at_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = $4 AND d.timestamp <= $5::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT $6::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = $4 AND r.job_name = $7 AND r.timestamp <= $5::timestamptz
+          ))
+),
+compare_balances AS (
+    SELECT b.delegator_address, b.amount_uatom
+        FROM (
+            SELECT DISTINCT ON (d.delegator_address)
+                   d.delegator_address, d.amount_uatom, d.timestamp
+                FROM delegation_snapshots d
+                WHERE d.validator_address = $4 AND d.timestamp <= $8::timestamptz
+                ORDER BY d.delegator_address, d.timestamp DESC
+        ) b
+        WHERE b.amount_uatom <> 0
+          AND (NOT $6::boolean OR b.timestamp = (
+              SELECT MAX(r.timestamp) FROM scheduler_runs r
+                  WHERE r.validator_address = $4 AND r.job_name = $7 AND r.timestamp <= $8::timestamptz
+          ))
+)
+SELECT COALESCE(a.delegator_address, c.delegator_address)::text AS delegator_address,
+       COALESCE(a.amount_uatom, 0)::bigint AS amount_uatom,
+       COALESCE(c.amount_uatom, 0)::bigint AS compare_amount_uatom,
+       (COALESCE(a.amount_uatom, 0) - COALESCE(c.amount_uatom, 0))::bigint AS change_uatom
+    FROM at_balances a
+    FULL OUTER JOIN compare_balances c ON c.delegator_address = a.delegator_address
+    WHERE COALESCE(a.amount_uatom, 0) <> COALESCE(c.amount_uatom, 0)
+    ORDER BY
+    CASE WHEN $1::text = '-amount' THEN COALESCE(a.amount_uatom, 0) END DESC,
+    CASE WHEN $1::text = 'amount' THEN COALESCE(a.amount_uatom, 0) END ASC,
+    CASE WHEN $1::text = '-change' THEN COALESCE(a.amount_uatom, 0) - COALESCE(c.amount_uatom, 0) END DESC,
+    CASE WHEN $1::text = 'change' THEN COALESCE(a.amount_uatom, 0) - COALESCE(c.amount_uatom, 0) END ASC,
+    COALESCE(a.delegator_address, c.delegator_address) ASC
+    LIMIT $3
+    OFFSET $2
+`
+
+type GetDelegationDiffByValidatorParams struct {
+	SortBy           string    `json:"sort_by"`
+	Offset           int32     `json:"offset"`
+	Limit            int32     `json:"limit"`
+	ValidatorAddress string    `json:"validator_address"`
+	At               time.Time `json:"at"`
+	LatestRunOnly    bool      `json:"latest_run_only"`
+	JobName          string    `json:"job_name"`
+	CompareTo        time.Time `json:"compare_to"`
+}
+
+type GetDelegationDiffByValidatorRow struct {
+	DelegatorAddress   string `json:"delegator_address"`
+	AmountUatom        int64  `json:"amount_uatom"`
+	CompareAmountUatom int64  `json:"compare_amount_uatom"`
+	ChangeUatom        int64  `json:"change_uatom"`
+}
+
+func (q *Queries) GetDelegationDiffByValidator(ctx context.Context, arg GetDelegationDiffByValidatorParams) ([]GetDelegationDiffByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDelegationDiffByValidator,
+		arg.SortBy,
+		arg.Offset,
+		arg.Limit,
+		arg.ValidatorAddress,
+		arg.At,
+		arg.LatestRunOnly,
+		arg.JobName,
+		arg.CompareTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegationDiffByValidatorRow{}
+	for rows.Next() {
+		var i GetDelegationDiffByValidatorRow
+		if err := rows.Scan(
+			&i.DelegatorAddress,
+			&i.AmountUatom,
+			&i.CompareAmountUatom,
+			&i.ChangeUatom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegationSnapshotByValidator = `-- name: GetDelegationSnapshotByValidator :many
+ SELECT delegator_address, amount_uatom, timestamp, change_uatom
+    FROM delegation_snapshots
+    WHERE validator_address = $1
+    ORDER BY timestamp ASC
+    LIMIT $2
+    OFFSET $3
+`
+
+type GetDelegationSnapshotByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	Limit            int32  `json:"limit"`
+	Offset           int32  `json:"offset"`
+}
+
+type GetDelegationSnapshotByValidatorRow struct {
+	DelegatorAddress string    `json:"delegator_address"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	Timestamp        time.Time `json:"timestamp"`
+	ChangeUatom      int64     `json:"change_uatom"`
+}
+
+func (q *Queries) GetDelegationSnapshotByValidator(ctx context.Context, arg GetDelegationSnapshotByValidatorParams) ([]GetDelegationSnapshotByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDelegationSnapshotByValidator, arg.ValidatorAddress, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegationSnapshotByValidatorRow{}
+	for rows.Next() {
+		var i GetDelegationSnapshotByValidatorRow
+		if err := rows.Scan(
+			&i.DelegatorAddress,
+			&i.AmountUatom,
+			&i.Timestamp,
+			&i.ChangeUatom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegationSnapshotByValidatorAndDelegator = `-- name: GetDelegationSnapshotByValidatorAndDelegator :one
+ SELECT id, validator_address, 
+        delegator_address, amount_uatom, 
+        change_uatom, timestamp 
+    FROM delegation_snapshots
+    WHERE validator_address = $1 AND delegator_address = $2
+    ORDER BY timestamp DESC LIMIT 1
+`
+
+type GetDelegationSnapshotByValidatorAndDelegatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	DelegatorAddress string `json:"delegator_address"`
+}
+
+type GetDelegationSnapshotByValidatorAndDelegatorRow struct {
+	ID               uuid.UUID `json:"id"`
+	ValidatorAddress string    `json:"validator_address"`
+	DelegatorAddress string    `json:"delegator_address"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	ChangeUatom      int64     `json:"change_uatom"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (q *Queries) GetDelegationSnapshotByValidatorAndDelegator(ctx context.Context, arg GetDelegationSnapshotByValidatorAndDelegatorParams) (GetDelegationSnapshotByValidatorAndDelegatorRow, error) {
+	row := q.db.QueryRow(ctx, getDelegationSnapshotByValidatorAndDelegator, arg.ValidatorAddress, arg.DelegatorAddress)
+	var i GetDelegationSnapshotByValidatorAndDelegatorRow
+	err := row.Scan(
+		&i.ID,
+		&i.ValidatorAddress,
+		&i.DelegatorAddress,
+		&i.AmountUatom,
+		&i.ChangeUatom,
+		&i.Timestamp,
+	)
+	return i, err
+}
+
+const getDelegationSnapshotByValidatorAndTimeRange = `-- name: GetDelegationSnapshotByValidatorAndTimeRange :many
+SELECT validator_address, delegator_address, amount_uatom, change_uatom, timestamp
+    FROM delegation_snapshots
+    WHERE validator_address = $1 AND timestamp >= $2::timestamptz AND timestamp < $3::timestamptz
+    ORDER BY timestamp ASC, delegator_address ASC
+`
+
+type GetDelegationSnapshotByValidatorAndTimeRangeParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+}
+
+type GetDelegationSnapshotByValidatorAndTimeRangeRow struct {
+	ValidatorAddress string    `json:"validator_address"`
+	DelegatorAddress string    `json:"delegator_address"`
+	AmountUatom      int64     `json:"amount_uatom"`
+	ChangeUatom      int64     `json:"change_uatom"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (q *Queries) GetDelegationSnapshotByValidatorAndTimeRange(ctx context.Context, arg GetDelegationSnapshotByValidatorAndTimeRangeParams) ([]GetDelegationSnapshotByValidatorAndTimeRangeRow, error) {
+	rows, err := q.db.Query(ctx, getDelegationSnapshotByValidatorAndTimeRange, arg.ValidatorAddress, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegationSnapshotByValidatorAndTimeRangeRow{}
+	for rows.Next() {
+		var i GetDelegationSnapshotByValidatorAndTimeRangeRow
+		if err := rows.Scan(
+			&i.ValidatorAddress,
+			&i.DelegatorAddress,
+			&i.AmountUatom,
+			&i.ChangeUatom,
+			&i.Timestamp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegatorChangeHistory = `-- name: GetDelegatorChangeHistory :many
+WITH changes AS (
+    SELECT d.validator_address, d.timestamp, d.amount_uatom, d.change_uatom
+        FROM delegation_snapshots d
+        WHERE d.delegator_address = $6 AND d.change_uatom <> 0
+    UNION ALL
+    -- In full storage a delegator who left is missing from the next run of the validator instead of
+    -- having a row of their own, so the exit is rebuilt from the gap.
+    SELECT d.validator_address, n.timestamp, 0::bigint AS amount_uatom, -d.amount_uatom AS change_uatom
+        FROM delegation_snapshots d
+        CROSS JOIN LATERAL (
+            SELECT r.timestamp FROM scheduler_runs r
+                WHERE r.validator_address = d.validator_address
+                  AND r.job_name = $7
+                  AND r.timestamp > d.timestamp
+                ORDER BY r.timestamp ASC
+                LIMIT 1
+        ) n
+        WHERE $8::boolean
+          AND d.delegator_address = $6
+          AND d.amount_uatom > 0
+          AND NOT EXISTS (
+              SELECT 1 FROM delegation_snapshots e
+                  WHERE e.validator_address = d.validator_address
+                    AND e.delegator_address = d.delegator_address
+                    AND e.timestamp = n.timestamp
+          )
+)
+SELECT validator_address, timestamp, amount_uatom, change_uatom
+    FROM changes
+    WHERE ($1::timestamptz IS NULL OR timestamp >= $1)
+      AND ($2::timestamptz IS NULL OR timestamp < $2)
+    ORDER BY
+    CASE WHEN $3::text = '-date' THEN "timestamp" END DESC,
+    CASE WHEN $3::text = 'date' THEN "timestamp" END ASC,
+    validator_address ASC
+    LIMIT $5
+    OFFSET $4
+`
+
+type GetDelegatorChangeHistoryParams struct {
+	StartTime        sql.NullTime `json:"start_time"`
+	EndTime          sql.NullTime `json:"end_time"`
+	SortBy           string       `json:"sort_by"`
+	Offset           int32        `json:"offset"`
+	Limit            int32        `json:"limit"`
+	DelegatorAddress string       `json:"delegator_address"`
+	JobName          string       `json:"job_name"`
+	SynthesizeExits  bool         `json:"synthesize_exits"`
+}
+
+type GetDelegatorChangeHistoryRow struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Timestamp        time.Time `json:"timestamp"`
+	AmountUatom      int64     `json:"amount_uatom"`
 	ChangeUatom      int64     `json:"change_uatom"`
 }
 
-func (q *Queries) GetDelegationSnapshotByValidator(ctx context.Context, arg GetDelegationSnapshotByValidatorParams) ([]GetDelegationSnapshotByValidatorRow, error) {
-	rows, err := q.db.Query(ctx, getDelegationSnapshotByValidator, arg.ValidatorAddress, arg.Limit, arg.Offset)
+func (q *Queries) GetDelegatorChangeHistory(ctx context.Context, arg GetDelegatorChangeHistoryParams) ([]GetDelegatorChangeHistoryRow, error) {
+	rows, err := q.db.Query(ctx, getDelegatorChangeHistory,
+		arg.StartTime,
+		arg.EndTime,
+		arg.SortBy,
+		arg.Offset,
+		arg.Limit,
+		arg.DelegatorAddress,
+		arg.JobName,
+		arg.SynthesizeExits,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegatorChangeHistoryRow{}
+	for rows.Next() {
+		var i GetDelegatorChangeHistoryRow
+		if err := rows.Scan(
+			&i.ValidatorAddress,
+			&i.Timestamp,
+			&i.AmountUatom,
+			&i.ChangeUatom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegatorCohortRetentionByValidator = `-- name: GetDelegatorCohortRetentionByValidator :many
+WITH cohorts AS (
+    SELECT DISTINCT ON (d.delegator_address)
+           d.delegator_address,
+           d.amount_uatom AS initial_amount,
+           date_trunc($4::text, d.timestamp AT TIME ZONE $5::text) AS cohort_start
+        FROM delegation_snapshots d
+        WHERE d.validator_address = $2 AND d.amount_uatom > 0
+        ORDER BY d.delegator_address, d.timestamp ASC
+),
+periods AS (
+    SELECT c.cohort_start, o.period_offset,
+           LEAST(
+               (c.cohort_start + (o.period_offset + 1) * ('1 ' || $4::text)::interval) AT TIME ZONE $5::text,
+               $6::timestamptz
+           ) AS period_end
+        FROM (SELECT DISTINCT cohort_start FROM cohorts) c
+        CROSS JOIN generate_series(0, $7::int) AS o(period_offset)
+        WHERE (c.cohort_start + o.period_offset * ('1 ' || $4::text)::interval) AT TIME ZONE $5::text <= $6::timestamptz
+)
+SELECT p.cohort_start::date AS cohort_start,
+       p.period_offset::int AS period_offset,
+       COUNT(*) AS cohort_size,
+       COUNT(*) FILTER (WHERE s.remaining_amount > 0) AS retained_count,
+       SUM(c.initial_amount)::bigint AS initial_amount,
+       SUM(s.remaining_amount)::bigint AS remaining_amount
+    FROM periods p
+    JOIN cohorts c ON c.cohort_start = p.cohort_start
+    CROSS JOIN LATERAL (
+        SELECT COALESCE((
+            SELECT CASE WHEN NOT $1::boolean OR d.timestamp = (
+                       SELECT MAX(r.timestamp) FROM scheduler_runs r
+                           WHERE r.validator_address = $2
+                             AND r.job_name = $3
+                             AND r.timestamp <= p.period_end
+                   ) THEN d.amount_uatom ELSE 0 END
+                FROM delegation_snapshots d
+                WHERE d.validator_address = $2
+                  AND d.delegator_address = c.delegator_address
+                  AND d.timestamp <= p.period_end
+                ORDER BY d.timestamp DESC LIMIT 1
+        ), 0)::bigint AS remaining_amount
+    ) s
+    GROUP BY p.cohort_start, p.period_offset
+    ORDER BY p.cohort_start ASC, p.period_offset ASC
+`
+
+type GetDelegatorCohortRetentionByValidatorParams struct {
+	LatestRunOnly    bool      `json:"latest_run_only"`
+	ValidatorAddress string    `json:"validator_address"`
+	JobName          string    `json:"job_name"`
+	Period           string    `json:"period"`
+	DayTimezone      string    `json:"day_timezone"`
+	AsOf             time.Time `json:"as_of"`
+	PeriodCount      int32     `json:"period_count"`
+}
+
+type GetDelegatorCohortRetentionByValidatorRow struct {
+	CohortStart     time.Time `json:"cohort_start"`
+	PeriodOffset    int32     `json:"period_offset"`
+	CohortSize      int64     `json:"cohort_size"`
+	RetainedCount   int64     `json:"retained_count"`
+	InitialAmount   int64     `json:"initial_amount"`
+	RemainingAmount int64     `json:"remaining_amount"`
+}
+
+func (q *Queries) GetDelegatorCohortRetentionByValidator(ctx context.Context, arg GetDelegatorCohortRetentionByValidatorParams) ([]GetDelegatorCohortRetentionByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDelegatorCohortRetentionByValidator,
+		arg.LatestRunOnly,
+		arg.ValidatorAddress,
+		arg.JobName,
+		arg.Period,
+		arg.DayTimezone,
+		arg.AsOf,
+		arg.PeriodCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegatorCohortRetentionByValidatorRow{}
+	for rows.Next() {
+		var i GetDelegatorCohortRetentionByValidatorRow
+		if err := rows.Scan(
+			&i.CohortStart,
+			&i.PeriodOffset,
+			&i.CohortSize,
+			&i.RetainedCount,
+			&i.InitialAmount,
+			&i.RemainingAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegatorEventByValidator = `-- name: GetDelegatorEventByValidator :many
+SELECT date, delegator_address, type, amount_uatom
+    FROM delegator_events
+    WHERE validator_address = $1
+      AND ($2::text = '' OR type = $2::text)
+      AND ($3::date IS NULL OR date >= $3)
+      AND ($4::date IS NULL OR date <= $4)
+    ORDER BY date DESC, amount_uatom DESC, delegator_address ASC
+    LIMIT $6
+    OFFSET $5
+`
+
+type GetDelegatorEventByValidatorParams struct {
+	ValidatorAddress string       `json:"validator_address"`
+	Type             string       `json:"type"`
+	StartDate        sql.NullTime `json:"start_date"`
+	EndDate          sql.NullTime `json:"end_date"`
+	Offset           int32        `json:"offset"`
+	Limit            int32        `json:"limit"`
+}
+
+type GetDelegatorEventByValidatorRow struct {
+	Date             time.Time `json:"date"`
+	DelegatorAddress string    `json:"delegator_address"`
+	Type             string    `json:"type"`
+	AmountUatom      int64     `json:"amount_uatom"`
+}
+
+func (q *Queries) GetDelegatorEventByValidator(ctx context.Context, arg GetDelegatorEventByValidatorParams) ([]GetDelegatorEventByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDelegatorEventByValidator,
+		arg.ValidatorAddress,
+		arg.Type,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDelegatorEventByValidatorRow{}
+	for rows.Next() {
+		var i GetDelegatorEventByValidatorRow
+		if err := rows.Scan(
+			&i.Date,
+			&i.DelegatorAddress,
+			&i.Type,
+			&i.AmountUatom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDelegatorHistoryByValidator = `-- name: GetDelegatorHistoryByValidator :many
+SELECT timestamp, amount_uatom, change_uatom
+    FROM delegation_snapshots
+    WHERE validator_address = $1 AND delegator_address = $2
+    ORDER BY 
+    CASE WHEN $5::text = '-date' THEN "timestamp" END DESC,
+    CASE WHEN $5::text = 'date' THEN "timestamp" END ASC
+    LIMIT $3
+    OFFSET $4
+`
+
+type GetDelegatorHistoryByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	DelegatorAddress string `json:"delegator_address"`
+	Limit            int32  `json:"limit"`
+	Offset           int32  `json:"offset"`
+	SortBy           string `json:"sort_by"`
+}
+
+type GetDelegatorHistoryByValidatorRow struct {
+	Timestamp   time.Time `json:"timestamp"`
+	AmountUatom int64     `json:"amount_uatom"`
+	ChangeUatom int64     `json:"change_uatom"`
+}
+
+func (q *Queries) GetDelegatorHistoryByValidator(ctx context.Context, arg GetDelegatorHistoryByValidatorParams) ([]GetDelegatorHistoryByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getDelegatorHistoryByValidator,
+		arg.ValidatorAddress,
+		arg.DelegatorAddress,
+		arg.Limit,
+		arg.Offset,
+		arg.SortBy,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetDelegationSnapshotByValidatorRow{}
+	items := []GetDelegatorHistoryByValidatorRow{}
 	for rows.Next() {
-		var i GetDelegationSnapshotByValidatorRow
-		if err := rows.Scan(
-			&i.DelegatorAddress,
-			&i.AmountUatom,
-			&i.Timestamp,
-			&i.ChangeUatom,
-		); err != nil {
+		var i GetDelegatorHistoryByValidatorRow
+		if err := rows.Scan(&i.Timestamp, &i.AmountUatom, &i.ChangeUatom); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -201,72 +1847,265 @@ func (q *Queries) GetDelegationSnapshotByValidator(ctx context.Context, arg GetD
 	return items, nil
 }
 
-const getDelegationSnapshotByValidatorAndDelegator = `-- name: GetDelegationSnapshotByValidatorAndDelegator :one
- SELECT id, validator_address, 
-        delegator_address, amount_uatom, 
-        change_uatom, timestamp 
-    FROM delegation_snapshots
-    WHERE validator_address = $1 AND delegator_address = $2
+const getExistsSchedulerRunWithRowsAffected = `-- name: GetExistsSchedulerRunWithRowsAffected :one
+SELECT EXISTS (
+    SELECT 1 FROM scheduler_runs
+        WHERE validator_address = $1 AND job_name = $2 AND rows_affected > 0
+)
+`
+
+type GetExistsSchedulerRunWithRowsAffectedParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	JobName          string `json:"job_name"`
+}
+
+func (q *Queries) GetExistsSchedulerRunWithRowsAffected(ctx context.Context, arg GetExistsSchedulerRunWithRowsAffectedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, getExistsSchedulerRunWithRowsAffected, arg.ValidatorAddress, arg.JobName)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const getLatestCheckpointSchedulerRun = `-- name: GetLatestCheckpointSchedulerRun :one
+SELECT timestamp
+    FROM scheduler_runs
+    WHERE validator_address = $1 AND job_name = $2 AND is_checkpoint
     ORDER BY timestamp DESC LIMIT 1
 `
 
-type GetDelegationSnapshotByValidatorAndDelegatorParams struct {
+type GetLatestCheckpointSchedulerRunParams struct {
 	ValidatorAddress string `json:"validator_address"`
-	DelegatorAddress string `json:"delegator_address"`
+	JobName          string `json:"job_name"`
 }
 
-type GetDelegationSnapshotByValidatorAndDelegatorRow struct {
-	ID               uuid.UUID `json:"id"`
+func (q *Queries) GetLatestCheckpointSchedulerRun(ctx context.Context, arg GetLatestCheckpointSchedulerRunParams) (time.Time, error) {
+	row := q.db.QueryRow(ctx, getLatestCheckpointSchedulerRun, arg.ValidatorAddress, arg.JobName)
+	var timestamp time.Time
+	err := row.Scan(&timestamp)
+	return timestamp, err
+}
+
+const getLatestCheckpointSchedulerRunBefore = `-- name: GetLatestCheckpointSchedulerRunBefore :one
+SELECT timestamp
+    FROM scheduler_runs
+    WHERE validator_address = $1 AND job_name = $2 AND is_checkpoint AND timestamp <= $3::timestamptz
+    ORDER BY timestamp DESC LIMIT 1
+`
+
+type GetLatestCheckpointSchedulerRunBeforeParams struct {
 	ValidatorAddress string    `json:"validator_address"`
+	JobName          string    `json:"job_name"`
+	Before           time.Time `json:"before"`
+}
+
+func (q *Queries) GetLatestCheckpointSchedulerRunBefore(ctx context.Context, arg GetLatestCheckpointSchedulerRunBeforeParams) (time.Time, error) {
+	row := q.db.QueryRow(ctx, getLatestCheckpointSchedulerRunBefore, arg.ValidatorAddress, arg.JobName, arg.Before)
+	var timestamp time.Time
+	err := row.Scan(&timestamp)
+	return timestamp, err
+}
+
+const getLatestDelegationSnapshot = `-- name: GetLatestDelegationSnapshot :many
+SELECT s.validator_address, s.delegator_address, s.amount_uatom
+    FROM (
+        SELECT DISTINCT ON (d.delegator_address, d.validator_address)
+               d.validator_address, d.delegator_address, d.amount_uatom, d.timestamp
+            FROM delegation_snapshots d
+            ORDER BY d.delegator_address, d.validator_address, d.timestamp DESC
+    ) s
+    WHERE NOT $1::boolean OR s.timestamp = (
+        SELECT MAX(r.timestamp) FROM scheduler_runs r
+            WHERE r.validator_address = s.validator_address AND r.job_name = $2
+    )
+`
+
+type GetLatestDelegationSnapshotParams struct {
+	LatestRunOnly bool   `json:"latest_run_only"`
+	JobName       string `json:"job_name"`
+}
+
+type GetLatestDelegationSnapshotRow struct {
+	ValidatorAddress string `json:"validator_address"`
+	DelegatorAddress string `json:"delegator_address"`
+	AmountUatom      int64  `json:"amount_uatom"`
+}
+
+func (q *Queries) GetLatestDelegationSnapshot(ctx context.Context, arg GetLatestDelegationSnapshotParams) ([]GetLatestDelegationSnapshotRow, error) {
+	rows, err := q.db.Query(ctx, getLatestDelegationSnapshot, arg.LatestRunOnly, arg.JobName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLatestDelegationSnapshotRow{}
+	for rows.Next() {
+		var i GetLatestDelegationSnapshotRow
+		if err := rows.Scan(&i.ValidatorAddress, &i.DelegatorAddress, &i.AmountUatom); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestDelegationSnapshotByValidator = `-- name: GetLatestDelegationSnapshotByValidator :many
+SELECT DISTINCT ON (delegator_address)
+           delegator_address, amount_uatom
+    FROM delegation_snapshots
+    WHERE validator_address = $1
+    ORDER BY delegator_address, timestamp DESC
+`
+
+type GetLatestDelegationSnapshotByValidatorRow struct {
+	DelegatorAddress string `json:"delegator_address"`
+	AmountUatom      int64  `json:"amount_uatom"`
+}
+
+func (q *Queries) GetLatestDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) ([]GetLatestDelegationSnapshotByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getLatestDelegationSnapshotByValidator, validatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLatestDelegationSnapshotByValidatorRow{}
+	for rows.Next() {
+		var i GetLatestDelegationSnapshotByValidatorRow
+		if err := rows.Scan(&i.DelegatorAddress, &i.AmountUatom); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestSchedulerRun = `-- name: GetLatestSchedulerRun :one
+SELECT timestamp
+    FROM scheduler_runs
+    WHERE job_name = $1
+    ORDER BY timestamp DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestSchedulerRun(ctx context.Context, jobName string) (time.Time, error) {
+	row := q.db.QueryRow(ctx, getLatestSchedulerRun, jobName)
+	var timestamp time.Time
+	err := row.Scan(&timestamp)
+	return timestamp, err
+}
+
+const getReconstructedDelegationSnapshotByValidator = `-- name: GetReconstructedDelegationSnapshotByValidator :many
+SELECT s.delegator_address, s.amount_uatom, r.timestamp,
+       (CASE WHEN s.timestamp = r.timestamp THEN s.change_uatom ELSE 0 END)::bigint AS change_uatom
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT DISTINCT ON (d.delegator_address)
+               d.delegator_address, d.amount_uatom, d.change_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.timestamp <= r.timestamp
+              AND d.timestamp >= (
+                  SELECT MAX(c.timestamp) FROM scheduler_runs c
+                      WHERE c.validator_address = r.validator_address
+                        AND c.job_name = r.job_name
+                        AND c.is_checkpoint
+                        AND c.timestamp <= r.timestamp
+              )
+            ORDER BY d.delegator_address, d.timestamp DESC
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $2 AND s.amount_uatom <> 0
+    ORDER BY r.timestamp ASC, s.delegator_address ASC
+    LIMIT $3
+    OFFSET $4
+`
+
+type GetReconstructedDelegationSnapshotByValidatorParams struct {
+	ValidatorAddress string `json:"validator_address"`
+	JobName          string `json:"job_name"`
+	Limit            int32  `json:"limit"`
+	Offset           int32  `json:"offset"`
+}
+
+type GetReconstructedDelegationSnapshotByValidatorRow struct {
 	DelegatorAddress string    `json:"delegator_address"`
 	AmountUatom      int64     `json:"amount_uatom"`
-	ChangeUatom      int64     `json:"change_uatom"`
 	Timestamp        time.Time `json:"timestamp"`
+	ChangeUatom      int64     `json:"change_uatom"`
 }
 
-func (q *Queries) GetDelegationSnapshotByValidatorAndDelegator(ctx context.Context, arg GetDelegationSnapshotByValidatorAndDelegatorParams) (GetDelegationSnapshotByValidatorAndDelegatorRow, error) {
-	row := q.db.QueryRow(ctx, getDelegationSnapshotByValidatorAndDelegator, arg.ValidatorAddress, arg.DelegatorAddress)
-	var i GetDelegationSnapshotByValidatorAndDelegatorRow
-	err := row.Scan(
-		&i.ID,
-		&i.ValidatorAddress,
-		&i.DelegatorAddress,
-		&i.AmountUatom,
-		&i.ChangeUatom,
-		&i.Timestamp,
+func (q *Queries) GetReconstructedDelegationSnapshotByValidator(ctx context.Context, arg GetReconstructedDelegationSnapshotByValidatorParams) ([]GetReconstructedDelegationSnapshotByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getReconstructedDelegationSnapshotByValidator,
+		arg.ValidatorAddress,
+		arg.JobName,
+		arg.Limit,
+		arg.Offset,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReconstructedDelegationSnapshotByValidatorRow{}
+	for rows.Next() {
+		var i GetReconstructedDelegationSnapshotByValidatorRow
+		if err := rows.Scan(
+			&i.DelegatorAddress,
+			&i.AmountUatom,
+			&i.Timestamp,
+			&i.ChangeUatom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getDelegatorHistoryByValidator = `-- name: GetDelegatorHistoryByValidator :many
-SELECT timestamp, amount_uatom, change_uatom
-    FROM delegation_snapshots
-    WHERE validator_address = $1 AND delegator_address = $2
-    ORDER BY 
-    CASE WHEN $5::text = '-date' THEN "timestamp" END DESC,
-    CASE WHEN $5::text = 'date' THEN "timestamp" END ASC
-    LIMIT $3
-    OFFSET $4
+const getReconstructedDelegatorHistoryByValidator = `-- name: GetReconstructedDelegatorHistoryByValidator :many
+SELECT r.timestamp, s.amount_uatom,
+       (CASE WHEN s.timestamp = r.timestamp THEN s.change_uatom ELSE 0 END)::bigint AS change_uatom
+    FROM scheduler_runs r
+    CROSS JOIN LATERAL (
+        SELECT d.amount_uatom, d.change_uatom, d.timestamp
+            FROM delegation_snapshots d
+            WHERE d.validator_address = r.validator_address
+              AND d.delegator_address = $2
+              AND d.timestamp <= r.timestamp
+            ORDER BY d.timestamp DESC LIMIT 1
+    ) s
+    WHERE r.validator_address = $1 AND r.job_name = $3 AND s.amount_uatom <> 0
+    ORDER BY
+    CASE WHEN $6::text = '-date' THEN r.timestamp END DESC,
+    CASE WHEN $6::text = 'date' THEN r.timestamp END ASC
+    LIMIT $4
+    OFFSET $5
 `
 
-type GetDelegatorHistoryByValidatorParams struct {
+type GetReconstructedDelegatorHistoryByValidatorParams struct {
 	ValidatorAddress string `json:"validator_address"`
 	DelegatorAddress string `json:"delegator_address"`
+	JobName          string `json:"job_name"`
 	Limit            int32  `json:"limit"`
 	Offset           int32  `json:"offset"`
 	SortBy           string `json:"sort_by"`
 }
 
-type GetDelegatorHistoryByValidatorRow struct {
+type GetReconstructedDelegatorHistoryByValidatorRow struct {
 	Timestamp   time.Time `json:"timestamp"`
 	AmountUatom int64     `json:"amount_uatom"`
 	ChangeUatom int64     `json:"change_uatom"`
 }
 
-func (q *Queries) GetDelegatorHistoryByValidator(ctx context.Context, arg GetDelegatorHistoryByValidatorParams) ([]GetDelegatorHistoryByValidatorRow, error) {
-	rows, err := q.db.Query(ctx, getDelegatorHistoryByValidator,
+func (q *Queries) GetReconstructedDelegatorHistoryByValidator(ctx context.Context, arg GetReconstructedDelegatorHistoryByValidatorParams) ([]GetReconstructedDelegatorHistoryByValidatorRow, error) {
+	rows, err := q.db.Query(ctx, getReconstructedDelegatorHistoryByValidator,
 		arg.ValidatorAddress,
 		arg.DelegatorAddress,
+		arg.JobName,
 		arg.Limit,
 		arg.Offset,
 		arg.SortBy,
@@ -275,9 +2114,9 @@ func (q *Queries) GetDelegatorHistoryByValidator(ctx context.Context, arg GetDel
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetDelegatorHistoryByValidatorRow{}
+	items := []GetReconstructedDelegatorHistoryByValidatorRow{}
 	for rows.Next() {
-		var i GetDelegatorHistoryByValidatorRow
+		var i GetReconstructedDelegatorHistoryByValidatorRow
 		if err := rows.Scan(&i.Timestamp, &i.AmountUatom, &i.ChangeUatom); err != nil {
 			return nil, err
 		}
@@ -289,29 +2128,201 @@ func (q *Queries) GetDelegatorHistoryByValidator(ctx context.Context, arg GetDel
 	return items, nil
 }
 
-const getLatestDelegationSnapshot = `-- name: GetLatestDelegationSnapshot :many
-SELECT DISTINCT ON (delegator_address, validator_address)
-           validator_address, delegator_address, amount_uatom
-    FROM delegation_snapshots
-    ORDER BY delegator_address, validator_address, timestamp DESC
+const getUnexportedDailyAggregateDates = `-- name: GetUnexportedDailyAggregateDates :many
+SELECT DISTINCT a.date
+    FROM daily_aggregates a
+    WHERE a.validator_address = $1
+      AND a.date < $2::date
+      AND NOT EXISTS (
+          SELECT 1 FROM export_manifest m
+              WHERE m.dataset = $3
+                AND m.validator_address = a.validator_address
+                AND m.date = a.date
+      )
+    ORDER BY a.date
 `
 
-type GetLatestDelegationSnapshotRow struct {
-	ValidatorAddress string `json:"validator_address"`
-	DelegatorAddress string `json:"delegator_address"`
-	AmountUatom      int64  `json:"amount_uatom"`
+type GetUnexportedDailyAggregateDatesParams struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Before           time.Time `json:"before"`
+	Dataset          string    `json:"dataset"`
 }
 
-func (q *Queries) GetLatestDelegationSnapshot(ctx context.Context) ([]GetLatestDelegationSnapshotRow, error) {
-	rows, err := q.db.Query(ctx, getLatestDelegationSnapshot)
+func (q *Queries) GetUnexportedDailyAggregateDates(ctx context.Context, arg GetUnexportedDailyAggregateDatesParams) ([]time.Time, error) {
+	rows, err := q.db.Query(ctx, getUnexportedDailyAggregateDates, arg.ValidatorAddress, arg.Before, arg.Dataset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetLatestDelegationSnapshotRow{}
+	items := []time.Time{}
 	for rows.Next() {
-		var i GetLatestDelegationSnapshotRow
-		if err := rows.Scan(&i.ValidatorAddress, &i.DelegatorAddress, &i.AmountUatom); err != nil {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		items = append(items, date)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnexportedSnapshotDates = `-- name: GetUnexportedSnapshotDates :many
+SELECT DISTINCT (r.timestamp AT TIME ZONE $1::text)::date AS date
+    FROM scheduler_runs r
+    WHERE r.validator_address = $2
+      AND r.job_name = $3
+      AND r.timestamp < $4::timestamptz
+      AND NOT EXISTS (
+          SELECT 1 FROM export_manifest m
+              WHERE m.dataset = $5
+                AND m.validator_address = r.validator_address
+                AND m.date = (r.timestamp AT TIME ZONE $1::text)::date
+      )
+    ORDER BY date
+`
+
+type GetUnexportedSnapshotDatesParams struct {
+	DayTimezone      string    `json:"day_timezone"`
+	ValidatorAddress string    `json:"validator_address"`
+	JobName          string    `json:"job_name"`
+	Before           time.Time `json:"before"`
+	Dataset          string    `json:"dataset"`
+}
+
+func (q *Queries) GetUnexportedSnapshotDates(ctx context.Context, arg GetUnexportedSnapshotDatesParams) ([]time.Time, error) {
+	rows, err := q.db.Query(ctx, getUnexportedSnapshotDates,
+		arg.DayTimezone,
+		arg.ValidatorAddress,
+		arg.JobName,
+		arg.Before,
+		arg.Dataset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []time.Time{}
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		items = append(items, date)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnprocessedDelegatorEventDates = `-- name: GetUnprocessedDelegatorEventDates :many
+SELECT DISTINCT (r.timestamp AT TIME ZONE $1::text)::date AS date
+    FROM scheduler_runs r
+    WHERE r.validator_address = $2
+      AND r.job_name = $3
+      AND r.timestamp < $4::timestamptz
+      AND NOT EXISTS (
+          SELECT 1 FROM scheduler_runs e
+              WHERE e.validator_address = r.validator_address
+                AND e.job_name = $5
+                AND (e.timestamp AT TIME ZONE $1::text)::date = (r.timestamp AT TIME ZONE $1::text)::date
+      )
+    ORDER BY date
+`
+
+type GetUnprocessedDelegatorEventDatesParams struct {
+	DayTimezone      string    `json:"day_timezone"`
+	ValidatorAddress string    `json:"validator_address"`
+	JobName          string    `json:"job_name"`
+	Before           time.Time `json:"before"`
+	EventJobName     string    `json:"event_job_name"`
+}
+
+func (q *Queries) GetUnprocessedDelegatorEventDates(ctx context.Context, arg GetUnprocessedDelegatorEventDatesParams) ([]time.Time, error) {
+	rows, err := q.db.Query(ctx, getUnprocessedDelegatorEventDates,
+		arg.DayTimezone,
+		arg.ValidatorAddress,
+		arg.JobName,
+		arg.Before,
+		arg.EventJobName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []time.Time{}
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		items = append(items, date)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getValidatorAddressesBySchedulerRun = `-- name: GetValidatorAddressesBySchedulerRun :many
+SELECT DISTINCT validator_address
+    FROM scheduler_runs
+    WHERE job_name = $1
+    ORDER BY validator_address
+`
+
+func (q *Queries) GetValidatorAddressesBySchedulerRun(ctx context.Context, jobName string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getValidatorAddressesBySchedulerRun, jobName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var validator_address string
+		if err := rows.Scan(&validator_address); err != nil {
+			return nil, err
+		}
+		items = append(items, validator_address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getValidatorCommissionByValidators = `-- name: GetValidatorCommissionByValidators :many
+SELECT validator_address, date, commission_rate
+    FROM validator_commissions
+    WHERE validator_address = ANY($1::text[])
+      AND date >= $2::date AND date <= $3::date
+    ORDER BY validator_address, date
+`
+
+type GetValidatorCommissionByValidatorsParams struct {
+	ValidatorAddresses []string  `json:"validator_addresses"`
+	StartDate          time.Time `json:"start_date"`
+	EndDate            time.Time `json:"end_date"`
+}
+
+type GetValidatorCommissionByValidatorsRow struct {
+	ValidatorAddress string    `json:"validator_address"`
+	Date             time.Time `json:"date"`
+	CommissionRate   float64   `json:"commission_rate"`
+}
+
+func (q *Queries) GetValidatorCommissionByValidators(ctx context.Context, arg GetValidatorCommissionByValidatorsParams) ([]GetValidatorCommissionByValidatorsRow, error) {
+	rows, err := q.db.Query(ctx, getValidatorCommissionByValidators, arg.ValidatorAddresses, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetValidatorCommissionByValidatorsRow{}
+	for rows.Next() {
+		var i GetValidatorCommissionByValidatorsRow
+		if err := rows.Scan(&i.ValidatorAddress, &i.Date, &i.CommissionRate); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
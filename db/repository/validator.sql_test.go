@@ -2,12 +2,14 @@ package querier
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v3"
 	"github.com/stretchr/testify/assert"
 )
@@ -85,6 +87,49 @@ func TestCreateDelegationSnapshot(t *testing.T) {
 	})
 }
 
+func TestCreateDelegationSnapshots(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := []CreateDelegationSnapshotsParams{
+		{
+			ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+			DelegatorAddress: "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv",
+			AmountUatom:      100,
+			ChangeUatom:      100,
+			Timestamp:        time.Now(),
+		},
+		{
+			ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+			DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+			AmountUatom:      200,
+			ChangeUatom:      0,
+			Timestamp:        time.Now(),
+		},
+	}
+	columns := []string{"validator_address", "delegator_address", "amount_uatom", "change_uatom", "timestamp"}
+
+	t.Run("success create delegation snapshots", func(t *testing.T) {
+		mockDB.ExpectCopyFrom(pgx.Identifier{"delegation_snapshots"}, columns).
+			WillReturnResult(int64(len(req)))
+
+		res, err := q.CreateDelegationSnapshots(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(req)), res)
+	})
+
+	t.Run("failed create delegation snapshots", func(t *testing.T) {
+		mockDB.ExpectCopyFrom(pgx.Identifier{"delegation_snapshots"}, columns).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateDelegationSnapshots(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
 func TestGetCountDailyAggregateByValidator(t *testing.T) {
 	mockDB, _ := pgxmock.NewPool()
 	defer mockDB.Close()
@@ -355,6 +400,10 @@ func TestGetLatestDelegationSnapshot(t *testing.T) {
 	q := NewRepository(mockDB)
 	ctx := context.Background()
 
+	req := GetLatestDelegationSnapshotParams{
+		LatestRunOnly: true,
+		JobName:       "hourly_collect",
+	}
 	response := []GetLatestDelegationSnapshotRow{
 		{
 			ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
@@ -365,19 +414,1646 @@ func TestGetLatestDelegationSnapshot(t *testing.T) {
 
 	t.Run("success get latest delegation snapshot", func(t *testing.T) {
 		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestDelegationSnapshot)).
+			WithArgs(req.LatestRunOnly, req.JobName).
 			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "delegator_address", "amount_uatom"}).
 				AddRow(response[0].ValidatorAddress, response[0].DelegatorAddress, response[0].AmountUatom))
 
-		res, err := q.GetLatestDelegationSnapshot(ctx)
+		res, err := q.GetLatestDelegationSnapshot(ctx, req)
 		assert.NoError(t, err)
 		assert.Equal(t, response, res)
 	})
 
 	t.Run("failed get latest delegation snapshot", func(t *testing.T) {
 		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestDelegationSnapshot)).
+			WithArgs(req.LatestRunOnly, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetLatestDelegationSnapshot(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetLatestDelegationSnapshotByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+	response := []GetLatestDelegationSnapshotByValidatorRow{
+		{
+			DelegatorAddress: "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv",
+			AmountUatom:      100,
+		},
+		{
+			DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+			AmountUatom:      200,
+		},
+	}
+
+	t.Run("success get latest delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestDelegationSnapshotByValidator)).
+			WithArgs(req).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom"}).
+				AddRow(response[0].DelegatorAddress, response[0].AmountUatom).
+				AddRow(response[1].DelegatorAddress, response[1].AmountUatom))
+
+		res, err := q.GetLatestDelegationSnapshotByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, response, res)
+	})
+
+	t.Run("failed get latest delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestDelegationSnapshotByValidator)).
+			WithArgs(req).
+			WillReturnError(errQuery)
+
+		res, err := q.GetLatestDelegationSnapshotByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateSchedulerRun(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateSchedulerRunParams{
+		JobName:          "hourly_collect",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Timestamp:        time.Now(),
+		IsCheckpoint:     true,
+		RowsAffected:     100,
+	}
+	id := uuid.New()
+
+	t.Run("success create scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createSchedulerRun)).
+			WithArgs(req.JobName, req.ValidatorAddress, req.Timestamp, req.IsCheckpoint, req.RowsAffected).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(id))
+
+		res, err := q.CreateSchedulerRun(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, id, res)
+	})
+
+	t.Run("failed create scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createSchedulerRun)).
+			WithArgs(req.JobName, req.ValidatorAddress, req.Timestamp, req.IsCheckpoint, req.RowsAffected).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateSchedulerRun(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetLatestSchedulerRun(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	jobName := "hourly_collect"
+	timestamp := time.Now()
+
+	t.Run("success get latest scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestSchedulerRun)).
+			WithArgs(jobName).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(timestamp))
+
+		res, err := q.GetLatestSchedulerRun(ctx, jobName)
+		assert.NoError(t, err)
+		assert.Equal(t, timestamp, res)
+	})
+
+	t.Run("failed get latest scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestSchedulerRun)).
+			WithArgs(jobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetLatestSchedulerRun(ctx, jobName)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetExistsSchedulerRunWithRowsAffected(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetExistsSchedulerRunWithRowsAffectedParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "retention",
+	}
+
+	t.Run("success get exists scheduler run with rows affected", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getExistsSchedulerRunWithRowsAffected)).
+			WithArgs(req.ValidatorAddress, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+		res, err := q.GetExistsSchedulerRunWithRowsAffected(ctx, req)
+		assert.NoError(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("failed get exists scheduler run with rows affected", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getExistsSchedulerRunWithRowsAffected)).
+			WithArgs(req.ValidatorAddress, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetExistsSchedulerRunWithRowsAffected(ctx, req)
+		assert.Error(t, err)
+		assert.False(t, res)
+	})
+}
+
+func TestGetLatestCheckpointSchedulerRun(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetLatestCheckpointSchedulerRunParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+	}
+	timestamp := time.Now()
+
+	t.Run("success get latest checkpoint scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestCheckpointSchedulerRun)).
+			WithArgs(req.ValidatorAddress, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(timestamp))
+
+		res, err := q.GetLatestCheckpointSchedulerRun(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, timestamp, res)
+	})
+
+	t.Run("failed get latest checkpoint scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestCheckpointSchedulerRun)).
+			WithArgs(req.ValidatorAddress, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetLatestCheckpointSchedulerRun(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetReconstructedDelegationSnapshotByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetReconstructedDelegationSnapshotByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Limit:            10,
+		Offset:           0,
+	}
+	response := []GetReconstructedDelegationSnapshotByValidatorRow{
+		{
+			DelegatorAddress: "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv",
+			AmountUatom:      100,
+			Timestamp:        time.Now(),
+			ChangeUatom:      0,
+		},
+	}
+
+	t.Run("success get reconstructed delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegationSnapshotByValidator)).
+			WithArgs(req.ValidatorAddress, req.JobName, req.Limit, req.Offset).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom", "timestamp", "change_uatom"}).
+				AddRow(response[0].DelegatorAddress, response[0].AmountUatom, response[0].Timestamp, response[0].ChangeUatom))
+
+		res, err := q.GetReconstructedDelegationSnapshotByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, response, res)
+	})
+
+	t.Run("failed get reconstructed delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegationSnapshotByValidator)).
+			WithArgs(req.ValidatorAddress, req.JobName, req.Limit, req.Offset).
+			WillReturnError(errQuery)
+
+		res, err := q.GetReconstructedDelegationSnapshotByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountReconstructedDelegationSnapshotByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountReconstructedDelegationSnapshotByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+	}
+	totalCount := int64(1)
+
+	t.Run("success get count reconstructed delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountReconstructedDelegationSnapshotByValidator)).
+			WithArgs(req.ValidatorAddress, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(totalCount))
+
+		res, err := q.GetCountReconstructedDelegationSnapshotByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, totalCount, res)
+	})
+
+	t.Run("failed get count reconstructed delegation snapshot by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountReconstructedDelegationSnapshotByValidator)).
+			WithArgs(req.ValidatorAddress, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountReconstructedDelegationSnapshotByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetReconstructedDelegatorHistoryByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetReconstructedDelegatorHistoryByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		DelegatorAddress: "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv",
+		JobName:          "hourly_collect",
+		Limit:            10,
+		Offset:           0,
+		SortBy:           "date",
+	}
+	response := []GetReconstructedDelegatorHistoryByValidatorRow{
+		{
+			Timestamp:   time.Now(),
+			AmountUatom: 100,
+			ChangeUatom: 0,
+		},
+	}
+
+	t.Run("success get reconstructed delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, req.JobName, req.Limit, req.Offset, req.SortBy).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp", "amount_uatom", "change_uatom"}).
+				AddRow(response[0].Timestamp, response[0].AmountUatom, response[0].ChangeUatom))
+
+		res, err := q.GetReconstructedDelegatorHistoryByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, response, res)
+	})
+
+	t.Run("failed get reconstructed delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getReconstructedDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, req.JobName, req.Limit, req.Offset, req.SortBy).
+			WillReturnError(errQuery)
+
+		res, err := q.GetReconstructedDelegatorHistoryByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountReconstructedDelegatorHistoryByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountReconstructedDelegatorHistoryByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		DelegatorAddress: "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv",
+		JobName:          "hourly_collect",
+	}
+	totalCount := int64(1)
+
+	t.Run("success get count reconstructed delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountReconstructedDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(totalCount))
+
+		res, err := q.GetCountReconstructedDelegatorHistoryByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, totalCount, res)
+	})
+
+	t.Run("failed get count reconstructed delegator history by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountReconstructedDelegatorHistoryByValidator)).
+			WithArgs(req.ValidatorAddress, req.DelegatorAddress, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountReconstructedDelegatorHistoryByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateDelegationSnapshotPartitions(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateDelegationSnapshotPartitionsParams{
+		FromMonth:  time.Now(),
+		MonthCount: 4,
+	}
+
+	t.Run("success create delegation snapshot partitions", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createDelegationSnapshotPartitions)).
+			WithArgs(req.FromMonth, req.MonthCount).
+			WillReturnRows(pgxmock.NewRows([]string{"created_count"}).AddRow(int32(2)))
+
+		res, err := q.CreateDelegationSnapshotPartitions(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), res)
+	})
+
+	t.Run("failed create delegation snapshot partitions", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createDelegationSnapshotPartitions)).
+			WithArgs(req.FromMonth, req.MonthCount).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateDelegationSnapshotPartitions(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetValidatorAddressesBySchedulerRun(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	jobName := "hourly_collect"
+	validatorAddress := "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"
+
+	t.Run("success get validator addresses by scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getValidatorAddressesBySchedulerRun)).
+			WithArgs(jobName).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address"}).AddRow(validatorAddress))
+
+		res, err := q.GetValidatorAddressesBySchedulerRun(ctx, jobName)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{validatorAddress}, res)
+	})
+
+	t.Run("failed get validator addresses by scheduler run", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getValidatorAddressesBySchedulerRun)).
+			WithArgs(jobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetValidatorAddressesBySchedulerRun(ctx, jobName)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetLatestCheckpointSchedulerRunBefore(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetLatestCheckpointSchedulerRunBeforeParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Before:           time.Now(),
+	}
+	timestamp := req.Before.Add(-time.Hour)
+
+	t.Run("success get latest checkpoint scheduler run before", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestCheckpointSchedulerRunBefore)).
+			WithArgs(req.ValidatorAddress, req.JobName, req.Before).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(timestamp))
+
+		res, err := q.GetLatestCheckpointSchedulerRunBefore(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, timestamp, res)
+	})
+
+	t.Run("failed get latest checkpoint scheduler run before", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getLatestCheckpointSchedulerRunBefore)).
+			WithArgs(req.ValidatorAddress, req.JobName, req.Before).
+			WillReturnError(errQuery)
+
+		res, err := q.GetLatestCheckpointSchedulerRunBefore(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateDownsampledDailyAggregates(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateDownsampledDailyAggregatesParams{
+		DayTimezone:      "Asia/Jakarta",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Before:           time.Now(),
+	}
+
+	t.Run("success create downsampled daily aggregates", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(createDownsampledDailyAggregates)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Before).
+			WillReturnResult(pgxmock.NewResult("INSERT", 3))
+
+		res, err := q.CreateDownsampledDailyAggregates(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("failed create downsampled daily aggregates", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(createDownsampledDailyAggregates)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Before).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateDownsampledDailyAggregates(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDelegationSnapshotBefore(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDelegationSnapshotBeforeParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Before:           time.Now(),
+	}
+
+	t.Run("success get count delegation snapshot before", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegationSnapshotBefore)).
+			WithArgs(req.ValidatorAddress, req.Before).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(48)))
+
+		res, err := q.GetCountDelegationSnapshotBefore(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(48), res)
+	})
+
+	t.Run("failed get count delegation snapshot before", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegationSnapshotBefore)).
+			WithArgs(req.ValidatorAddress, req.Before).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountDelegationSnapshotBefore(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestDeleteDelegationSnapshotsBefore(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := DeleteDelegationSnapshotsBeforeParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Before:           time.Now(),
+	}
+
+	t.Run("success delete delegation snapshots before", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(deleteDelegationSnapshotsBefore)).
+			WithArgs(req.ValidatorAddress, req.Before).
+			WillReturnResult(pgxmock.NewResult("DELETE", 48))
+
+		res, err := q.DeleteDelegationSnapshotsBefore(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(48), res)
+	})
+
+	t.Run("failed delete delegation snapshots before", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(deleteDelegationSnapshotsBefore)).
+			WithArgs(req.ValidatorAddress, req.Before).
+			WillReturnError(errQuery)
+
+		res, err := q.DeleteDelegationSnapshotsBefore(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestDeleteSchedulerRunsBefore(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := DeleteSchedulerRunsBeforeParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Before:           time.Now(),
+	}
+
+	t.Run("success delete scheduler runs before", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(deleteSchedulerRunsBefore)).
+			WithArgs(req.ValidatorAddress, req.JobName, req.Before).
+			WillReturnResult(pgxmock.NewResult("DELETE", 24))
+
+		res, err := q.DeleteSchedulerRunsBefore(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(24), res)
+	})
+
+	t.Run("failed delete scheduler runs before", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(deleteSchedulerRunsBefore)).
+			WithArgs(req.ValidatorAddress, req.JobName, req.Before).
+			WillReturnError(errQuery)
+
+		res, err := q.DeleteSchedulerRunsBefore(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDailyAggregateInTimezoneByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDailyAggregateInTimezoneByValidatorParams{
+		DayTimezone:      "Europe/Berlin",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Offset:           0,
+		Limit:            10,
+	}
+	row := GetDailyAggregateInTimezoneByValidatorRow{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Now(),
+		TotalAmount:      1000,
+	}
+
+	t.Run("success get daily aggregate in timezone by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateInTimezoneByValidator)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Offset, req.Limit).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "date", "total_amount"}).
+				AddRow(row.DelegatorAddress, row.Date, row.TotalAmount))
+
+		res, err := q.GetDailyAggregateInTimezoneByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyAggregateInTimezoneByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get daily aggregate in timezone by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateInTimezoneByValidator)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Offset, req.Limit).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDailyAggregateInTimezoneByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDailyAggregateInTimezoneByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDailyAggregateInTimezoneByValidatorParams{
+		DayTimezone:      "Europe/Berlin",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+	}
+
+	t.Run("success get count daily aggregate in timezone by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDailyAggregateInTimezoneByValidator)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(5)))
+
+		res, err := q.GetCountDailyAggregateInTimezoneByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), res)
+	})
+
+	t.Run("failed get count daily aggregate in timezone by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDailyAggregateInTimezoneByValidator)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountDailyAggregateInTimezoneByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetUnexportedSnapshotDates(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetUnexportedSnapshotDatesParams{
+		DayTimezone:      "UTC",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Before:           time.Now(),
+		Dataset:          "delegation_snapshots",
+	}
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success get unexported snapshot dates", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getUnexportedSnapshotDates)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Before, req.Dataset).
+			WillReturnRows(pgxmock.NewRows([]string{"date"}).AddRow(date))
+
+		res, err := q.GetUnexportedSnapshotDates(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date}, res)
+	})
+
+	t.Run("failed get unexported snapshot dates", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getUnexportedSnapshotDates)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Before, req.Dataset).
+			WillReturnError(errQuery)
+
+		res, err := q.GetUnexportedSnapshotDates(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetUnexportedDailyAggregateDates(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetUnexportedDailyAggregateDatesParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Before:           time.Now(),
+		Dataset:          "daily_aggregates",
+	}
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success get unexported daily aggregate dates", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getUnexportedDailyAggregateDates)).
+			WithArgs(req.ValidatorAddress, req.Before, req.Dataset).
+			WillReturnRows(pgxmock.NewRows([]string{"date"}).AddRow(date))
+
+		res, err := q.GetUnexportedDailyAggregateDates(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date}, res)
+	})
+
+	t.Run("failed get unexported daily aggregate dates", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getUnexportedDailyAggregateDates)).
+			WithArgs(req.ValidatorAddress, req.Before, req.Dataset).
+			WillReturnError(errQuery)
+
+		res, err := q.GetUnexportedDailyAggregateDates(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDelegationSnapshotByValidatorAndTimeRange(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	startTime := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	req := GetDelegationSnapshotByValidatorAndTimeRangeParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		StartTime:        startTime,
+		EndTime:          startTime.AddDate(0, 0, 1),
+	}
+	row := GetDelegationSnapshotByValidatorAndTimeRangeRow{
+		ValidatorAddress: req.ValidatorAddress,
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		AmountUatom:      1000,
+		ChangeUatom:      100,
+		Timestamp:        startTime.Add(time.Hour),
+	}
+
+	t.Run("success get delegation snapshot by validator and time range", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationSnapshotByValidatorAndTimeRange)).
+			WithArgs(req.ValidatorAddress, req.StartTime, req.EndTime).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "delegator_address", "amount_uatom", "change_uatom", "timestamp"}).
+				AddRow(row.ValidatorAddress, row.DelegatorAddress, row.AmountUatom, row.ChangeUatom, row.Timestamp))
+
+		res, err := q.GetDelegationSnapshotByValidatorAndTimeRange(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegationSnapshotByValidatorAndTimeRangeRow{row}, res)
+	})
+
+	t.Run("failed get delegation snapshot by validator and time range", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationSnapshotByValidatorAndTimeRange)).
+			WithArgs(req.ValidatorAddress, req.StartTime, req.EndTime).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDelegationSnapshotByValidatorAndTimeRange(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDailyAggregateByValidatorAndDate(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDailyAggregateByValidatorAndDateParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	row := GetDailyAggregateByValidatorAndDateRow{
+		ValidatorAddress: req.ValidatorAddress,
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             req.Date,
+		TotalAmount:      1000,
+	}
+
+	t.Run("success get daily aggregate by validator and date", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateByValidatorAndDate)).
+			WithArgs(req.ValidatorAddress, req.Date).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "delegator_address", "date", "total_amount"}).
+				AddRow(row.ValidatorAddress, row.DelegatorAddress, row.Date, row.TotalAmount))
+
+		res, err := q.GetDailyAggregateByValidatorAndDate(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyAggregateByValidatorAndDateRow{row}, res)
+	})
+
+	t.Run("failed get daily aggregate by validator and date", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyAggregateByValidatorAndDate)).
+			WithArgs(req.ValidatorAddress, req.Date).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDailyAggregateByValidatorAndDate(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateExportManifest(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateExportManifestParams{
+		Dataset:          "delegation_snapshots",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		ObjectKey:        "delegation_snapshots/validator=cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c/date=2025-04-01/part-0.parquet",
+		RowsExported:     24,
+	}
+	id := uuid.New()
+
+	t.Run("success create export manifest", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createExportManifest)).
+			WithArgs(req.Dataset, req.ValidatorAddress, req.Date, req.ObjectKey, req.RowsExported).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(id))
+
+		res, err := q.CreateExportManifest(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, id, res)
+	})
+
+	t.Run("failed create export manifest", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createExportManifest)).
+			WithArgs(req.Dataset, req.ValidatorAddress, req.Date, req.ObjectKey, req.RowsExported).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateExportManifest(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCurrentDelegationByDelegator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCurrentDelegationByDelegatorParams{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		LatestRunOnly:    true,
+		JobName:          "hourly_collect",
+	}
+	row := GetCurrentDelegationByDelegatorRow{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		AmountUatom:      1000,
+		Timestamp:        time.Now(),
+	}
+
+	t.Run("success get current delegation by delegator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCurrentDelegationByDelegator)).
+			WithArgs(req.DelegatorAddress, req.LatestRunOnly, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "amount_uatom", "timestamp"}).
+				AddRow(row.ValidatorAddress, row.AmountUatom, row.Timestamp))
+
+		res, err := q.GetCurrentDelegationByDelegator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetCurrentDelegationByDelegatorRow{row}, res)
+	})
+
+	t.Run("failed get current delegation by delegator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCurrentDelegationByDelegator)).
+			WithArgs(req.DelegatorAddress, req.LatestRunOnly, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCurrentDelegationByDelegator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDelegatorChangeHistory(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDelegatorChangeHistoryParams{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		SynthesizeExits:  true,
+		StartTime:        sql.NullTime{Time: time.Now().AddDate(0, 0, -7), Valid: true},
+		SortBy:           "date",
+		Offset:           0,
+		Limit:            10,
+	}
+	row := GetDelegatorChangeHistoryRow{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Timestamp:        time.Now(),
+		AmountUatom:      1000,
+		ChangeUatom:      100,
+	}
+
+	t.Run("success get delegator change history", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorChangeHistory)).
+			WithArgs(req.StartTime, req.EndTime, req.SortBy, req.Offset, req.Limit, req.DelegatorAddress, req.JobName, req.SynthesizeExits).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "timestamp", "amount_uatom", "change_uatom"}).
+				AddRow(row.ValidatorAddress, row.Timestamp, row.AmountUatom, row.ChangeUatom))
+
+		res, err := q.GetDelegatorChangeHistory(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegatorChangeHistoryRow{row}, res)
+	})
+
+	t.Run("failed get delegator change history", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorChangeHistory)).
+			WithArgs(req.StartTime, req.EndTime, req.SortBy, req.Offset, req.Limit, req.DelegatorAddress, req.JobName, req.SynthesizeExits).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDelegatorChangeHistory(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDelegatorChangeHistory(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDelegatorChangeHistoryParams{
+		DelegatorAddress: "cosmos1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		SynthesizeExits:  true,
+		EndTime:          sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	t.Run("success get count delegator change history", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegatorChangeHistory)).
+			WithArgs(req.StartTime, req.EndTime, req.DelegatorAddress, req.JobName, req.SynthesizeExits).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+		res, err := q.GetCountDelegatorChangeHistory(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("failed get count delegator change history", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegatorChangeHistory)).
+			WithArgs(req.StartTime, req.EndTime, req.DelegatorAddress, req.JobName, req.SynthesizeExits).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountDelegatorChangeHistory(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDelegatorCohortRetentionByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDelegatorCohortRetentionByValidatorParams{
+		LatestRunOnly:    true,
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Period:           "month",
+		DayTimezone:      "UTC",
+		AsOf:             time.Now(),
+		PeriodCount:      12,
+	}
+	row := GetDelegatorCohortRetentionByValidatorRow{
+		CohortStart:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodOffset:    0,
+		CohortSize:      4,
+		RetainedCount:   3,
+		InitialAmount:   1000,
+		RemainingAmount: 800,
+	}
+
+	t.Run("success get delegator cohort retention by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorCohortRetentionByValidator)).
+			WithArgs(req.LatestRunOnly, req.ValidatorAddress, req.JobName, req.Period, req.DayTimezone, req.AsOf, req.PeriodCount).
+			WillReturnRows(pgxmock.NewRows([]string{"cohort_start", "period_offset", "cohort_size", "retained_count", "initial_amount", "remaining_amount"}).
+				AddRow(row.CohortStart, row.PeriodOffset, row.CohortSize, row.RetainedCount, row.InitialAmount, row.RemainingAmount))
+
+		res, err := q.GetDelegatorCohortRetentionByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegatorCohortRetentionByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get delegator cohort retention by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorCohortRetentionByValidator)).
+			WithArgs(req.LatestRunOnly, req.ValidatorAddress, req.JobName, req.Period, req.DayTimezone, req.AsOf, req.PeriodCount).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDelegatorCohortRetentionByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateDailyConcentrationMetrics(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success create daily concentration metrics", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(createDailyConcentrationMetrics)).
+			WithArgs(date).
+			WillReturnResult(pgxmock.NewResult("INSERT", 3))
+
+		res, err := q.CreateDailyConcentrationMetrics(ctx, date)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("failed create daily concentration metrics", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(createDailyConcentrationMetrics)).
+			WithArgs(date).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateDailyConcentrationMetrics(ctx, date)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDailyConcentrationMetricByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDailyConcentrationMetricByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		StartDate:        sql.NullTime{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:          sql.NullTime{},
+		Offset:           0,
+		Limit:            10,
+	}
+	row := GetDailyConcentrationMetricByValidatorRow{
+		Date:            time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		DelegatorCount:  3,
+		TotalAmount:     1000,
+		GiniCoefficient: 0.4,
+		HerfindahlIndex: 0.38,
+		Top10Share:      1,
+		Top100Share:     1,
+		Nakamoto33:      1,
+		Nakamoto50:      2,
+	}
+
+	t.Run("success get daily concentration metric by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyConcentrationMetricByValidator)).
+			WithArgs(req.ValidatorAddress, req.StartDate, req.EndDate, req.Offset, req.Limit).
+			WillReturnRows(pgxmock.NewRows([]string{"date", "delegator_count", "total_amount", "gini_coefficient", "herfindahl_index", "top_10_share", "top_100_share", "nakamoto_33", "nakamoto_50"}).
+				AddRow(row.Date, row.DelegatorCount, row.TotalAmount, row.GiniCoefficient, row.HerfindahlIndex, row.Top10Share, row.Top100Share, row.Nakamoto33, row.Nakamoto50))
+
+		res, err := q.GetDailyConcentrationMetricByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyConcentrationMetricByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get daily concentration metric by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyConcentrationMetricByValidator)).
+			WithArgs(req.ValidatorAddress, req.StartDate, req.EndDate, req.Offset, req.Limit).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDailyConcentrationMetricByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDailyConcentrationMetricByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDailyConcentrationMetricByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		StartDate:        sql.NullTime{},
+		EndDate:          sql.NullTime{Time: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	t.Run("success get count daily concentration metric by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDailyConcentrationMetricByValidator)).
+			WithArgs(req.ValidatorAddress, req.StartDate, req.EndDate).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(30)))
+
+		res, err := q.GetCountDailyConcentrationMetricByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30), res)
+	})
+
+	t.Run("failed get count daily concentration metric by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDailyConcentrationMetricByValidator)).
+			WithArgs(req.ValidatorAddress, req.StartDate, req.EndDate).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountDailyConcentrationMetricByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCurrentDelegatorDistributionByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCurrentDelegatorDistributionByValidatorParams{
+		Boundaries:       []int64{1000000, 10000000},
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		LatestRunOnly:    true,
+		JobName:          "hourly_collect",
+	}
+	row := GetCurrentDelegatorDistributionByValidatorRow{
+		Bucket:         1,
+		DelegatorCount: 2,
+		TotalAmount:    5000000,
+	}
+
+	t.Run("success get current delegator distribution by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCurrentDelegatorDistributionByValidator)).
+			WithArgs(req.Boundaries, req.ValidatorAddress, req.LatestRunOnly, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"bucket", "delegator_count", "total_amount"}).
+				AddRow(row.Bucket, row.DelegatorCount, row.TotalAmount))
+
+		res, err := q.GetCurrentDelegatorDistributionByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetCurrentDelegatorDistributionByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get current delegator distribution by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCurrentDelegatorDistributionByValidator)).
+			WithArgs(req.Boundaries, req.ValidatorAddress, req.LatestRunOnly, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCurrentDelegatorDistributionByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDailyDelegatorDistributionByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDailyDelegatorDistributionByValidatorParams{
+		Boundaries:       []int64{1000000, 10000000},
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	row := GetDailyDelegatorDistributionByValidatorRow{
+		Bucket:         0,
+		DelegatorCount: 3,
+		TotalAmount:    1500000,
+	}
+
+	t.Run("success get daily delegator distribution by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyDelegatorDistributionByValidator)).
+			WithArgs(req.Boundaries, req.ValidatorAddress, req.Date).
+			WillReturnRows(pgxmock.NewRows([]string{"bucket", "delegator_count", "total_amount"}).
+				AddRow(row.Bucket, row.DelegatorCount, row.TotalAmount))
+
+		res, err := q.GetDailyDelegatorDistributionByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyDelegatorDistributionByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get daily delegator distribution by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyDelegatorDistributionByValidator)).
+			WithArgs(req.Boundaries, req.ValidatorAddress, req.Date).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDailyDelegatorDistributionByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetUnprocessedDelegatorEventDates(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetUnprocessedDelegatorEventDatesParams{
+		DayTimezone:      "UTC",
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		JobName:          "hourly_collect",
+		Before:           time.Now(),
+		EventJobName:     "delegator_events",
+	}
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success get unprocessed delegator event dates", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getUnprocessedDelegatorEventDates)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Before, req.EventJobName).
+			WillReturnRows(pgxmock.NewRows([]string{"date"}).AddRow(date))
+
+		res, err := q.GetUnprocessedDelegatorEventDates(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date}, res)
+	})
+
+	t.Run("failed get unprocessed delegator event dates", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getUnprocessedDelegatorEventDates)).
+			WithArgs(req.DayTimezone, req.ValidatorAddress, req.JobName, req.Before, req.EventJobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetUnprocessedDelegatorEventDates(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateDelegatorEvents(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateDelegatorEventsParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		JobName:          "hourly_collect",
+		StartTime:        time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:          time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+		LatestRunOnly:    true,
+	}
+
+	t.Run("success create delegator events", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(createDelegatorEvents)).
+			WithArgs(req.ValidatorAddress, req.Date, req.JobName, req.StartTime, req.EndTime, req.LatestRunOnly).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+		res, err := q.CreateDelegatorEvents(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed create delegator events", func(t *testing.T) {
+		mockDB.ExpectExec(regexp.QuoteMeta(createDelegatorEvents)).
+			WithArgs(req.ValidatorAddress, req.Date, req.JobName, req.StartTime, req.EndTime, req.LatestRunOnly).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateDelegatorEvents(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDelegatorEventByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDelegatorEventByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Type:             "new",
+		StartDate:        sql.NullTime{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:          sql.NullTime{},
+		Offset:           0,
+		Limit:            10,
+	}
+	row := GetDelegatorEventByValidatorRow{
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+		Type:             "new",
+		AmountUatom:      8000,
+	}
+
+	t.Run("success get delegator event by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorEventByValidator)).
+			WithArgs(req.ValidatorAddress, req.Type, req.StartDate, req.EndDate, req.Offset, req.Limit).
+			WillReturnRows(pgxmock.NewRows([]string{"date", "delegator_address", "type", "amount_uatom"}).
+				AddRow(row.Date, row.DelegatorAddress, row.Type, row.AmountUatom))
+
+		res, err := q.GetDelegatorEventByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegatorEventByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get delegator event by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegatorEventByValidator)).
+			WithArgs(req.ValidatorAddress, req.Type, req.StartDate, req.EndDate, req.Offset, req.Limit).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDelegatorEventByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDelegatorEventByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDelegatorEventByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Type:             "",
+		StartDate:        sql.NullTime{},
+		EndDate:          sql.NullTime{},
+	}
+
+	t.Run("success get count delegator event by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegatorEventByValidator)).
+			WithArgs(req.ValidatorAddress, req.Type, req.StartDate, req.EndDate).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(4)))
+
+		res, err := q.GetCountDelegatorEventByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), res)
+	})
+
+	t.Run("failed get count delegator event by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegatorEventByValidator)).
+			WithArgs(req.ValidatorAddress, req.Type, req.StartDate, req.EndDate).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountDelegatorEventByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDailyDelegatorEventCountByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDailyDelegatorEventCountByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Type:             "churned",
+		StartDate:        sql.NullTime{},
+		EndDate:          sql.NullTime{Time: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	row := GetDailyDelegatorEventCountByValidatorRow{
+		Date:           time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Type:           "churned",
+		DelegatorCount: 2,
+		TotalAmount:    12000,
+	}
+
+	t.Run("success get daily delegator event count by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyDelegatorEventCountByValidator)).
+			WithArgs(req.ValidatorAddress, req.Type, req.StartDate, req.EndDate).
+			WillReturnRows(pgxmock.NewRows([]string{"date", "type", "delegator_count", "total_amount"}).
+				AddRow(row.Date, row.Type, row.DelegatorCount, row.TotalAmount))
+
+		res, err := q.GetDailyDelegatorEventCountByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyDelegatorEventCountByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get daily delegator event count by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyDelegatorEventCountByValidator)).
+			WithArgs(req.ValidatorAddress, req.Type, req.StartDate, req.EndDate).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDailyDelegatorEventCountByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestCreateValidatorCommission(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := CreateValidatorCommissionParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		CommissionRate:   0.05,
+	}
+	id := uuid.New()
+
+	t.Run("success create validator commission", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createValidatorCommission)).
+			WithArgs(req.ValidatorAddress, req.Date, req.CommissionRate).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(id))
+
+		res, err := q.CreateValidatorCommission(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, id, res)
+	})
+
+	t.Run("failed create validator commission", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(createValidatorCommission)).
+			WithArgs(req.ValidatorAddress, req.Date, req.CommissionRate).
+			WillReturnError(errQuery)
+
+		res, err := q.CreateValidatorCommission(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDailyTotalByValidators(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDailyTotalByValidatorsParams{
+		ValidatorAddresses: []string{"cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c", "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"},
+		StartDate:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:            time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+	row := GetDailyTotalByValidatorsRow{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		DelegatorCount:   3,
+		TotalAmount:      24000,
+	}
+
+	t.Run("success get daily total by validators", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyTotalByValidators)).
+			WithArgs(req.ValidatorAddresses, req.StartDate, req.EndDate).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "date", "delegator_count", "total_amount"}).
+				AddRow(row.ValidatorAddress, row.Date, row.DelegatorCount, row.TotalAmount))
+
+		res, err := q.GetDailyTotalByValidators(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDailyTotalByValidatorsRow{row}, res)
+	})
+
+	t.Run("failed get daily total by validators", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDailyTotalByValidators)).
+			WithArgs(req.ValidatorAddresses, req.StartDate, req.EndDate).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDailyTotalByValidators(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetValidatorCommissionByValidators(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetValidatorCommissionByValidatorsParams{
+		ValidatorAddresses: []string{"cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c"},
+		StartDate:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:            time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+	row := GetValidatorCommissionByValidatorsRow{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		CommissionRate:   0.05,
+	}
+
+	t.Run("success get validator commission by validators", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getValidatorCommissionByValidators)).
+			WithArgs(req.ValidatorAddresses, req.StartDate, req.EndDate).
+			WillReturnRows(pgxmock.NewRows([]string{"validator_address", "date", "commission_rate"}).
+				AddRow(row.ValidatorAddress, row.Date, row.CommissionRate))
+
+		res, err := q.GetValidatorCommissionByValidators(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetValidatorCommissionByValidatorsRow{row}, res)
+	})
+
+	t.Run("failed get validator commission by validators", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getValidatorCommissionByValidators)).
+			WithArgs(req.ValidatorAddresses, req.StartDate, req.EndDate).
+			WillReturnError(errQuery)
+
+		res, err := q.GetValidatorCommissionByValidators(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDelegationAsOfByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDelegationAsOfByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		At:               time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+		LatestRunOnly:    true,
+		JobName:          "hourly_collect",
+		SortBy:           "-amount",
+		Offset:           0,
+		Limit:            10,
+	}
+	row := GetDelegationAsOfByValidatorRow{
+		DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+		AmountUatom:      8000,
+		Timestamp:        time.Date(2025, 4, 1, 11, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success get delegation as of by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationAsOfByValidator)).
+			WithArgs(req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName, req.SortBy, req.Offset, req.Limit).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom", "timestamp"}).
+				AddRow(row.DelegatorAddress, row.AmountUatom, row.Timestamp))
+
+		res, err := q.GetDelegationAsOfByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegationAsOfByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get delegation as of by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationAsOfByValidator)).
+			WithArgs(req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName, req.SortBy, req.Offset, req.Limit).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDelegationAsOfByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDelegationAsOfByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDelegationAsOfByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		At:               time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+		LatestRunOnly:    false,
+		JobName:          "hourly_collect",
+	}
+
+	t.Run("success get count delegation as of by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegationAsOfByValidator)).
+			WithArgs(req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+		res, err := q.GetCountDelegationAsOfByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("failed get count delegation as of by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegationAsOfByValidator)).
+			WithArgs(req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName).
+			WillReturnError(errQuery)
+
+		res, err := q.GetCountDelegationAsOfByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetDelegationDiffByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetDelegationDiffByValidatorParams{
+		SortBy:           "-change",
+		Offset:           0,
+		Limit:            10,
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		At:               time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+		LatestRunOnly:    true,
+		JobName:          "hourly_collect",
+		CompareTo:        time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	row := GetDelegationDiffByValidatorRow{
+		DelegatorAddress:   "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+		AmountUatom:        8000,
+		CompareAmountUatom: 5000,
+		ChangeUatom:        3000,
+	}
+
+	t.Run("success get delegation diff by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationDiffByValidator)).
+			WithArgs(req.SortBy, req.Offset, req.Limit, req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName, req.CompareTo).
+			WillReturnRows(pgxmock.NewRows([]string{"delegator_address", "amount_uatom", "compare_amount_uatom", "change_uatom"}).
+				AddRow(row.DelegatorAddress, row.AmountUatom, row.CompareAmountUatom, row.ChangeUatom))
+
+		res, err := q.GetDelegationDiffByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []GetDelegationDiffByValidatorRow{row}, res)
+	})
+
+	t.Run("failed get delegation diff by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getDelegationDiffByValidator)).
+			WithArgs(req.SortBy, req.Offset, req.Limit, req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName, req.CompareTo).
+			WillReturnError(errQuery)
+
+		res, err := q.GetDelegationDiffByValidator(ctx, req)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestGetCountDelegationDiffByValidator(t *testing.T) {
+	mockDB, _ := pgxmock.NewPool()
+	defer mockDB.Close()
+	q := NewRepository(mockDB)
+	ctx := context.Background()
+
+	req := GetCountDelegationDiffByValidatorParams{
+		ValidatorAddress: "cosmosvaloper1uhnsxv6m83jj3328mhrql7yax3nge5svrv6t6c",
+		At:               time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+		LatestRunOnly:    true,
+		JobName:          "hourly_collect",
+		CompareTo:        time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success get count delegation diff by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegationDiffByValidator)).
+			WithArgs(req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName, req.CompareTo).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(2)))
+
+		res, err := q.GetCountDelegationDiffByValidator(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed get count delegation diff by validator", func(t *testing.T) {
+		mockDB.ExpectQuery(regexp.QuoteMeta(getCountDelegationDiffByValidator)).
+			WithArgs(req.ValidatorAddress, req.At, req.LatestRunOnly, req.JobName, req.CompareTo).
 			WillReturnError(errQuery)
 
-		res, err := q.GetLatestDelegationSnapshot(ctx)
+		res, err := q.GetCountDelegationDiffByValidator(ctx, req)
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
@@ -25,9 +25,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/scheduler/validator/daily": {
-            "post": {
-                "description": "Scheduler For Daily Collect Validator Data",
+        "/api/v1/admin/keys": {
+            "get": {
+                "security": [
+                    {
+                        "authorization": []
+                    }
+                ],
+                "description": "Get every API key, including the revoked ones, without the keys themselves",
                 "consumes": [
                     "application/json"
                 ],
@@ -35,15 +40,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "validator"
+                    "admin"
                 ],
-                "summary": "Scheduler For Daily Collect Validator Data",
-                "operationId": "schedulerForDailyCollectValidatorData",
+                "summary": "Get API Keys",
+                "operationId": "getAPIKeys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResp200"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.GetAPIKeyResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "authorization": []
+                    }
+                ],
+                "description": "Create an API key with the read, trigger-jobs and/or admin scopes. The key is only returned once, only its hash is stored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create API Key",
+                "operationId": "createAPIKey",
+                "parameters": [
+                    {
+                        "description": "API key",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp201"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateAPIKeyResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -58,6 +158,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.FailedResp401"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -73,9 +179,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/scheduler/validator/hourly": {
-            "post": {
-                "description": "Scheduler For Hourly Collect Validator Data",
+        "/api/v1/admin/keys/{keyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "authorization": []
+                    }
+                ],
+                "description": "Revoke an API key, which is rejected from then on",
                 "consumes": [
                     "application/json"
                 ],
@@ -83,10 +194,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "validator"
+                    "admin"
+                ],
+                "summary": "Revoke API Key",
+                "operationId": "revokeAPIKey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key id",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Scheduler For Hourly Collect Validator Data",
-                "operationId": "schedulerForHourlyCollectValidatorData",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -106,6 +226,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.FailedResp401"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -121,9 +247,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/validators/{validatorAddress}/delegations/daily": {
+        "/api/v1/delegators/{delegatorAddress}": {
             "get": {
-                "description": "Get Daily Delegation Snapshot",
+                "description": "Get the current stake of a delegator at every tracked validator, the total stake and the latest changes",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,10 +257,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "validator"
+                    "delegator"
+                ],
+                "summary": "Get Delegator Summary",
+                "operationId": "getDelegatorSummary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delegator address",
+                        "name": "delegatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone for timestamps, defaults to the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Daily Delegation Snapshot",
-                "operationId": "getDailyDelegationSnapshot",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -147,7 +288,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDailySnapshotResponse"
+                                            "$ref": "#/definitions/dto.GetDelegatorSummaryResponse"
                                         }
                                     }
                                 }
@@ -181,9 +322,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/validators/{validatorAddress}/delegations/hourly": {
+        "/api/v1/delegators/{delegatorAddress}/history": {
             "get": {
-                "description": "Get Hourly Delegation Snapshot",
+                "description": "Get the stake changes of a delegator across every tracked validator",
                 "consumes": [
                     "application/json"
                 ],
@@ -191,10 +332,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "validator"
+                    "delegator"
+                ],
+                "summary": "Get Delegator Change History",
+                "operationId": "getDelegatorChangeHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delegator address",
+                        "name": "delegatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day to include, YYYY-MM-DD in tz",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day to include, YYYY-MM-DD in tz",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "date",
+                            "-date"
+                        ],
+                        "type": "string",
+                        "description": "Sort by timestamp",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone for timestamps and the date range, defaults to the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Hourly Delegation Snapshot",
-                "operationId": "getHourlyDelegationSnapshot",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -207,7 +397,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetHourlySnapshotResponse"
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDelegatorChangeResponse"
                                         }
                                     }
                                 }
@@ -241,9 +431,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/validators/{validatorAddress}/delegator/{delegatorAddress}/history": {
-            "get": {
-                "description": "Get Delegator History",
+        "/api/v1/scheduler/validator/daily": {
+            "post": {
+                "security": [
+                    {
+                        "authorization": []
+                    }
+                ],
+                "description": "Scheduler For Daily Collect Validator Data",
                 "consumes": [
                     "application/json"
                 ],
@@ -253,25 +448,13 @@ const docTemplate = `{
                 "tags": [
                     "validator"
                 ],
-                "summary": "Get Delegator History",
-                "operationId": "getDelegatorHistory",
+                "summary": "Scheduler For Daily Collect Validator Data",
+                "operationId": "schedulerForDailyCollectValidatorData",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.SuccessResp200"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDelegatorHistoryResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.SuccessResp200"
                         }
                     },
                     "400": {
@@ -286,6 +469,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.FailedResp401"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -300,104 +489,1691 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.ErrorMsgResp": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.FailedResp400": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ErrorMsgResp"
-                    }
-                },
-                "statusCode": {
-                    "type": "integer",
-                    "default": 400
-                },
-                "success": {
-                    "type": "boolean",
-                    "default": false
-                }
-            }
         },
-        "dto.FailedResp401": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ErrorMsgResp"
+        "/api/v1/scheduler/validator/events": {
+            "post": {
+                "security": [
+                    {
+                        "authorization": []
                     }
-                },
-                "statusCode": {
-                    "type": "integer",
-                    "default": 401
-                },
-                "success": {
-                    "type": "boolean",
-                    "default": false
-                }
-            }
-        },
-        "dto.FailedResp404": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ErrorMsgResp"
+                ],
+                "description": "Record the new, churned and returned delegators of every finished day that is not processed yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Scheduler For Delegator Events Validator Data",
+                "operationId": "schedulerForDelegatorEventsValidatorData",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResp200"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
                     }
-                },
-                "statusCode": {
-                    "type": "integer",
-                    "default": 404
-                },
-                "success": {
-                    "type": "boolean",
-                    "default": false
                 }
             }
         },
-        "dto.FailedResp500": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ErrorMsgResp"
+        "/api/v1/scheduler/validator/export": {
+            "post": {
+                "security": [
+                    {
+                        "authorization": []
                     }
-                },
-                "statusCode": {
+                ],
+                "description": "Export every finished day of delegation snapshots and daily aggregates that is not exported yet to parquet files",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Scheduler For Parquet Export Validator Data",
+                "operationId": "schedulerForParquetExportValidatorData",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResp200"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/scheduler/validator/hourly": {
+            "post": {
+                "security": [
+                    {
+                        "authorization": []
+                    }
+                ],
+                "description": "Scheduler For Hourly Collect Validator Data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Scheduler For Hourly Collect Validator Data",
+                "operationId": "schedulerForHourlyCollectValidatorData",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResp200"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/scheduler/validator/retention": {
+            "post": {
+                "security": [
+                    {
+                        "authorization": []
+                    }
+                ],
+                "description": "Downsample hourly snapshots older than the retention period into daily aggregates and remove them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Scheduler For Retention Validator Data",
+                "operationId": "schedulerForRetentionValidatorData",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only report how many hourly rows would be removed",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResp200"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/compare": {
+            "get": {
+                "description": "Get the daily total stake, delegator count, net flow and commission of several validators, aligned on the same days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Compare Validator",
+                "operationId": "compareValidator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated validator addresses, at most 10",
+                        "name": "addresses",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day to include, YYYY-MM-DD, default 29 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day to include, YYYY-MM-DD, default today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CompareValidatorResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/analytics/cohorts": {
+            "get": {
+                "description": "Group the delegators of a validator by the week or month of their first delegation and report per cohort how many are still delegated and how much of their initial stake remains after every period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Delegator Cohort",
+                "operationId": "getDelegatorCohort",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator address",
+                        "name": "validatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Cohort period",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of periods to follow every cohort for",
+                        "name": "periods",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone for the period boundaries, defaults to the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.GetDelegatorCohortResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/analytics/concentration": {
+            "get": {
+                "description": "Get the daily stake concentration of a validator: Gini coefficient, Herfindahl index, top 10 / top 100 share and the minimum number of delegators holding 33% / 50% of the stake",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Concentration Metric",
+                "operationId": "getConcentrationMetric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator address",
+                        "name": "validatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day to include, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day to include, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetConcentrationMetricResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/delegations": {
+            "get": {
+                "description": "Get the balance of every delegator of a validator as of an instant, from their latest snapshot at or before it. With compareTo, get the change of every delegator between the two instants instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Delegation As Of",
+                "operationId": "getDelegationAsOf",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator address",
+                        "name": "validatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instant to read the balances at, RFC3339, default now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instant to compare the balances with, RFC3339",
+                        "name": "compareTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by amount, -amount, or with compareTo also change, -change, default -amount",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone of the returned timestamps, default the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDelegationAsOfResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/delegations/daily": {
+            "get": {
+                "description": "Get Daily Delegation Snapshot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Daily Delegation Snapshot",
+                "operationId": "getDailyDelegationSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IANA timezone for timestamps and daily buckets, defaults to the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Stream the full result set as csv or ndjson instead of a JSON page, also set by the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDailySnapshotResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/delegations/hourly": {
+            "get": {
+                "description": "Get Hourly Delegation Snapshot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Hourly Delegation Snapshot",
+                "operationId": "getHourlyDelegationSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IANA timezone for timestamps and daily buckets, defaults to the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Stream the full result set as csv or ndjson instead of a JSON page, also set by the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetHourlySnapshotResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/delegator/{delegatorAddress}/history": {
+            "get": {
+                "description": "Get Delegator History",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Delegator History",
+                "operationId": "getDelegatorHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IANA timezone for timestamps and daily buckets, defaults to the reporting timezone",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Stream the full result set as csv or ndjson instead of a JSON page, also set by the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDelegatorHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/delegators/events": {
+            "get": {
+                "description": "Get the delegators of a validator that appeared for the first time, left entirely or came back, per day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Delegator Event",
+                "operationId": "getDelegatorEvent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator address",
+                        "name": "validatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type: new, churned or returned",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day to include, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day to include, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginationResp-dto_GetDelegatorEventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/delegators/events/daily": {
+            "get": {
+                "description": "Get the number of new, churned and returned delegators of a validator and their stake, per day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Daily Delegator Event",
+                "operationId": "getDailyDelegatorEvent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator address",
+                        "name": "validatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type: new, churned or returned",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day to include, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day to include, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.GetDailyDelegatorEventResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validators/{validatorAddress}/distribution": {
+            "get": {
+                "description": "Get how many delegators and how much stake fall in each balance bucket of a validator, now or on a past day, with the change versus a comparison day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validator"
+                ],
+                "summary": "Get Delegator Distribution",
+                "operationId": "getDelegatorDistribution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator address",
+                        "name": "validatorAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated ascending bucket boundaries in ATOM, default 1,10,100,1000,10000,100000",
+                        "name": "buckets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day to read the distribution of from the daily aggregates, YYYY-MM-DD, default the current delegations",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day to compare the distribution with, YYYY-MM-DD",
+                        "name": "compareDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResp200"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.GetDelegatorDistributionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FailedResp500"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Report that the process is up and serving requests, without checking its dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness",
+                "operationId": "getLiveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetLivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Check Postgres, Redis, the migration version and the freshness of the last hourly collection, with the status and latency of each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness",
+                "operationId": "getReadiness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetReadinessResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.CompareValidatorPointResponse": {
+            "type": "object",
+            "properties": {
+                "commission": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "delegators": {
+                    "type": "integer"
+                },
+                "netFlow": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CompareValidatorResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CompareValidatorSeriesResponse"
+                    }
+                }
+            }
+        },
+        "dto.CompareValidatorSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CompareValidatorPointResponse"
+                    }
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.FailedResp400": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_request"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ValidationErrorResp"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "default": 400
+                },
+                "title": {
+                    "type": "string",
+                    "default": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "about:blank"
+                }
+            }
+        },
+        "dto.FailedResp401": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "unauthorized"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "default": 401
+                },
+                "title": {
+                    "type": "string",
+                    "default": "Unauthorized"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "about:blank"
+                }
+            }
+        },
+        "dto.FailedResp403": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "forbidden"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "default": 403
+                },
+                "title": {
+                    "type": "string",
+                    "default": "Forbidden"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "about:blank"
+                }
+            }
+        },
+        "dto.FailedResp404": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "default": 404
+                },
+                "title": {
+                    "type": "string",
+                    "default": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "about:blank"
+                }
+            }
+        },
+        "dto.FailedResp500": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "internal_error"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "integer",
                     "default": 500
                 },
-                "success": {
-                    "type": "boolean",
-                    "default": false
+                "title": {
+                    "type": "string",
+                    "default": "Internal Server Error"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "about:blank"
+                }
+            }
+        },
+        "dto.GetAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keyPrefix": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.GetConcentrationMetricResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "delegators": {
+                    "type": "integer"
+                },
+                "giniCoefficient": {
+                    "type": "number"
+                },
+                "herfindahlIndex": {
+                    "type": "number"
+                },
+                "nakamoto33": {
+                    "type": "integer"
+                },
+                "nakamoto50": {
+                    "type": "integer"
+                },
+                "top100Share": {
+                    "type": "number"
+                },
+                "top10Share": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetDailyDelegatorEventResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "delegators": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetDailySnapshotResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetDelegationAsOfResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "compareAmount": {
+                    "type": "integer"
+                },
+                "delegatorAddress": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.GetDailySnapshotResponse": {
+        "dto.GetDelegatorChangeResponse": {
             "type": "object",
             "properties": {
-                "address": {
+                "amount": {
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "validatorAddress": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetDelegatorCohortPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "period": {
+                    "type": "integer"
+                },
+                "remainingAmount": {
+                    "type": "integer"
+                },
+                "remainingStakeRate": {
+                    "type": "number"
+                },
+                "retainedDelegators": {
+                    "type": "integer"
+                },
+                "retainedRate": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.GetDelegatorCohortResponse": {
+            "type": "object",
+            "properties": {
+                "cohortStart": {
+                    "type": "string"
+                },
+                "delegators": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "integer"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegatorCohortPeriodResponse"
+                    }
+                }
+            }
+        },
+        "dto.GetDelegatorDelegationResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "validatorAddress": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetDelegatorDistributionBucketResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amountChange": {
+                    "type": "integer"
+                },
+                "delegators": {
+                    "type": "integer"
+                },
+                "delegatorsChange": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.GetDelegatorDistributionResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegatorDistributionBucketResponse"
+                    }
+                },
+                "compareDate": {
                     "type": "string"
                 },
                 "date": {
                     "type": "string"
                 },
+                "delegators": {
+                    "type": "integer"
+                },
+                "delegatorsChange": {
+                    "type": "integer"
+                },
                 "total": {
                     "type": "integer"
+                },
+                "totalChange": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetDelegatorEventResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "delegatorAddress": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
@@ -415,6 +2191,49 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.GetDelegatorSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "delegations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegatorDelegationResponse"
+                    }
+                },
+                "delegatorAddress": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegatorChangeResponse"
+                    }
+                },
+                "totalAmount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetHealthCheckResponse": {
+            "type": "object",
+            "properties": {
+                "lastRun": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "number"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.GetHourlySnapshotResponse": {
             "type": "object",
             "properties": {
@@ -435,6 +2254,28 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.GetLivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.GetHealthCheckResponse"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.Next": {
             "type": "object",
             "properties": {
@@ -443,6 +2284,29 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PaginationResp-dto_GetConcentrationMetricResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetConcentrationMetricResponse"
+                    }
+                },
+                "isLoadMore": {
+                    "type": "boolean"
+                },
+                "next": {
+                    "$ref": "#/definitions/dto.Next"
+                },
+                "prev": {
+                    "$ref": "#/definitions/dto.Prev"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.PaginationResp-dto_GetDailySnapshotResponse": {
             "type": "object",
             "properties": {
@@ -466,6 +2330,75 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PaginationResp-dto_GetDelegationAsOfResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegationAsOfResponse"
+                    }
+                },
+                "isLoadMore": {
+                    "type": "boolean"
+                },
+                "next": {
+                    "$ref": "#/definitions/dto.Next"
+                },
+                "prev": {
+                    "$ref": "#/definitions/dto.Prev"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PaginationResp-dto_GetDelegatorChangeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegatorChangeResponse"
+                    }
+                },
+                "isLoadMore": {
+                    "type": "boolean"
+                },
+                "next": {
+                    "$ref": "#/definitions/dto.Next"
+                },
+                "prev": {
+                    "$ref": "#/definitions/dto.Prev"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PaginationResp-dto_GetDelegatorEventResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDelegatorEventResponse"
+                    }
+                },
+                "isLoadMore": {
+                    "type": "boolean"
+                },
+                "next": {
+                    "$ref": "#/definitions/dto.Next"
+                },
+                "prev": {
+                    "$ref": "#/definitions/dto.Prev"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.PaginationResp-dto_GetDelegatorHistoryResponse": {
             "type": "object",
             "properties": {
@@ -533,6 +2466,34 @@ const docTemplate = `{
                     "default": true
                 }
             }
+        },
+        "dto.SuccessResp201": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "statusCode": {
+                    "type": "integer",
+                    "default": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "default": true
+                }
+            }
+        },
+        "dto.ValidationErrorResp": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -12,38 +12,72 @@ type SuccessResp201 struct {
 	Data       any  `json:"data"`
 }
 
-type ErrorMsgResp struct {
+// The FailedResp types document the RFC 7807 problem+json body of every error status
+type ValidationErrorResp struct {
 	Message string `json:"message"`
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
 }
 
 type FailedResp400 struct {
-	Success    bool           `json:"success" default:"false"`
-	StatusCode int            `json:"statusCode" default:"400"`
-	Errors     []ErrorMsgResp `json:"errors"`
+	Type      string                `json:"type" default:"about:blank"`
+	Title     string                `json:"title" default:"Bad Request"`
+	Status    int                   `json:"status" default:"400"`
+	Detail    string                `json:"detail"`
+	Instance  string                `json:"instance"`
+	Code      string                `json:"code" example:"invalid_request"`
+	RequestID string                `json:"requestId"`
+	Errors    []ValidationErrorResp `json:"errors,omitempty"`
 }
 
 type FailedResp401 struct {
-	Success    bool           `json:"success" default:"false"`
-	StatusCode int            `json:"statusCode" default:"401"`
-	Errors     []ErrorMsgResp `json:"errors"`
+	Type      string `json:"type" default:"about:blank"`
+	Title     string `json:"title" default:"Unauthorized"`
+	Status    int    `json:"status" default:"401"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code" example:"unauthorized"`
+	RequestID string `json:"requestId"`
+}
+
+type FailedResp403 struct {
+	Type      string `json:"type" default:"about:blank"`
+	Title     string `json:"title" default:"Forbidden"`
+	Status    int    `json:"status" default:"403"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code" example:"forbidden"`
+	RequestID string `json:"requestId"`
 }
 
 type FailedResp404 struct {
-	Success    bool           `json:"success" default:"false"`
-	StatusCode int            `json:"statusCode" default:"404"`
-	Errors     []ErrorMsgResp `json:"errors"`
+	Type      string `json:"type" default:"about:blank"`
+	Title     string `json:"title" default:"Not Found"`
+	Status    int    `json:"status" default:"404"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code" example:"not_found"`
+	RequestID string `json:"requestId"`
 }
 
 type FailedResp422 struct {
-	Success    bool           `json:"success" default:"false"`
-	StatusCode int            `json:"statusCode" default:"422"`
-	Errors     []ErrorMsgResp `json:"errors"`
+	Type      string `json:"type" default:"about:blank"`
+	Title     string `json:"title" default:"Unprocessable Entity"`
+	Status    int    `json:"status" default:"422"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code" example:"query_failed"`
+	RequestID string `json:"requestId"`
 }
 
 type FailedResp500 struct {
-	Success    bool           `json:"success" default:"false"`
-	StatusCode int            `json:"statusCode" default:"500"`
-	Errors     []ErrorMsgResp `json:"errors"`
+	Type      string `json:"type" default:"about:blank"`
+	Title     string `json:"title" default:"Internal Server Error"`
+	Status    int    `json:"status" default:"500"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code" example:"internal_error"`
+	RequestID string `json:"requestId"`
 }
 
 type DocPaginationResp struct {
@@ -4,12 +4,14 @@ type GetHourlySnapshotRequest struct {
 	ValidatorAddress string `json:"validatorAddress" validate:"required"`
 	Limit            int32  `json:"limit" validate:"required"`
 	Page             int32  `json:"page" validate:"required"`
+	Timezone         string `json:"tz"`
 }
 
 type GetDailySnapshotRequest struct {
 	ValidatorAddress string `json:"validatorAddress" validate:"required"`
 	Limit            int32  `json:"limit" validate:"required"`
 	Page             int32  `json:"page" validate:"required"`
+	Timezone         string `json:"tz"`
 }
 
 type GetDelegatorHistoryRequest struct {
@@ -18,4 +20,96 @@ type GetDelegatorHistoryRequest struct {
 	SortBy           string `json:"sortBy" validate:"required"`
 	Limit            int32  `json:"limit" validate:"required"`
 	Page             int32  `json:"page" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type ExportHourlySnapshotRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type ExportDailySnapshotRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type ExportDelegatorHistoryRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	DelegatorAddress string `json:"delegatorAddress" validate:"required"`
+	SortBy           string `json:"sortBy" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type GetDelegatorSummaryRequest struct {
+	DelegatorAddress string `json:"delegatorAddress" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type GetDelegatorChangeHistoryRequest struct {
+	DelegatorAddress string `json:"delegatorAddress" validate:"required"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	SortBy           string `json:"sortBy" validate:"required"`
+	Limit            int32  `json:"limit" validate:"required"`
+	Page             int32  `json:"page" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type GetDelegatorCohortRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	Period           string `json:"period" validate:"required"`
+	Periods          int32  `json:"periods" validate:"required"`
+	Timezone         string `json:"tz"`
+}
+
+type GetConcentrationMetricRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Limit            int32  `json:"limit" validate:"required"`
+	Page             int32  `json:"page" validate:"required"`
+}
+
+type GetDelegatorEventRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	Type             string `json:"type"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Limit            int32  `json:"limit" validate:"required"`
+	Page             int32  `json:"page" validate:"required"`
+}
+
+type GetDailyDelegatorEventRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	Type             string `json:"type"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+}
+
+type CompareValidatorRequest struct {
+	Addresses []string `json:"addresses" validate:"required"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+}
+
+type GetDelegatorDistributionRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	Buckets          string `json:"buckets" validate:"required"`
+	Date             string `json:"date"`
+	CompareDate      string `json:"compareDate"`
+}
+
+type GetDelegationAsOfRequest struct {
+	ValidatorAddress string `json:"validatorAddress" validate:"required"`
+	At               string `json:"at"`
+	CompareTo        string `json:"compareTo"`
+	SortBy           string `json:"sortBy"`
+	Timezone         string `json:"tz"`
+	Limit            int32  `json:"limit" validate:"required"`
+	Page             int32  `json:"page" validate:"required"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=read trigger-jobs admin"`
 }
@@ -19,3 +19,145 @@ type GetDelegatorHistoryResponse struct {
 	Amount    int64  `json:"amount"`
 	Change    int64  `json:"change"`
 }
+
+type GetDelegatorSummaryResponse struct {
+	DelegatorAddress string                           `json:"delegatorAddress"`
+	TotalAmount      int64                            `json:"totalAmount"`
+	Delegations      []GetDelegatorDelegationResponse `json:"delegations"`
+	History          []GetDelegatorChangeResponse     `json:"history"`
+}
+
+type GetDelegatorDelegationResponse struct {
+	ValidatorAddress string `json:"validatorAddress"`
+	Amount           int64  `json:"amount"`
+	Timestamp        string `json:"timestamp"`
+}
+
+type GetDelegatorChangeResponse struct {
+	ValidatorAddress string `json:"validatorAddress"`
+	Timestamp        string `json:"timestamp"`
+	Amount           int64  `json:"amount"`
+	Change           int64  `json:"change"`
+}
+
+type GetDelegatorCohortResponse struct {
+	CohortStart   string                             `json:"cohortStart"`
+	Delegators    int64                              `json:"delegators"`
+	InitialAmount int64                              `json:"initialAmount"`
+	Periods       []GetDelegatorCohortPeriodResponse `json:"periods"`
+}
+
+type GetDelegatorCohortPeriodResponse struct {
+	Period             int32   `json:"period"`
+	RetainedDelegators int64   `json:"retainedDelegators"`
+	RetainedRate       float64 `json:"retainedRate"`
+	RemainingAmount    int64   `json:"remainingAmount"`
+	RemainingStakeRate float64 `json:"remainingStakeRate"`
+}
+
+type GetConcentrationMetricResponse struct {
+	Date            string  `json:"date"`
+	Delegators      int64   `json:"delegators"`
+	Total           int64   `json:"total"`
+	GiniCoefficient float64 `json:"giniCoefficient"`
+	HerfindahlIndex float64 `json:"herfindahlIndex"`
+	Top10Share      float64 `json:"top10Share"`
+	Top100Share     float64 `json:"top100Share"`
+	Nakamoto33      int64   `json:"nakamoto33"`
+	Nakamoto50      int64   `json:"nakamoto50"`
+}
+
+type GetDelegatorDistributionResponse struct {
+	Date             string                                   `json:"date,omitempty"`
+	CompareDate      string                                   `json:"compareDate,omitempty"`
+	Delegators       int64                                    `json:"delegators"`
+	Total            int64                                    `json:"total"`
+	DelegatorsChange *int64                                   `json:"delegatorsChange,omitempty"`
+	TotalChange      *int64                                   `json:"totalChange,omitempty"`
+	Buckets          []GetDelegatorDistributionBucketResponse `json:"buckets"`
+}
+
+type GetDelegatorDistributionBucketResponse struct {
+	Min              float64  `json:"min"`
+	Max              *float64 `json:"max"`
+	Delegators       int64    `json:"delegators"`
+	Amount           int64    `json:"amount"`
+	DelegatorsChange *int64   `json:"delegatorsChange,omitempty"`
+	AmountChange     *int64   `json:"amountChange,omitempty"`
+}
+
+type GetDelegatorEventResponse struct {
+	Date             string `json:"date"`
+	DelegatorAddress string `json:"delegatorAddress"`
+	Type             string `json:"type"`
+	Amount           int64  `json:"amount"`
+}
+
+type GetDailyDelegatorEventResponse struct {
+	Date       string `json:"date"`
+	Type       string `json:"type"`
+	Delegators int64  `json:"delegators"`
+	Amount     int64  `json:"amount"`
+}
+
+type CompareValidatorResponse struct {
+	From       string                           `json:"from"`
+	To         string                           `json:"to"`
+	Validators []CompareValidatorSeriesResponse `json:"validators"`
+}
+
+type CompareValidatorSeriesResponse struct {
+	Address string                          `json:"address"`
+	Series  []CompareValidatorPointResponse `json:"series"`
+}
+
+type CompareValidatorPointResponse struct {
+	Date       string   `json:"date"`
+	Total      *int64   `json:"total"`
+	Delegators *int64   `json:"delegators"`
+	NetFlow    *int64   `json:"netFlow"`
+	Commission *float64 `json:"commission"`
+}
+
+type GetDelegationAsOfResponse struct {
+	DelegatorAddress string `json:"delegatorAddress"`
+	Amount           int64  `json:"amount"`
+	Timestamp        string `json:"timestamp,omitempty"`
+	CompareAmount    *int64 `json:"compareAmount,omitempty"`
+	Change           *int64 `json:"change,omitempty"`
+}
+
+type CreateAPIKeyResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Key       string   `json:"key"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+type GetAPIKeyResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	KeyPrefix  string   `json:"keyPrefix"`
+	Scopes     []string `json:"scopes"`
+	LastUsedAt *string  `json:"lastUsedAt"`
+	RevokedAt  *string  `json:"revokedAt"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+type GetLivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type GetReadinessResponse struct {
+	Status string                            `json:"status"`
+	Checks map[string]GetHealthCheckResponse `json:"checks"`
+}
+
+type GetHealthCheckResponse struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Message   string  `json:"message,omitempty"`
+	Version   *int64  `json:"version,omitempty"`
+	LastRun   *string `json:"lastRun,omitempty"`
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/service"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/go-chi/chi"
+)
+
+type APIKeyHandler interface {
+	SetupAPIKeyRoutes(route chi.Router)
+}
+
+type APIKeyHandlerImpl struct {
+	apiKeyService service.APIKeySvc
+	logger        utils.LoggerSvc
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeySvc, logger utils.LoggerSvc) APIKeyHandler {
+	return &APIKeyHandlerImpl{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKey godoc
+// @Id createAPIKey
+// @Summary      Create API Key
+// @Description  Create an API key with the read, trigger-jobs and/or admin scopes. The key is only returned once, only its hash is stored
+// @Tags         admin
+// @Accept 		 json
+// @Produce      json
+// @Security     authorization
+// @Param        payload  body  dto.CreateAPIKeyRequest  true  "API key"
+// @Success      201  {object}  dto.SuccessResp201{data=dto.CreateAPIKeyResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/admin/keys [post]
+func (h *APIKeyHandlerImpl) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	req := utils.ValidateBodyPayload(r.Body, &dto.CreateAPIKeyRequest{})
+
+	resp, err := h.apiKeyService.CreateAPIKey(r.Context(), req)
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusCreated)
+}
+
+// GetAPIKeys godoc
+// @Id getAPIKeys
+// @Summary      Get API Keys
+// @Description  Get every API key, including the revoked ones, without the keys themselves
+// @Tags         admin
+// @Accept 		 json
+// @Produce      json
+// @Security     authorization
+// @Success      200  {object}  dto.SuccessResp200{data=[]dto.GetAPIKeyResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/admin/keys [get]
+func (h *APIKeyHandlerImpl) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.apiKeyService.GetAPIKeys(r.Context())
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// RevokeAPIKey godoc
+// @Id revokeAPIKey
+// @Summary      Revoke API Key
+// @Description  Revoke an API key, which is rejected from then on
+// @Tags         admin
+// @Accept 		 json
+// @Produce      json
+// @Security     authorization
+// @Param        keyId  path  string  true  "API key id"
+// @Success      200  {object}  dto.SuccessResp200
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/admin/keys/{keyId} [delete]
+func (h *APIKeyHandlerImpl) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID := utils.ValidateURLParamUUID(r, "keyId")
+
+	err := h.apiKeyService.RevokeAPIKey(r.Context(), keyID)
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp[any](w, nil, http.StatusOK)
+}
+
+func (h *APIKeyHandlerImpl) SetupAPIKeyRoutes(route chi.Router) {
+	setupAPIKeyV1Routes(route, h)
+}
+
+func setupAPIKeyV1Routes(route chi.Router, h *APIKeyHandlerImpl) {
+	route.Post("/api/v1/admin/keys", h.CreateAPIKey)
+	route.Get("/api/v1/admin/keys", h.GetAPIKeys)
+	route.Delete("/api/v1/admin/keys/{keyId}", h.RevokeAPIKey)
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/service"
+	mocksvc "github.com/gadhittana01/cosmos-validation-tracking/service/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKeyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+	loggerMock := mockutl.NewMockLoggerSvc(ctrl)
+
+	type args struct {
+		service service.APIKeySvc
+		logger  utils.LoggerSvc
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *APIKeyHandlerImpl
+	}{
+		{
+			args: args{
+				service: apiKeyMock,
+				logger:  loggerMock,
+			},
+			want: &APIKeyHandlerImpl{
+				apiKeyService: apiKeyMock,
+				logger:        loggerMock,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAPIKeyHandler(tt.args.service, tt.args.logger); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAPIKeyHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	sampleReq := httptest.NewRequest("POST", "http://localhost:8000/api/v1/admin/keys", strings.NewReader(`{"name":"collector","scopes":["trigger-jobs"]}`))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("POST", "http://localhost:8000/api/v1/admin/keys", strings.NewReader(`{"name":"collector","scopes":["write"]}`))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.APIKeySvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success create api key",
+			fields: func() fields {
+				apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+
+				apiKeyMock.EXPECT().CreateAPIKey(gomock.Any(), dto.CreateAPIKeyRequest{
+					Name:   "collector",
+					Scopes: []string{constant.APIKeyScopeTriggerJobs},
+				}).Return(dto.CreateAPIKeyResponse{
+					Name:   "collector",
+					Key:    "vtk_0123abcd",
+					Scopes: []string{constant.APIKeyScopeTriggerJobs},
+				}, nil).Times(1)
+
+				return fields{
+					service: apiKeyMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid scope",
+			fields: func() fields {
+				apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+
+				apiKeyMock.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: apiKeyMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := APIKeyHandlerImpl{
+				apiKeyService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.CreateAPIKey(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.CreateAPIKey(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+
+	sampleReq := httptest.NewRequest("GET", "http://localhost:8000/api/v1/admin/keys", strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	apiKeyMock.EXPECT().GetAPIKeys(gomock.Any()).Return([]dto.GetAPIKeyResponse{
+		{Name: "dashboard", KeyPrefix: "vtk_0123abcd", Scopes: []string{constant.APIKeyScopeRead}},
+	}, nil).Times(1)
+
+	i := APIKeyHandlerImpl{
+		apiKeyService: apiKeyMock,
+	}
+
+	assert.NotPanics(t, func() {
+		i.GetAPIKeys(sampleResp, sampleReq)
+	})
+	assert.Equal(t, http.StatusOK, sampleResp.Code)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	keyID := uuid.New()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("keyId", keyID.String())
+	sampleReq := httptest.NewRequest("DELETE", fmt.Sprintf("http://localhost:8000/api/v1/admin/keys/%s", keyID), strings.NewReader(``))
+	sampleReq = sampleReq.WithContext(context.WithValue(sampleReq.Context(), chi.RouteCtxKey, rctx))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("DELETE", "http://localhost:8000/api/v1/admin/keys/test", strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.APIKeySvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success revoke api key",
+			fields: func() fields {
+				apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+
+				apiKeyMock.EXPECT().RevokeAPIKey(gomock.Any(), keyID).Times(1)
+
+				return fields{
+					service: apiKeyMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+
+				apiKeyMock.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: apiKeyMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := APIKeyHandlerImpl{
+				apiKeyService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.RevokeAPIKey(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.RevokeAPIKey(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestRevokeAPIKeyNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	apiKeyMock := mocksvc.NewMockAPIKeySvc(ctrl)
+	loggerMock := mockutl.NewMockLoggerSvc(ctrl)
+	mockutl.LoggerMock(loggerMock)
+	keyID := uuid.New()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("keyId", keyID.String())
+	sampleReq := httptest.NewRequest("DELETE", fmt.Sprintf("http://localhost:8000/api/v1/admin/keys/%s", keyID), strings.NewReader(``))
+	sampleReq = sampleReq.WithContext(context.WithValue(utils.ContextWithRequestID(sampleReq.Context(), "request-1"), chi.RouteCtxKey, rctx))
+	sampleResp := httptest.NewRecorder()
+
+	apiKeyMock.EXPECT().RevokeAPIKey(gomock.Any(), keyID).Return(utils.NewAppError(constant.ErrorCodeNotFound, "api key not found", http.StatusNotFound)).Times(1)
+
+	i := APIKeyHandlerImpl{
+		apiKeyService: apiKeyMock,
+		logger:        loggerMock,
+	}
+
+	assert.NotPanics(t, func() {
+		i.RevokeAPIKey(sampleResp, sampleReq)
+	})
+	assert.Equal(t, http.StatusNotFound, sampleResp.Code)
+	assert.Equal(t, constant.ProblemContentType, sampleResp.Header().Get("Content-Type"))
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "api key not found",
+		"instance": "/api/v1/admin/keys/%s",
+		"code": "not_found",
+		"requestId": "request-1"
+	}`, keyID), sampleResp.Body.String())
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/service"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/go-chi/chi"
+)
+
+type DelegatorHandler interface {
+	SetupDelegatorRoutes(route chi.Router)
+}
+
+type DelegatorHandlerImpl struct {
+	delegatorService service.DelegatorSvc
+	logger           utils.LoggerSvc
+}
+
+func NewDelegatorHandler(delegatorService service.DelegatorSvc, logger utils.LoggerSvc) DelegatorHandler {
+	return &DelegatorHandlerImpl{
+		delegatorService: delegatorService,
+		logger:           logger,
+	}
+}
+
+// GetDelegatorSummary godoc
+// @Id getDelegatorSummary
+// @Summary      Get Delegator Summary
+// @Description  Get the current stake of a delegator at every tracked validator, the total stake and the latest changes
+// @Tags         delegator
+// @Accept 		 json
+// @Produce      json
+// @Param        delegatorAddress  path  string  true  "Delegator address"
+// @Param        tz  query  string  false  "IANA timezone for timestamps, defaults to the reporting timezone"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.GetDelegatorSummaryResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/delegators/{delegatorAddress} [get]
+func (h *DelegatorHandlerImpl) GetDelegatorSummary(w http.ResponseWriter, r *http.Request) {
+	delegatorAddress := utils.ValidateURLParamString(r, "delegatorAddress")
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+
+	resp, err := h.delegatorService.GetDelegatorSummary(r.Context(), dto.GetDelegatorSummaryRequest{
+		DelegatorAddress: delegatorAddress,
+		Timezone:         timezone,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetDelegatorChangeHistory godoc
+// @Id getDelegatorChangeHistory
+// @Summary      Get Delegator Change History
+// @Description  Get the stake changes of a delegator across every tracked validator
+// @Tags         delegator
+// @Accept 		 json
+// @Produce      json
+// @Param        delegatorAddress  path  string  true  "Delegator address"
+// @Param        from  query  string  false  "First day to include, YYYY-MM-DD in tz"
+// @Param        to  query  string  false  "Last day to include, YYYY-MM-DD in tz"
+// @Param        sortBy  query  string  false  "Sort by timestamp"  Enums(date, -date)
+// @Param        page  query  int  false  "Page"
+// @Param        limit  query  int  false  "Limit"
+// @Param        tz  query  string  false  "IANA timezone for timestamps and the date range, defaults to the reporting timezone"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetDelegatorChangeResponse]}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/delegators/{delegatorAddress}/history [get]
+func (h *DelegatorHandlerImpl) GetDelegatorChangeHistory(w http.ResponseWriter, r *http.Request) {
+	delegatorAddress := utils.ValidateURLParamString(r, "delegatorAddress")
+	from := utils.ValidateQueryParamDate(r, "from")
+	to := utils.ValidateQueryParamDate(r, "to")
+	sortBy := utils.ValidateQueryParamString(r, "sortBy", "date")
+	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
+	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+
+	resp, err := h.delegatorService.GetDelegatorChangeHistory(r.Context(), dto.GetDelegatorChangeHistoryRequest{
+		DelegatorAddress: delegatorAddress,
+		From:             from,
+		To:               to,
+		SortBy:           sortBy,
+		Page:             int32(page),
+		Limit:            int32(limit),
+		Timezone:         timezone,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+func (h *DelegatorHandlerImpl) SetupDelegatorRoutes(route chi.Router) {
+	setupDelegatorV1Routes(route, h)
+}
+
+func setupDelegatorV1Routes(route chi.Router, h *DelegatorHandlerImpl) {
+	route.Get("/api/v1/delegators/{delegatorAddress}", h.GetDelegatorSummary)
+	route.Get("/api/v1/delegators/{delegatorAddress}/history", h.GetDelegatorChangeHistory)
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/service"
+	mocksvc "github.com/gadhittana01/cosmos-validation-tracking/service/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDelegatorHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	delegatorMock := mocksvc.NewMockDelegatorSvc(ctrl)
+	loggerMock := mockutl.NewMockLoggerSvc(ctrl)
+
+	type args struct {
+		service service.DelegatorSvc
+		logger  utils.LoggerSvc
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *DelegatorHandlerImpl
+	}{
+		{
+			args: args{
+				service: delegatorMock,
+				logger:  loggerMock,
+			},
+			want: &DelegatorHandlerImpl{
+				delegatorService: delegatorMock,
+				logger:           loggerMock,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDelegatorHandler(tt.args.service, tt.args.logger); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDelegatorHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDelegatorSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	delegatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/delegators/%s", delegatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidTzSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/delegators/%s?tz=Mars/Olympus", delegatorAddress), strings.NewReader(``))
+	invalidTzSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.DelegatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get delegator summary",
+			fields: func() fields {
+				delegatorMock := mocksvc.NewMockDelegatorSvc(ctrl)
+
+				delegatorMock.EXPECT().GetDelegatorSummary(gomock.Any(), dto.GetDelegatorSummaryRequest{}).Return(dto.GetDelegatorSummaryResponse{
+					DelegatorAddress: delegatorAddress,
+					TotalAmount:      100,
+					Delegations: []dto.GetDelegatorDelegationResponse{
+						{
+							ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+							Amount:           100,
+							Timestamp:        "2021-01-01T00:00:00Z",
+						},
+					},
+					History: []dto.GetDelegatorChangeResponse{},
+				}, nil).Times(1)
+
+				return fields{
+					service: delegatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid timezone",
+			fields: func() fields {
+				delegatorMock := mocksvc.NewMockDelegatorSvc(ctrl)
+
+				delegatorMock.EXPECT().GetDelegatorSummary(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: delegatorMock,
+				}
+			},
+			args: args{
+				w:   invalidTzSampleResp,
+				req: invalidTzSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := DelegatorHandlerImpl{
+				delegatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDelegatorSummary(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDelegatorSummary(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetDelegatorChangeHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	delegatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	page := 1
+	limit := 10
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/delegators/%s/history?from=2025-04-01&to=2025-04-30&sortBy=-date&page=%d&limit=%d", delegatorAddress, page, limit), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/delegators/%s/history?page=%d&limit=test", delegatorAddress, page), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	invalidDateSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/delegators/%s/history?from=01-04-2025", delegatorAddress), strings.NewReader(``))
+	invalidDateSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.DelegatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get delegator change history",
+			fields: func() fields {
+				delegatorMock := mocksvc.NewMockDelegatorSvc(ctrl)
+
+				delegatorMock.EXPECT().GetDelegatorChangeHistory(gomock.Any(), dto.GetDelegatorChangeHistoryRequest{
+					From:   "2025-04-01",
+					To:     "2025-04-30",
+					SortBy: "-date",
+					Page:   int32(page),
+					Limit:  int32(limit),
+				}).Return(dto.PaginationResp[dto.GetDelegatorChangeResponse]{
+					Total:      1,
+					IsLoadMore: false,
+					Data: []dto.GetDelegatorChangeResponse{
+						{
+							ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+							Timestamp:        "2025-04-02T10:00:00Z",
+							Amount:           100,
+							Change:           100,
+						},
+					},
+				}, nil).Times(1)
+
+				return fields{
+					service: delegatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				delegatorMock := mocksvc.NewMockDelegatorSvc(ctrl)
+
+				delegatorMock.EXPECT().GetDelegatorChangeHistory(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: delegatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid date",
+			fields: func() fields {
+				delegatorMock := mocksvc.NewMockDelegatorSvc(ctrl)
+
+				delegatorMock.EXPECT().GetDelegatorChangeHistory(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: delegatorMock,
+				}
+			},
+			args: args{
+				w:   invalidDateSampleResp,
+				req: invalidDateSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := DelegatorHandlerImpl{
+				delegatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDelegatorChangeHistory(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDelegatorChangeHistory(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
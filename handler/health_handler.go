@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/service"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/go-chi/chi"
+)
+
+type HealthHandler interface {
+	SetupHealthRoutes(route chi.Router)
+}
+
+type HealthHandlerImpl struct {
+	healthService service.HealthSvc
+}
+
+func NewHealthHandler(healthService service.HealthSvc) HealthHandler {
+	return &HealthHandlerImpl{
+		healthService: healthService,
+	}
+}
+
+// GetLiveness godoc
+// @Id getLiveness
+// @Summary      Liveness
+// @Description  Report that the process is up and serving requests, without checking its dependencies
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.GetLivenessResponse
+// @Router       /health/live [get]
+func (h *HealthHandlerImpl) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	utils.GenerateDefaultResp(w, dto.GetLivenessResponse{
+		Status: constant.HealthStatusOK,
+	}, http.StatusOK)
+}
+
+// GetReadiness godoc
+// @Id getReadiness
+// @Summary      Readiness
+// @Description  Check Postgres, Redis, the migration version and the freshness of the last hourly collection, with the status and latency of each
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.GetReadinessResponse
+// @Failure      503  {object}  dto.GetReadinessResponse
+// @Router       /health/ready [get]
+func (h *HealthHandlerImpl) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	resp := h.healthService.CheckReadiness(r.Context())
+
+	statusCode := http.StatusOK
+	if resp.Status != constant.HealthStatusOK {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	utils.GenerateDefaultResp(w, resp, statusCode)
+}
+
+func (h *HealthHandlerImpl) SetupHealthRoutes(route chi.Router) {
+	setupHealthV1Routes(route, h)
+}
+
+func setupHealthV1Routes(route chi.Router, h *HealthHandlerImpl) {
+	route.Get("/health/live", h.GetLiveness)
+	route.Get("/health/ready", h.GetReadiness)
+}
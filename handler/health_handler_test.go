@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/service"
+	mocksvc "github.com/gadhittana01/cosmos-validation-tracking/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	healthMock := mocksvc.NewMockHealthSvc(ctrl)
+
+	type args struct {
+		service service.HealthSvc
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *HealthHandlerImpl
+	}{
+		{
+			args: args{
+				service: healthMock,
+			},
+			want: &HealthHandlerImpl{
+				healthService: healthMock,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewHealthHandler(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewHealthHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLiveness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	sampleReq := httptest.NewRequest("GET", "http://localhost:8000/health/live", strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	i := HealthHandlerImpl{
+		healthService: mocksvc.NewMockHealthSvc(ctrl),
+	}
+
+	assert.NotPanics(t, func() {
+		i.GetLiveness(sampleResp, sampleReq)
+	})
+	assert.Equal(t, http.StatusOK, sampleResp.Code)
+}
+
+func TestGetReadiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type fields struct {
+		service service.HealthSvc
+	}
+
+	tests := []struct {
+		name           string
+		fields         func() fields
+		wantStatusCode int
+	}{
+		{
+			name: "success get readiness",
+			fields: func() fields {
+				healthMock := mocksvc.NewMockHealthSvc(ctrl)
+
+				healthMock.EXPECT().CheckReadiness(gomock.Any()).Return(dto.GetReadinessResponse{
+					Status: constant.HealthStatusOK,
+					Checks: map[string]dto.GetHealthCheckResponse{
+						constant.HealthCheckPostgres: {Status: constant.HealthStatusOK},
+					},
+				}).Times(1)
+
+				return fields{
+					service: healthMock,
+				}
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "not ready",
+			fields: func() fields {
+				healthMock := mocksvc.NewMockHealthSvc(ctrl)
+
+				healthMock.EXPECT().CheckReadiness(gomock.Any()).Return(dto.GetReadinessResponse{
+					Status: constant.HealthStatusUnavailable,
+					Checks: map[string]dto.GetHealthCheckResponse{
+						constant.HealthCheckPostgres: {Status: constant.HealthStatusUnavailable, Message: "connection refused"},
+					},
+				}).Times(1)
+
+				return fields{
+					service: healthMock,
+				}
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := HealthHandlerImpl{
+				healthService: field.service,
+			}
+			sampleReq := httptest.NewRequest("GET", "http://localhost:8000/health/ready", strings.NewReader(``))
+			sampleResp := httptest.NewRecorder()
+
+			assert.NotPanics(t, func() {
+				i.GetReadiness(sampleResp, sampleReq)
+			})
+			assert.Equal(t, tt.wantStatusCode, sampleResp.Code)
+		})
+	}
+}
@@ -9,17 +9,19 @@ import (
 )
 
 type SchedulerHandler interface {
-	SetupSchedulerRoutes(route *chi.Mux)
+	SetupSchedulerRoutes(route chi.Router)
 }
 
 type schedulerHandlerImpl struct {
 	validatorScheduler scheduler.ValidatorScheduler
+	config             *utils.BaseConfig
 	logger             utils.LoggerSvc
 }
 
-func NewSchedulerHandler(validatorScheduler scheduler.ValidatorScheduler, logger utils.LoggerSvc) SchedulerHandler {
+func NewSchedulerHandler(validatorScheduler scheduler.ValidatorScheduler, config *utils.BaseConfig, logger utils.LoggerSvc) SchedulerHandler {
 	return &schedulerHandlerImpl{
 		validatorScheduler: validatorScheduler,
+		config:             config,
 		logger:             logger,
 	}
 }
@@ -31,9 +33,11 @@ func NewSchedulerHandler(validatorScheduler scheduler.ValidatorScheduler, logger
 // @Tags         validator
 // @Accept 		 json
 // @Produce      json
+// @Security     authorization
 // @Success      200  {object}  dto.SuccessResp200
 // @Failure      400  {object}  dto.FailedResp400
 // @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
 // @Failure      404  {object}  dto.FailedResp404
 // @Failure      500  {object}  dto.FailedResp500
 // @Router       /api/v1/scheduler/validator/hourly [post]
@@ -50,9 +54,11 @@ func (h *schedulerHandlerImpl) SchedulerForHourlyCollectValidatorData(w http.Res
 // @Tags         validator
 // @Accept 		 json
 // @Produce      json
+// @Security     authorization
 // @Success      200  {object}  dto.SuccessResp200
 // @Failure      400  {object}  dto.FailedResp400
 // @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
 // @Failure      404  {object}  dto.FailedResp404
 // @Failure      500  {object}  dto.FailedResp500
 // @Router       /api/v1/scheduler/validator/daily [post]
@@ -62,11 +68,80 @@ func (h *schedulerHandlerImpl) SchedulerForDailyCollectValidatorData(w http.Resp
 	utils.GenerateSuccessResp[any](w, nil, 200)
 }
 
-func (h *schedulerHandlerImpl) SetupSchedulerRoutes(route *chi.Mux) {
+// SchedulerForRetentionValidatorData godoc
+// @Id schedulerForRetentionValidatorData
+// @Summary      Scheduler For Retention Validator Data
+// @Description  Downsample hourly snapshots older than the retention period into daily aggregates and remove them
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Security     authorization
+// @Param        dryRun  query  bool  false  "Only report how many hourly rows would be removed"
+// @Success      200  {object}  dto.SuccessResp200
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/scheduler/validator/retention [post]
+func (h *schedulerHandlerImpl) SchedulerForRetentionValidatorData(w http.ResponseWriter, r *http.Request) {
+	dryRun := utils.ValidateQueryParamBool(r, "dryRun", h.config.RetentionDryRun)
+
+	h.validatorScheduler.SchedulerForRetentionValidatorData(r.Context(), dryRun)
+
+	utils.GenerateSuccessResp[any](w, nil, 200)
+}
+
+// SchedulerForParquetExportValidatorData godoc
+// @Id schedulerForParquetExportValidatorData
+// @Summary      Scheduler For Parquet Export Validator Data
+// @Description  Export every finished day of delegation snapshots and daily aggregates that is not exported yet to parquet files
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Security     authorization
+// @Success      200  {object}  dto.SuccessResp200
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/scheduler/validator/export [post]
+func (h *schedulerHandlerImpl) SchedulerForParquetExportValidatorData(w http.ResponseWriter, r *http.Request) {
+	h.validatorScheduler.SchedulerForParquetExportValidatorData(r.Context())
+
+	utils.GenerateSuccessResp[any](w, nil, 200)
+}
+
+// SchedulerForDelegatorEventsValidatorData godoc
+// @Id schedulerForDelegatorEventsValidatorData
+// @Summary      Scheduler For Delegator Events Validator Data
+// @Description  Record the new, churned and returned delegators of every finished day that is not processed yet
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Security     authorization
+// @Success      200  {object}  dto.SuccessResp200
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      403  {object}  dto.FailedResp403
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/scheduler/validator/events [post]
+func (h *schedulerHandlerImpl) SchedulerForDelegatorEventsValidatorData(w http.ResponseWriter, r *http.Request) {
+	h.validatorScheduler.SchedulerForDelegatorEventsValidatorData(r.Context())
+
+	utils.GenerateSuccessResp[any](w, nil, 200)
+}
+
+func (h *schedulerHandlerImpl) SetupSchedulerRoutes(route chi.Router) {
 	setupSchedulerV1Routes(route, h)
 }
 
-func setupSchedulerV1Routes(route *chi.Mux, h *schedulerHandlerImpl) {
+func setupSchedulerV1Routes(route chi.Router, h *schedulerHandlerImpl) {
 	route.Post("/api/v1/scheduler/validator/hourly", h.SchedulerForHourlyCollectValidatorData)
 	route.Post("/api/v1/scheduler/validator/daily", h.SchedulerForDailyCollectValidatorData)
+	route.Post("/api/v1/scheduler/validator/retention", h.SchedulerForRetentionValidatorData)
+	route.Post("/api/v1/scheduler/validator/export", h.SchedulerForParquetExportValidatorData)
+	route.Post("/api/v1/scheduler/validator/events", h.SchedulerForDelegatorEventsValidatorData)
 }
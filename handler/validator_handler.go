@@ -8,10 +8,11 @@ import (
 	"github.com/gadhittana01/cosmos-validation-tracking/service"
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	"github.com/go-chi/chi"
+	"go.uber.org/zap"
 )
 
 type ValidatorHandler interface {
-	SetupValidatorRoutes(route *chi.Mux)
+	SetupValidatorRoutes(route chi.Router)
 }
 
 type ValidatorHandlerImpl struct {
@@ -32,7 +33,9 @@ func NewValidatorHandler(validatorService service.ValidatorSvc, logger utils.Log
 // @Description  Get Hourly Delegation Snapshot
 // @Tags         validator
 // @Accept 		 json
-// @Produce      json
+// @Produce      json,text/csv,application/x-ndjson
+// @Param        tz  query  string  false  "IANA timezone for timestamps and daily buckets, defaults to the reporting timezone"
+// @Param        format  query  string  false  "Stream the full result set as csv or ndjson instead of a JSON page, also set by the Accept header"  Enums(json, csv, ndjson)
 // @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetHourlySnapshotResponse]}
 // @Failure      400  {object}  dto.FailedResp400
 // @Failure      401  {object}  dto.FailedResp401
@@ -41,14 +44,31 @@ func NewValidatorHandler(validatorService service.ValidatorSvc, logger utils.Log
 // @Router       /api/v1/validators/{validatorAddress}/delegations/hourly [get]
 func (h *ValidatorHandlerImpl) GetHourlyDelegationSnapshot(w http.ResponseWriter, r *http.Request) {
 	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+
+	if format := utils.ValidateExportFormat(r, "format"); format != "" {
+		writer := utils.NewExportWriter[dto.GetHourlySnapshotResponse](w, format)
+		err := h.validatorService.ExportHourlySnapshot(r.Context(), dto.ExportHourlySnapshotRequest{
+			ValidatorAddress: validatorAddress,
+			Timezone:         timezone,
+		}, writer.Write)
+		h.finishExport(w, r, writer, err)
+		return
+	}
+
 	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
 	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
 
-	resp := h.validatorService.GetHourlySnapshot(r.Context(), dto.GetHourlySnapshotRequest{
+	resp, err := h.validatorService.GetHourlySnapshot(r.Context(), dto.GetHourlySnapshotRequest{
 		ValidatorAddress: validatorAddress,
 		Page:             int32(page),
 		Limit:            int32(limit),
+		Timezone:         timezone,
 	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
 
 	utils.GenerateSuccessResp(w, resp, http.StatusOK)
 }
@@ -59,7 +79,9 @@ func (h *ValidatorHandlerImpl) GetHourlyDelegationSnapshot(w http.ResponseWriter
 // @Description  Get Daily Delegation Snapshot
 // @Tags         validator
 // @Accept 		 json
-// @Produce      json
+// @Produce      json,text/csv,application/x-ndjson
+// @Param        tz  query  string  false  "IANA timezone for timestamps and daily buckets, defaults to the reporting timezone"
+// @Param        format  query  string  false  "Stream the full result set as csv or ndjson instead of a JSON page, also set by the Accept header"  Enums(json, csv, ndjson)
 // @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetDailySnapshotResponse]}
 // @Failure      400  {object}  dto.FailedResp400
 // @Failure      401  {object}  dto.FailedResp401
@@ -68,14 +90,31 @@ func (h *ValidatorHandlerImpl) GetHourlyDelegationSnapshot(w http.ResponseWriter
 // @Router       /api/v1/validators/{validatorAddress}/delegations/daily [get]
 func (h *ValidatorHandlerImpl) GetDailyDelegationSnapshot(w http.ResponseWriter, r *http.Request) {
 	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+
+	if format := utils.ValidateExportFormat(r, "format"); format != "" {
+		writer := utils.NewExportWriter[dto.GetDailySnapshotResponse](w, format)
+		err := h.validatorService.ExportDailySnapshot(r.Context(), dto.ExportDailySnapshotRequest{
+			ValidatorAddress: validatorAddress,
+			Timezone:         timezone,
+		}, writer.Write)
+		h.finishExport(w, r, writer, err)
+		return
+	}
+
 	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
 	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
 
-	resp := h.validatorService.GetDailySnapshot(r.Context(), dto.GetDailySnapshotRequest{
+	resp, err := h.validatorService.GetDailySnapshot(r.Context(), dto.GetDailySnapshotRequest{
 		ValidatorAddress: validatorAddress,
 		Page:             int32(page),
 		Limit:            int32(limit),
+		Timezone:         timezone,
 	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
 
 	utils.GenerateSuccessResp(w, resp, http.StatusOK)
 }
@@ -86,7 +125,9 @@ func (h *ValidatorHandlerImpl) GetDailyDelegationSnapshot(w http.ResponseWriter,
 // @Description  Get Delegator History
 // @Tags         validator
 // @Accept 		 json
-// @Produce      json
+// @Produce      json,text/csv,application/x-ndjson
+// @Param        tz  query  string  false  "IANA timezone for timestamps and daily buckets, defaults to the reporting timezone"
+// @Param        format  query  string  false  "Stream the full result set as csv or ndjson instead of a JSON page, also set by the Accept header"  Enums(json, csv, ndjson)
 // @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetDelegatorHistoryResponse]}
 // @Failure      400  {object}  dto.FailedResp400
 // @Failure      401  {object}  dto.FailedResp401
@@ -97,26 +138,346 @@ func (h *ValidatorHandlerImpl) GetDelegatorHistory(w http.ResponseWriter, r *htt
 	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
 	delegatorAddress := utils.ValidateURLParamString(r, "delegatorAddress")
 	sortBy := utils.ValidateURLParamString(r, "sortBy", "date")
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+
+	if format := utils.ValidateExportFormat(r, "format"); format != "" {
+		writer := utils.NewExportWriter[dto.GetDelegatorHistoryResponse](w, format)
+		err := h.validatorService.ExportDelegatorHistory(r.Context(), dto.ExportDelegatorHistoryRequest{
+			ValidatorAddress: validatorAddress,
+			DelegatorAddress: delegatorAddress,
+			SortBy:           sortBy,
+			Timezone:         timezone,
+		}, writer.Write)
+		h.finishExport(w, r, writer, err)
+		return
+	}
+
 	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
 	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
 
-	resp := h.validatorService.GetDelegatorHistory(r.Context(), dto.GetDelegatorHistoryRequest{
+	resp, err := h.validatorService.GetDelegatorHistory(r.Context(), dto.GetDelegatorHistoryRequest{
 		ValidatorAddress: validatorAddress,
 		DelegatorAddress: delegatorAddress,
 		SortBy:           sortBy,
 		Page:             int32(page),
 		Limit:            int32(limit),
+		Timezone:         timezone,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetDelegatorCohort godoc
+// @Id getDelegatorCohort
+// @Summary      Get Delegator Cohort
+// @Description  Group the delegators of a validator by the week or month of their first delegation and report per cohort how many are still delegated and how much of their initial stake remains after every period
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        validatorAddress  path  string  true  "Validator address"
+// @Param        period  query  string  false  "Cohort period"  Enums(week, month)
+// @Param        periods  query  int  false  "Number of periods to follow every cohort for"
+// @Param        tz  query  string  false  "IANA timezone for the period boundaries, defaults to the reporting timezone"
+// @Success      200  {object}  dto.SuccessResp200{data=[]dto.GetDelegatorCohortResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/{validatorAddress}/analytics/cohorts [get]
+func (h *ValidatorHandlerImpl) GetDelegatorCohort(w http.ResponseWriter, r *http.Request) {
+	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	period := utils.ValidateQueryParamString(r, "period", constant.CohortPeriodMonth)
+	periods := utils.ValidateQueryParamInt(r, "periods", constant.DefaultCohortPeriods)
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+
+	resp, err := h.validatorService.GetDelegatorCohort(r.Context(), dto.GetDelegatorCohortRequest{
+		ValidatorAddress: validatorAddress,
+		Period:           period,
+		Periods:          int32(periods),
+		Timezone:         timezone,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetConcentrationMetric godoc
+// @Id getConcentrationMetric
+// @Summary      Get Concentration Metric
+// @Description  Get the daily stake concentration of a validator: Gini coefficient, Herfindahl index, top 10 / top 100 share and the minimum number of delegators holding 33% / 50% of the stake
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        validatorAddress  path  string  true  "Validator address"
+// @Param        from  query  string  false  "First day to include, YYYY-MM-DD"
+// @Param        to  query  string  false  "Last day to include, YYYY-MM-DD"
+// @Param        page  query  int  false  "Page"
+// @Param        limit  query  int  false  "Limit"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetConcentrationMetricResponse]}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/{validatorAddress}/analytics/concentration [get]
+func (h *ValidatorHandlerImpl) GetConcentrationMetric(w http.ResponseWriter, r *http.Request) {
+	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	from := utils.ValidateQueryParamDate(r, "from")
+	to := utils.ValidateQueryParamDate(r, "to")
+	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
+	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
+
+	resp, err := h.validatorService.GetConcentrationMetric(r.Context(), dto.GetConcentrationMetricRequest{
+		ValidatorAddress: validatorAddress,
+		From:             from,
+		To:               to,
+		Page:             int32(page),
+		Limit:            int32(limit),
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetDelegatorEvent godoc
+// @Id getDelegatorEvent
+// @Summary      Get Delegator Event
+// @Description  Get the delegators of a validator that appeared for the first time, left entirely or came back, per day
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        validatorAddress  path  string  true  "Validator address"
+// @Param        type  query  string  false  "Event type: new, churned or returned"
+// @Param        from  query  string  false  "First day to include, YYYY-MM-DD"
+// @Param        to  query  string  false  "Last day to include, YYYY-MM-DD"
+// @Param        page  query  int  false  "Page"
+// @Param        limit  query  int  false  "Limit"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetDelegatorEventResponse]}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/{validatorAddress}/delegators/events [get]
+func (h *ValidatorHandlerImpl) GetDelegatorEvent(w http.ResponseWriter, r *http.Request) {
+	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	eventType := utils.ValidateQueryParamString(r, "type")
+	from := utils.ValidateQueryParamDate(r, "from")
+	to := utils.ValidateQueryParamDate(r, "to")
+	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
+	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
+
+	resp, err := h.validatorService.GetDelegatorEvent(r.Context(), dto.GetDelegatorEventRequest{
+		ValidatorAddress: validatorAddress,
+		Type:             eventType,
+		From:             from,
+		To:               to,
+		Page:             int32(page),
+		Limit:            int32(limit),
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetDailyDelegatorEvent godoc
+// @Id getDailyDelegatorEvent
+// @Summary      Get Daily Delegator Event
+// @Description  Get the number of new, churned and returned delegators of a validator and their stake, per day
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        validatorAddress  path  string  true  "Validator address"
+// @Param        type  query  string  false  "Event type: new, churned or returned"
+// @Param        from  query  string  false  "First day to include, YYYY-MM-DD"
+// @Param        to  query  string  false  "Last day to include, YYYY-MM-DD"
+// @Success      200  {object}  dto.SuccessResp200{data=[]dto.GetDailyDelegatorEventResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/{validatorAddress}/delegators/events/daily [get]
+func (h *ValidatorHandlerImpl) GetDailyDelegatorEvent(w http.ResponseWriter, r *http.Request) {
+	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	eventType := utils.ValidateQueryParamString(r, "type")
+	from := utils.ValidateQueryParamDate(r, "from")
+	to := utils.ValidateQueryParamDate(r, "to")
+
+	resp, err := h.validatorService.GetDailyDelegatorEvent(r.Context(), dto.GetDailyDelegatorEventRequest{
+		ValidatorAddress: validatorAddress,
+		Type:             eventType,
+		From:             from,
+		To:               to,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// CompareValidator godoc
+// @Id compareValidator
+// @Summary      Compare Validator
+// @Description  Get the daily total stake, delegator count, net flow and commission of several validators, aligned on the same days
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        addresses  query  string  true  "Comma separated validator addresses, at most 10"
+// @Param        from  query  string  false  "First day to include, YYYY-MM-DD, default 29 days before to"
+// @Param        to  query  string  false  "Last day to include, YYYY-MM-DD, default today"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.CompareValidatorResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/compare [get]
+func (h *ValidatorHandlerImpl) CompareValidator(w http.ResponseWriter, r *http.Request) {
+	addresses := utils.ValidateQueryParamStringList(r, "addresses")
+	from := utils.ValidateQueryParamDate(r, "from")
+	to := utils.ValidateQueryParamDate(r, "to")
+
+	resp, err := h.validatorService.CompareValidator(r.Context(), dto.CompareValidatorRequest{
+		Addresses: addresses,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetDelegatorDistribution godoc
+// @Id getDelegatorDistribution
+// @Summary      Get Delegator Distribution
+// @Description  Get how many delegators and how much stake fall in each balance bucket of a validator, now or on a past day, with the change versus a comparison day
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        validatorAddress  path  string  true  "Validator address"
+// @Param        buckets  query  string  false  "Comma separated ascending bucket boundaries in ATOM, default 1,10,100,1000,10000,100000"
+// @Param        date  query  string  false  "Day to read the distribution of from the daily aggregates, YYYY-MM-DD, default the current delegations"
+// @Param        compareDate  query  string  false  "Day to compare the distribution with, YYYY-MM-DD"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.GetDelegatorDistributionResponse}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/{validatorAddress}/distribution [get]
+func (h *ValidatorHandlerImpl) GetDelegatorDistribution(w http.ResponseWriter, r *http.Request) {
+	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	buckets := utils.ValidateQueryParamString(r, "buckets", constant.DefaultDistributionBuckets)
+	date := utils.ValidateQueryParamDate(r, "date")
+	compareDate := utils.ValidateQueryParamDate(r, "compareDate")
+
+	resp, err := h.validatorService.GetDelegatorDistribution(r.Context(), dto.GetDelegatorDistributionRequest{
+		ValidatorAddress: validatorAddress,
+		Buckets:          buckets,
+		Date:             date,
+		CompareDate:      compareDate,
+	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+
+	utils.GenerateSuccessResp(w, resp, http.StatusOK)
+}
+
+// GetDelegationAsOf godoc
+// @Id getDelegationAsOf
+// @Summary      Get Delegation As Of
+// @Description  Get the balance of every delegator of a validator as of an instant, from their latest snapshot at or before it. With compareTo, get the change of every delegator between the two instants instead
+// @Tags         validator
+// @Accept 		 json
+// @Produce      json
+// @Param        validatorAddress  path  string  true  "Validator address"
+// @Param        at  query  string  false  "Instant to read the balances at, RFC3339, default now"
+// @Param        compareTo  query  string  false  "Instant to compare the balances with, RFC3339"
+// @Param        sortBy  query  string  false  "Sort by amount, -amount, or with compareTo also change, -change, default -amount"
+// @Param        tz  query  string  false  "Timezone of the returned timestamps, default the reporting timezone"
+// @Param        page  query  int  false  "Page"
+// @Param        limit  query  int  false  "Limit"
+// @Success      200  {object}  dto.SuccessResp200{data=dto.PaginationResp[dto.GetDelegationAsOfResponse]}
+// @Failure      400  {object}  dto.FailedResp400
+// @Failure      401  {object}  dto.FailedResp401
+// @Failure      404  {object}  dto.FailedResp404
+// @Failure      500  {object}  dto.FailedResp500
+// @Router       /api/v1/validators/{validatorAddress}/delegations [get]
+func (h *ValidatorHandlerImpl) GetDelegationAsOf(w http.ResponseWriter, r *http.Request) {
+	validatorAddress := utils.ValidateURLParamString(r, "validatorAddress")
+	at := utils.ValidateQueryParamTimestamp(r, "at")
+	compareTo := utils.ValidateQueryParamTimestamp(r, "compareTo")
+	sortBy := utils.ValidateQueryParamString(r, "sortBy", constant.DelegationSortByAmountDesc)
+	timezone := utils.ValidateQueryParamTimezone(r, "tz")
+	page := utils.ValidateQueryParamInt(r, "page", constant.DefaultPage)
+	limit := utils.ValidateQueryParamInt(r, "limit", constant.DefaultLimit)
+
+	resp, err := h.validatorService.GetDelegationAsOf(r.Context(), dto.GetDelegationAsOfRequest{
+		ValidatorAddress: validatorAddress,
+		At:               at,
+		CompareTo:        compareTo,
+		SortBy:           sortBy,
+		Timezone:         timezone,
+		Page:             int32(page),
+		Limit:            int32(limit),
 	})
+	if err != nil {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
 
 	utils.GenerateSuccessResp(w, resp, http.StatusOK)
 }
 
-func (h *ValidatorHandlerImpl) SetupValidatorRoutes(route *chi.Mux) {
+// The status line is already sent once rows are streamed, so an error can only be responded
+// with when it happened before the first row, after that it is only logged.
+func (h *ValidatorHandlerImpl) finishExport(w http.ResponseWriter, r *http.Request, writer interface {
+	Flush() error
+	Started() bool
+}, err error) {
+	if err != nil && !writer.Started() {
+		utils.GenerateProblemResp(w, r, h.logger, err)
+		return
+	}
+	if err != nil {
+		h.logger.WithContext(r.Context()).Error("Error streaming export", zap.Error(err))
+		return
+	}
+
+	if err := writer.Flush(); err != nil {
+		h.logger.WithContext(r.Context()).Error("Error flushing export", zap.Error(err))
+	}
+}
+
+func (h *ValidatorHandlerImpl) SetupValidatorRoutes(route chi.Router) {
 	setupValidatorV1Routes(route, h)
 }
 
-func setupValidatorV1Routes(route *chi.Mux, h *ValidatorHandlerImpl) {
+func setupValidatorV1Routes(route chi.Router, h *ValidatorHandlerImpl) {
+	route.Get("/api/v1/validators/compare", h.CompareValidator)
+	route.Get("/api/v1/validators/{validatorAddress}/delegations", h.GetDelegationAsOf)
 	route.Get("/api/v1/validators/{validatorAddress}/delegations/hourly", h.GetHourlyDelegationSnapshot)
 	route.Get("/api/v1/validators/{validatorAddress}/delegations/daily", h.GetDailyDelegationSnapshot)
 	route.Get("/api/v1/validators/{validatorAddress}/delegator/{delegatorAddress}/history", h.GetDelegatorHistory)
+	route.Get("/api/v1/validators/{validatorAddress}/analytics/cohorts", h.GetDelegatorCohort)
+	route.Get("/api/v1/validators/{validatorAddress}/analytics/concentration", h.GetConcentrationMetric)
+	route.Get("/api/v1/validators/{validatorAddress}/distribution", h.GetDelegatorDistribution)
+	route.Get("/api/v1/validators/{validatorAddress}/delegators/events", h.GetDelegatorEvent)
+	route.Get("/api/v1/validators/{validatorAddress}/delegators/events/daily", h.GetDailyDelegatorEvent)
 }
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 	"github.com/gadhittana01/cosmos-validation-tracking/dto"
 	"github.com/gadhittana01/cosmos-validation-tracking/service"
 	mocksvc "github.com/gadhittana01/cosmos-validation-tracking/service/mock"
@@ -64,6 +67,12 @@ func TestGetHourlyDelegationSnapshot(t *testing.T) {
 	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/hourly?page=%d&limit=test", validatorAddress, page), strings.NewReader(``))
 	invalidSampleResp := httptest.NewRecorder()
 
+	invalidTzSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/hourly?page=%d&limit=%d&tz=Mars/Olympus", validatorAddress, page, limit), strings.NewReader(``))
+	invalidTzSampleResp := httptest.NewRecorder()
+
+	negativeLimitSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/hourly?page=%d&limit=-1", validatorAddress, page), strings.NewReader(``))
+	negativeLimitSampleResp := httptest.NewRecorder()
+
 	type fields struct {
 		service service.ValidatorSvc
 	}
@@ -99,7 +108,7 @@ func TestGetHourlyDelegationSnapshot(t *testing.T) {
 							Timestamp: "2021-01-01",
 						},
 					},
-				}).Times(1)
+				}, nil).Times(1)
 
 				return fields{
 					service: validatorMock,
@@ -123,7 +132,7 @@ func TestGetHourlyDelegationSnapshot(t *testing.T) {
 					Total:      0,
 					IsLoadMore: false,
 					Data:       []dto.GetHourlySnapshotResponse{},
-				}).Times(0)
+				}, nil).Times(0)
 
 				return fields{
 					service: validatorMock,
@@ -135,6 +144,40 @@ func TestGetHourlyDelegationSnapshot(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid timezone",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetHourlySnapshot(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidTzSampleResp,
+				req: invalidTzSampleReq,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative limit",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetHourlySnapshot(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   negativeLimitSampleResp,
+				req: negativeLimitSampleReq,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -201,7 +244,7 @@ func TestGetDailyDelegationSnapshot(t *testing.T) {
 							Total:   100,
 						},
 					},
-				}).Times(1)
+				}, nil).Times(1)
 
 				return fields{
 					service: validatorMock,
@@ -225,7 +268,7 @@ func TestGetDailyDelegationSnapshot(t *testing.T) {
 					Total:      0,
 					IsLoadMore: false,
 					Data:       []dto.GetDailySnapshotResponse{},
-				}).Times(0)
+				}, nil).Times(0)
 
 				return fields{
 					service: validatorMock,
@@ -305,7 +348,7 @@ func TestGetDelegatorHistory(t *testing.T) {
 							Change:    100,
 						},
 					},
-				}).Times(1)
+				}, nil).Times(1)
 
 				return fields{
 					service: validatorMock,
@@ -329,7 +372,7 @@ func TestGetDelegatorHistory(t *testing.T) {
 					Total:      0,
 					IsLoadMore: false,
 					Data:       []dto.GetDelegatorHistoryResponse{},
-				}).Times(0)
+				}, nil).Times(0)
 
 				return fields{
 					service: validatorMock,
@@ -361,3 +404,713 @@ func TestGetDelegatorHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestExportDelegationSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	delegatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	t.Run("export hourly snapshot as csv", func(t *testing.T) {
+		validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+		validatorMock.EXPECT().ExportHourlySnapshot(gomock.Any(), dto.ExportHourlySnapshotRequest{}, gomock.Any()).
+			Do(func(ctx context.Context, req dto.ExportHourlySnapshotRequest, fn func(dto.GetHourlySnapshotResponse) error) {
+				_ = fn(dto.GetHourlySnapshotResponse{
+					Address:   delegatorAddress,
+					Amount:    100,
+					Change:    10,
+					Date:      "2021-01-01",
+					Timestamp: "2021-01-01T10:00:00Z",
+				})
+			}).Times(1)
+		i := ValidatorHandlerImpl{validatorService: validatorMock}
+
+		req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/hourly?format=csv", validatorAddress), strings.NewReader(``))
+		resp := httptest.NewRecorder()
+		i.GetHourlyDelegationSnapshot(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "text/csv", resp.Header().Get("Content-Type"))
+		assert.Equal(t, "address,amount,change,date,timestamp\n"+delegatorAddress+",100,10,2021-01-01,2021-01-01T10:00:00Z\n", resp.Body.String())
+	})
+
+	t.Run("export daily snapshot as ndjson from accept header", func(t *testing.T) {
+		validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+		validatorMock.EXPECT().ExportDailySnapshot(gomock.Any(), dto.ExportDailySnapshotRequest{Timezone: "Europe/Berlin"}, gomock.Any()).
+			Do(func(ctx context.Context, req dto.ExportDailySnapshotRequest, fn func(dto.GetDailySnapshotResponse) error) {
+				_ = fn(dto.GetDailySnapshotResponse{Address: delegatorAddress, Date: "2021-01-01", Total: 100})
+				_ = fn(dto.GetDailySnapshotResponse{Address: delegatorAddress, Date: "2021-01-02", Total: 200})
+			}).Times(1)
+		i := ValidatorHandlerImpl{validatorService: validatorMock}
+
+		req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/daily?tz=Europe/Berlin", validatorAddress), strings.NewReader(``))
+		req.Header.Set("Accept", "application/x-ndjson")
+		resp := httptest.NewRecorder()
+		i.GetDailyDelegationSnapshot(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/x-ndjson", resp.Header().Get("Content-Type"))
+		assert.Equal(t, `{"address":"`+delegatorAddress+`","date":"2021-01-01","total":100}`+"\n"+
+			`{"address":"`+delegatorAddress+`","date":"2021-01-02","total":200}`+"\n", resp.Body.String())
+	})
+
+	t.Run("export empty delegator history as csv", func(t *testing.T) {
+		validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+		validatorMock.EXPECT().ExportDelegatorHistory(gomock.Any(), dto.ExportDelegatorHistoryRequest{SortBy: "date"}, gomock.Any()).Times(1)
+		i := ValidatorHandlerImpl{validatorService: validatorMock}
+
+		req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegator/%s/history", validatorAddress, delegatorAddress), strings.NewReader(``))
+		req.Header.Set("Accept", "text/csv")
+		resp := httptest.NewRecorder()
+		i.GetDelegatorHistory(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "timestamp,amount,change\n", resp.Body.String())
+	})
+
+	t.Run("failed export before the first row", func(t *testing.T) {
+		loggerMock := mockutl.NewMockLoggerSvc(ctrl)
+		mockutl.LoggerMock(loggerMock)
+		validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+		validatorMock.EXPECT().ExportHourlySnapshot(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(utils.WrapAppError(errors.New("connection refused"), constant.ErrorCodeQueryFailed, "failed to export hourly snapshot", http.StatusUnprocessableEntity)).Times(1)
+		i := ValidatorHandlerImpl{validatorService: validatorMock, logger: loggerMock}
+
+		req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/hourly?format=csv", validatorAddress), strings.NewReader(``))
+		resp := httptest.NewRecorder()
+		i.GetHourlyDelegationSnapshot(resp, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, constant.ProblemContentType, resp.Header().Get("Content-Type"))
+		assert.NotContains(t, resp.Body.String(), "connection refused")
+	})
+
+	t.Run("invalid export format", func(t *testing.T) {
+		validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+		validatorMock.EXPECT().ExportHourlySnapshot(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		i := ValidatorHandlerImpl{validatorService: validatorMock}
+
+		req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations/hourly?format=xml", validatorAddress), strings.NewReader(``))
+		assert.Panics(t, func() {
+			i.GetHourlyDelegationSnapshot(httptest.NewRecorder(), req)
+		})
+	})
+}
+
+func TestGetDelegatorCohort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/analytics/cohorts?period=week&periods=4", validatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/analytics/cohorts?periods=test", validatorAddress), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get delegator cohort",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegatorCohort(gomock.Any(), dto.GetDelegatorCohortRequest{
+					Period:  "week",
+					Periods: 4,
+				}).Return([]dto.GetDelegatorCohortResponse{
+					{
+						CohortStart:   "2025-01-06",
+						Delegators:    1,
+						InitialAmount: 100,
+						Periods: []dto.GetDelegatorCohortPeriodResponse{
+							{Period: 0, RetainedDelegators: 1, RetainedRate: 1, RemainingAmount: 100, RemainingStakeRate: 1},
+						},
+					},
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegatorCohort(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDelegatorCohort(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDelegatorCohort(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetConcentrationMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/analytics/concentration?from=2025-04-01&to=2025-04-30&page=1&limit=10", validatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/analytics/concentration?from=2025-13-01", validatorAddress), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get concentration metric",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetConcentrationMetric(gomock.Any(), dto.GetConcentrationMetricRequest{
+					From:  "2025-04-01",
+					To:    "2025-04-30",
+					Page:  1,
+					Limit: 10,
+				}).Return(dto.PaginationResp[dto.GetConcentrationMetricResponse]{
+					Data: []dto.GetConcentrationMetricResponse{
+						{Date: "2025-04-01", Delegators: 3, Total: 1000, GiniCoefficient: 0.4, HerfindahlIndex: 0.38, Top10Share: 1, Top100Share: 1, Nakamoto33: 1, Nakamoto50: 2},
+					},
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetConcentrationMetric(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetConcentrationMetric(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetConcentrationMetric(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetDelegatorDistribution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/distribution?date=2025-04-08&compareDate=2025-04-01", validatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/distribution?compareDate=test", validatorAddress), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get delegator distribution",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegatorDistribution(gomock.Any(), dto.GetDelegatorDistributionRequest{
+					Buckets:     constant.DefaultDistributionBuckets,
+					Date:        "2025-04-08",
+					CompareDate: "2025-04-01",
+				}).Return(dto.GetDelegatorDistributionResponse{
+					Date:        "2025-04-08",
+					CompareDate: "2025-04-01",
+					Delegators:  1,
+					Total:       500000,
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegatorDistribution(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDelegatorDistribution(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDelegatorDistribution(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetDelegatorEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegators/events?type=new&from=2025-04-01&page=1&limit=10", validatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegators/events?page=test", validatorAddress), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get delegator event",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegatorEvent(gomock.Any(), dto.GetDelegatorEventRequest{
+					Type:  constant.DelegatorEventTypeNew,
+					From:  "2025-04-01",
+					Page:  1,
+					Limit: 10,
+				}).Return(dto.PaginationResp[dto.GetDelegatorEventResponse]{
+					Data: []dto.GetDelegatorEventResponse{
+						{Date: "2025-04-01", DelegatorAddress: "cosmos1...", Type: constant.DelegatorEventTypeNew, Amount: 8000},
+					},
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegatorEvent(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDelegatorEvent(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDelegatorEvent(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetDailyDelegatorEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegators/events/daily?type=churned&to=2025-04-30", validatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegators/events/daily?to=30-04-2025", validatorAddress), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get daily delegator event",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDailyDelegatorEvent(gomock.Any(), dto.GetDailyDelegatorEventRequest{
+					Type: constant.DelegatorEventTypeChurned,
+					To:   "2025-04-30",
+				}).Return([]dto.GetDailyDelegatorEventResponse{
+					{Date: "2025-04-01", Type: constant.DelegatorEventTypeChurned, Delegators: 2, Amount: 12000},
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDailyDelegatorEvent(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDailyDelegatorEvent(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDailyDelegatorEvent(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestCompareValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sampleReq := httptest.NewRequest("GET", "http://localhost:8000/api/v1/validators/compare?addresses=cosmosvaloper1a...,%20cosmosvaloper1b...,cosmosvaloper1a...&from=2025-04-01&to=2025-04-30", strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", "http://localhost:8000/api/v1/validators/compare?addresses=cosmosvaloper1a...&to=2025-04-31", strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success compare validator",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().CompareValidator(gomock.Any(), dto.CompareValidatorRequest{
+					Addresses: []string{"cosmosvaloper1a...", "cosmosvaloper1b..."},
+					From:      "2025-04-01",
+					To:        "2025-04-30",
+				}).Return(dto.CompareValidatorResponse{
+					From: "2025-04-01",
+					To:   "2025-04-30",
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().CompareValidator(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.CompareValidator(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.CompareValidator(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
+
+func TestGetDelegationAsOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	sampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations?at=2025-04-02T00:00:00Z&compareTo=2025-04-01T00:00:00Z&sortBy=-change&page=1&limit=10", validatorAddress), strings.NewReader(``))
+	sampleResp := httptest.NewRecorder()
+
+	invalidSampleReq := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:8000/api/v1/validators/%s/delegations?at=2025-04-02", validatorAddress), strings.NewReader(``))
+	invalidSampleResp := httptest.NewRecorder()
+
+	type fields struct {
+		service service.ValidatorSvc
+	}
+
+	type args struct {
+		w   http.ResponseWriter
+		req *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		fields  func() fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success get delegation as of",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegationAsOf(gomock.Any(), dto.GetDelegationAsOfRequest{
+					At:        "2025-04-02T00:00:00Z",
+					CompareTo: "2025-04-01T00:00:00Z",
+					SortBy:    constant.DelegationSortByChangeDesc,
+					Page:      1,
+					Limit:     10,
+				}).Return(dto.PaginationResp[dto.GetDelegationAsOfResponse]{
+					Data: []dto.GetDelegationAsOfResponse{
+						{DelegatorAddress: "cosmos1...", Amount: 8000},
+					},
+				}, nil).Times(1)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   sampleResp,
+				req: sampleReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid request",
+			fields: func() fields {
+				validatorMock := mocksvc.NewMockValidatorSvc(ctrl)
+
+				validatorMock.EXPECT().GetDelegationAsOf(gomock.Any(), gomock.Any()).Times(0)
+
+				return fields{
+					service: validatorMock,
+				}
+			},
+			args: args{
+				w:   invalidSampleResp,
+				req: invalidSampleReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := ValidatorHandlerImpl{
+				validatorService: field.service,
+			}
+
+			if tt.wantErr {
+				assert.Panics(t, func() {
+					i.GetDelegationAsOf(tt.args.w, tt.args.req)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					i.GetDelegationAsOf(tt.args.w, tt.args.req)
+				})
+			}
+		})
+	}
+}
@@ -21,6 +21,16 @@ var validatorHandlerSet = wire.NewSet(
 	service.NewValidatorSvc,
 )
 
+var delegatorHandlerSet = wire.NewSet(
+	handler.NewDelegatorHandler,
+	service.NewDelegatorSvc,
+)
+
+var healthHandlerSet = wire.NewSet(
+	handler.NewHealthHandler,
+	service.NewHealthSvc,
+)
+
 var loggerSet = wire.NewSet(
 	utils.NewLogger,
 )
@@ -29,6 +39,30 @@ var recoveryMiddlewareSet = wire.NewSet(
 	utils.NewRecoveryMiddlewareSvc,
 )
 
+var authMiddlewareSet = wire.NewSet(
+	wire.Bind(new(utils.APIKeyVerifier), new(service.APIKeySvc)),
+	service.NewAPIKeySvc,
+	handler.NewAPIKeyHandler,
+	utils.NewAuthMiddlewareSvc,
+)
+
+var rateLimitMiddlewareSet = wire.NewSet(
+	utils.NewRateLimitMiddlewareSvc,
+)
+
+var metricsMiddlewareSet = wire.NewSet(
+	utils.NewMetricsMiddlewareSvc,
+)
+
+var requestIDMiddlewareSet = wire.NewSet(
+	utils.NewRequestIDMiddlewareSvc,
+)
+
+var tracingSet = wire.NewSet(
+	utils.NewTracerSvc,
+	utils.NewTracingMiddlewareSvc,
+)
+
 var httpClientSet = wire.NewSet(
 	utils.NewDefaultHTTPClient,
 )
@@ -38,6 +72,18 @@ var validatorSchedulerSet = wire.NewSet(
 	handler.NewSchedulerHandler,
 )
 
+var lockSet = wire.NewSet(
+	utils.NewLockSvc,
+)
+
+var jobManagerSet = wire.NewSet(
+	utils.NewJobManager,
+)
+
+var objectStorageSet = wire.NewSet(
+	utils.NewObjectStorage,
+)
+
 var cacheSet = wire.NewSet(
 	wire.Bind(new(utils.RedisClient), new(*redis.Client)),
 	utils.NewRedisClient,
@@ -51,12 +97,22 @@ func InitializeApp(
 ) (app.App, error) {
 	wire.Build(
 		validatorHandlerSet,
+		delegatorHandlerSet,
+		healthHandlerSet,
 		app.NewApp,
 		loggerSet,
 		recoveryMiddlewareSet,
+		authMiddlewareSet,
+		rateLimitMiddlewareSet,
+		metricsMiddlewareSet,
+		tracingSet,
+		requestIDMiddlewareSet,
 		httpClientSet,
 		validatorSchedulerSet,
 		cacheSet,
+		objectStorageSet,
+		lockSet,
+		jobManagerSet,
 	)
 
 	return nil, nil
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"context"
+	"time"
+
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	"github.com/go-chi/chi"
 )
@@ -34,6 +38,15 @@ func main() {
 		panic(err)
 	}
 
+	// Create the current month and the upcoming ones so the hourly collector never writes outside a partition.
+	_, err := querier.New(DBpool).CreateDelegationSnapshotPartitions(context.Background(), querier.CreateDelegationSnapshotPartitionsParams{
+		FromMonth:  time.Now().UTC(),
+		MonthCount: int32(config.SnapshotPartitionMonths + 1),
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	app, err := InitializeApp(r, DBpool, config)
 	if err != nil {
 		panic(err)
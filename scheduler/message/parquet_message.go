@@ -0,0 +1,18 @@
+package message
+
+import "time"
+
+type DelegationSnapshotRecord struct {
+	ValidatorAddress string    `parquet:"validator_address"`
+	DelegatorAddress string    `parquet:"delegator_address"`
+	AmountUatom      int64     `parquet:"amount_uatom"`
+	ChangeUatom      int64     `parquet:"change_uatom"`
+	Timestamp        time.Time `parquet:"timestamp,timestamp(millisecond)"`
+}
+
+type DailyAggregateRecord struct {
+	ValidatorAddress string `parquet:"validator_address"`
+	DelegatorAddress string `parquet:"delegator_address"`
+	Date             int32  `parquet:"date,date"` // days since the unix epoch
+	TotalAmount      int64  `parquet:"total_amount"`
+}
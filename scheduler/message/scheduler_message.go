@@ -2,6 +2,7 @@ package message
 
 type CosmosAPIResponse struct {
 	DelegationResponses []DelegationResponse `json:"delegation_responses"`
+	Pagination          Pagination           `json:"pagination"`
 }
 
 type DelegationResponse struct {
@@ -19,3 +20,27 @@ type Balance struct {
 	Denom  string `json:"denom"`
 	Amount string `json:"amount"`
 }
+
+type Pagination struct {
+	NextKey string `json:"next_key"`
+	Total   string `json:"total"`
+}
+
+type CosmosValidatorResponse struct {
+	Validator Validator `json:"validator"`
+}
+
+type Validator struct {
+	OperatorAddress string     `json:"operator_address"`
+	Commission      Commission `json:"commission"`
+}
+
+type Commission struct {
+	CommissionRates CommissionRates `json:"commission_rates"`
+}
+
+type CommissionRates struct {
+	Rate          string `json:"rate"`
+	MaxRate       string `json:"max_rate"`
+	MaxChangeRate string `json:"max_change_rate"`
+}
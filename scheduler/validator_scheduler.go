@@ -1,8 +1,13 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -11,12 +16,23 @@ import (
 	"github.com/gadhittana01/cosmos-validation-tracking/scheduler/message"
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	"github.com/jackc/pgx/v5"
+	"github.com/parquet-go/parquet-go"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
 type ValidatorScheduler interface {
 	SchedulerForHourlyCollectValidatorData(ctx context.Context)
 	SchedulerForDailyCollectValidatorData(ctx context.Context)
+	SchedulerForRetentionValidatorData(ctx context.Context, dryRun bool)
+	SchedulerForParquetExportValidatorData(ctx context.Context)
+	SchedulerForDelegatorEventsValidatorData(ctx context.Context)
+}
+
+type delegationBalance struct {
+	ValidatorAddress string
+	DelegatorAddress string
+	AmountUatom      int64
 }
 
 type ValidatorSchedulerImpl struct {
@@ -25,6 +41,9 @@ type ValidatorSchedulerImpl struct {
 	logger     utils.LoggerSvc
 	httpClient utils.HTTPClient
 	cache      utils.CacheSvc
+	storage    utils.ObjectStorage
+	lock       utils.LockSvc
+	jobs       utils.JobManager
 }
 
 func NewValidatorScheduler(
@@ -33,6 +52,9 @@ func NewValidatorScheduler(
 	logger utils.LoggerSvc,
 	httpClient utils.HTTPClient,
 	cache utils.CacheSvc,
+	storage utils.ObjectStorage,
+	lock utils.LockSvc,
+	jobs utils.JobManager,
 ) ValidatorScheduler {
 	return &ValidatorSchedulerImpl{
 		repo:       repo,
@@ -40,110 +62,905 @@ func NewValidatorScheduler(
 		logger:     logger,
 		httpClient: httpClient,
 		cache:      cache,
+		storage:    storage,
+		lock:       lock,
+		jobs:       jobs,
 	}
 }
 
 func (s *ValidatorSchedulerImpl) SchedulerForHourlyCollectValidatorData(ctx context.Context) {
-	s.logger.Info("Scheduler for collect validator data")
+	s.logger.WithContext(ctx).Info("Scheduler for collect validator data")
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+	s.jobs.Go(constant.HourlyCollectJobName, func(ctx context.Context) {
+		delegations, err := s.fetchDelegations(ctx)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting validator data", zap.Error(err))
+			return
+		}
 
-		err := utils.ExecTxPoolWithRetry(ctx, s.repo.GetDB(), constant.RetryCount, func(tx pgx.Tx) error {
-			repoTx := s.repo.WithTx(tx)
+		ctx, cancel := context.WithTimeout(ctx, s.config.CollectorTxTimeout)
+		defer cancel()
 
-			response, err := s.httpClient.Get(ctx, s.config.CosmosAPIURL)
-			if err != nil {
-				s.logger.Error("Error getting validator data", zap.Error(err))
-				return err
+		timestamp := utils.GetCurrentTimeInUTC()
+		s.ensureSnapshotPartitions(ctx, timestamp)
+		delegationsByValidator := lo.GroupBy(delegations, func(item delegationBalance) string {
+			return item.ValidatorAddress
+		})
+		validatorAddresses := make([]string, 0)
+		for _, validatorAddress := range lo.Uniq(lo.Map(delegations, func(item delegationBalance, _ int) string {
+			return item.ValidatorAddress
+		})) {
+			release, acquired := s.tryLockJob(ctx, constant.HourlyCollectJobName, validatorAddress)
+			if !acquired {
+				continue
 			}
+			defer release()
 
-			var data message.CosmosAPIResponse
-			err = json.Unmarshal([]byte(response.Body), &data)
-			if err != nil {
-				s.logger.Error("Error unmarshalling validator data", zap.Error(err))
-				return err
-			}
+			validatorAddresses = append(validatorAddresses, validatorAddress)
+		}
 
-			for _, delegation := range data.DelegationResponses {
-				delegationSnapshot, err := repoTx.GetDelegationSnapshotByValidatorAndDelegator(ctx, querier.GetDelegationSnapshotByValidatorAndDelegatorParams{
-					ValidatorAddress: delegation.Delegation.ValidatorAddress,
-					DelegatorAddress: delegation.Delegation.DelegatorAddress,
-				})
-				if err != nil && err != pgx.ErrNoRows {
-					s.logger.Error("Error getting delegation snapshot", zap.Error(err))
+		var snapshotsWritten int64
+		err = utils.ExecTxPoolWithRetry(ctx, s.repo.GetDB(), constant.RetryCount, func(tx pgx.Tx) error {
+			repoTx := s.repo.WithTx(tx)
+
+			snapshots := make([]querier.CreateDelegationSnapshotsParams, 0, len(delegations))
+			for _, validatorAddress := range validatorAddresses {
+				ctx := utils.ContextWithValidatorAddress(ctx, validatorAddress)
+				isCheckpoint, err := s.isCheckpointRun(ctx, repoTx, validatorAddress, timestamp)
+				if err != nil {
 					return err
 				}
 
-				currentUatom, err := strconv.ParseInt(delegation.Balance.Amount, 10, 64)
+				validatorSnapshots, err := s.buildDelegationSnapshots(ctx, repoTx, validatorAddress, delegationsByValidator[validatorAddress], timestamp, isCheckpoint)
 				if err != nil {
-					s.logger.Error("Error parsing current uatom", zap.Error(err))
 					return err
 				}
 
-				changeUatom := currentUatom - delegationSnapshot.AmountUatom
-
-				_, err = repoTx.CreateDelegationSnapshot(ctx, querier.CreateDelegationSnapshotParams{
-					ValidatorAddress: delegation.Delegation.ValidatorAddress,
-					DelegatorAddress: delegation.Delegation.DelegatorAddress,
-					AmountUatom:      currentUatom,
-					ChangeUatom:      changeUatom,
-					Timestamp:        utils.GetCurrentTimeInJakarta(),
+				_, err = repoTx.CreateSchedulerRun(ctx, querier.CreateSchedulerRunParams{
+					JobName:          constant.HourlyCollectJobName,
+					ValidatorAddress: validatorAddress,
+					Timestamp:        timestamp,
+					IsCheckpoint:     isCheckpoint,
+					RowsAffected:     int64(len(validatorSnapshots)),
 				})
-
 				if err != nil {
-					s.logger.Error("Error creating delegation snapshot", zap.Error(err))
+					s.logger.WithContext(ctx).Error("Error creating scheduler run", zap.Error(err))
 					return err
 				}
+
+				snapshots = append(snapshots, validatorSnapshots...)
 			}
+
+			rows, err := repoTx.CreateDelegationSnapshots(ctx, snapshots)
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error creating delegation snapshots", zap.Error(err))
+				return err
+			}
+
+			snapshotsWritten = rows
 			return nil
 		})
 		if err != nil {
-			s.logger.Error("Error executing transaction", zap.Error(err))
+			s.logger.WithContext(ctx).Error("Error executing transaction", zap.Error(err))
+			return
 		}
+		utils.AddSnapshotsWritten(snapshotsWritten)
 
 		s.cache.ClearCaches([]string{constant.ValidatorHourlySnapshotCacheKey}, "")
 		s.cache.ClearCaches([]string{constant.ValidatorDelegatorHistoryCacheKey}, "")
-		s.logger.Info("Successfully collected hourly validator data")
-	}()
+		s.cache.ClearCaches([]string{constant.DelegatorSummaryCacheKey, constant.DelegatorChangeHistoryCacheKey}, "")
+		s.cache.ClearCaches([]string{constant.ValidatorCohortCacheKey}, "")
+		s.cache.ClearCaches([]string{constant.ValidatorDistributionCacheKey}, "")
+		s.cache.ClearCaches([]string{constant.ValidatorDelegationAsOfCacheKey}, "")
+		s.logger.WithContext(ctx).Info("Successfully collected hourly validator data")
+	})
+}
+
+// ensureSnapshotPartitions creates the partitions of the current month and the upcoming ones that are missing,
+// so a process running longer than SNAPSHOT_PARTITION_MONTHS never writes outside a partition. A failure is
+// only logged, the insert then failing on its own if the partition of the run is missing.
+func (s *ValidatorSchedulerImpl) ensureSnapshotPartitions(ctx context.Context, timestamp time.Time) {
+	created, err := s.repo.CreateDelegationSnapshotPartitions(ctx, querier.CreateDelegationSnapshotPartitionsParams{
+		FromMonth:  timestamp,
+		MonthCount: int32(s.config.SnapshotPartitionMonths + 1),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error creating delegation snapshot partitions", zap.Error(err))
+		return
+	}
+
+	if created > 0 {
+		s.logger.WithContext(ctx).Info(fmt.Sprintf("Created %d delegation snapshot partitions", created))
+	}
+}
+
+// tryLockJob takes the lock of a job, per validator when one is given, so only one replica runs it at a time.
+// A job that is already running elsewhere, or whose lock cannot be taken, is skipped.
+func (s *ValidatorSchedulerImpl) tryLockJob(ctx context.Context, keys ...string) (func(), bool) {
+	key := utils.BuildPrefixKey(append([]string{constant.JobLockKey}, keys...)...)
+
+	release, acquired, err := s.lock.TryLock(ctx, key)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error acquiring job lock", zap.String("key", key), zap.Error(err))
+		return nil, false
+	}
+
+	if !acquired {
+		s.logger.WithContext(ctx).Warn(fmt.Sprintf("Skipping %s, already running", key))
+		utils.IncJobSkipped(keys[0])
+		return nil, false
+	}
+
+	return release, true
+}
+
+// isCheckpointRun reports whether this run has to store every balance. In
+// full mode that is every run, in CDC mode only when the last checkpoint is
+// older than the configured interval.
+func (s *ValidatorSchedulerImpl) isCheckpointRun(ctx context.Context, repo querier.Querier, validatorAddress string, timestamp time.Time) (bool, error) {
+	if s.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC {
+		return true, nil
+	}
+
+	lastCheckpoint, err := repo.GetLatestCheckpointSchedulerRun(ctx, querier.GetLatestCheckpointSchedulerRunParams{
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+	})
+	if err == pgx.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting latest checkpoint", zap.Error(err))
+		return false, err
+	}
+
+	return !timestamp.Before(lastCheckpoint.Add(s.config.SnapshotCheckpointInterval)), nil
+}
+
+// buildDelegationSnapshots loads the latest stored balance of every delegator
+// with one query per validator instead of one query per delegator. Outside a
+// checkpoint, CDC mode keeps only the changed balances and closes delegators
+// that disappeared with a zero balance row.
+func (s *ValidatorSchedulerImpl) buildDelegationSnapshots(
+	ctx context.Context,
+	repo querier.Querier,
+	validatorAddress string,
+	delegations []delegationBalance,
+	timestamp time.Time,
+	isCheckpoint bool,
+) ([]querier.CreateDelegationSnapshotsParams, error) {
+	latestSnapshots, err := repo.GetLatestDelegationSnapshotByValidator(ctx, validatorAddress)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting latest delegation snapshot", zap.Error(err))
+		return nil, err
+	}
+
+	previousBalances := make(map[string]int64, len(latestSnapshots))
+	for _, snapshot := range latestSnapshots {
+		previousBalances[snapshot.DelegatorAddress] = snapshot.AmountUatom
+	}
+
+	isCDC := s.config.SnapshotStorageMode == constant.SnapshotStorageModeCDC
+	snapshots := make([]querier.CreateDelegationSnapshotsParams, 0, len(delegations))
+	for _, delegation := range delegations {
+		changeUatom := delegation.AmountUatom - previousBalances[delegation.DelegatorAddress]
+		delete(previousBalances, delegation.DelegatorAddress)
+
+		if isCDC && !isCheckpoint && changeUatom == 0 {
+			continue
+		}
+
+		snapshots = append(snapshots, querier.CreateDelegationSnapshotsParams{
+			ValidatorAddress: validatorAddress,
+			DelegatorAddress: delegation.DelegatorAddress,
+			AmountUatom:      delegation.AmountUatom,
+			ChangeUatom:      changeUatom,
+			Timestamp:        timestamp,
+		})
+	}
+
+	if isCDC {
+		for _, snapshot := range latestSnapshots {
+			previousUatom, isGone := previousBalances[snapshot.DelegatorAddress]
+			if !isGone || previousUatom == 0 {
+				continue
+			}
+
+			snapshots = append(snapshots, querier.CreateDelegationSnapshotsParams{
+				ValidatorAddress: validatorAddress,
+				DelegatorAddress: snapshot.DelegatorAddress,
+				AmountUatom:      0,
+				ChangeUatom:      -previousUatom,
+				Timestamp:        timestamp,
+			})
+		}
+	}
+
+	return snapshots, nil
+}
+
+// fetchDelegations downloads every page of the delegation list before any
+// transaction is opened, so DB retries never hit the LCD again.
+func (s *ValidatorSchedulerImpl) fetchDelegations(ctx context.Context) ([]delegationBalance, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.CosmosAPITimeout)
+	defer cancel()
+
+	var delegations []delegationBalance
+	nextKey := ""
+	for {
+		var data message.CosmosAPIResponse
+		err := utils.RetryWithBackoff(ctx, s.config.CosmosAPIRetryCount, s.config.CosmosAPIRetryBackoff, func() error {
+			return s.fetchDelegationPage(ctx, nextKey, &data)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delegation := range data.DelegationResponses {
+			currentUatom, err := strconv.ParseInt(delegation.Balance.Amount, 10, 64)
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error parsing current uatom", zap.Error(err))
+				return nil, err
+			}
+
+			delegations = append(delegations, delegationBalance{
+				ValidatorAddress: delegation.Delegation.ValidatorAddress,
+				DelegatorAddress: delegation.Delegation.DelegatorAddress,
+				AmountUatom:      currentUatom,
+			})
+		}
+
+		if data.Pagination.NextKey == "" {
+			utils.AddDelegationsFetched(len(delegations))
+			return delegations, nil
+		}
+		nextKey = data.Pagination.NextKey
+	}
+}
+
+func (s *ValidatorSchedulerImpl) fetchDelegationPage(ctx context.Context, nextKey string, data *message.CosmosAPIResponse) error {
+	apiURL := s.config.CosmosAPIURL
+	if nextKey != "" {
+		u, err := url.Parse(apiURL)
+		if err != nil {
+			return err
+		}
+		query := u.Query()
+		query.Set("pagination.key", nextKey)
+		u.RawQuery = query.Encode()
+		apiURL = u.String()
+	}
+
+	response, err := s.httpClient.Get(ctx, apiURL)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting validator data", zap.Error(err))
+		utils.IncLCDError(0)
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		utils.IncLCDError(response.StatusCode)
+		s.logger.WithContext(ctx).Error(fmt.Sprintf("Unexpected status code %d from cosmos api", response.StatusCode))
+		return fmt.Errorf("unexpected status code %d from cosmos api", response.StatusCode)
+	}
+
+	err = json.Unmarshal([]byte(response.Body), data)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error unmarshalling validator data", zap.Error(err))
+		return err
+	}
+
+	return nil
 }
 
 func (s *ValidatorSchedulerImpl) SchedulerForDailyCollectValidatorData(ctx context.Context) {
-	s.logger.Info("Scheduler for collect validator data")
+	s.logger.WithContext(ctx).Info("Scheduler for collect validator data")
+
+	s.jobs.Go(constant.DailyCollectJobName, func(ctx context.Context) {
+		loc, err := time.LoadLocation(s.config.ReportingTimezone)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error loading reporting timezone", zap.Error(err))
+			return
+		}
+		date := utils.GetDateInLocation(utils.GetCurrentTimeInUTC(), loc)
+
+		// The daily aggregates of every validator are written together, so the job is locked as a whole
+		release, acquired := s.tryLockJob(ctx, constant.DailyCollectJobName)
+		if !acquired {
+			return
+		}
+		defer release()
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		// The commission only feeds the comparison endpoint, so a failed fetch must not hold back the daily aggregates.
+		// The fetches get a budget of their own, so slow LCD calls do not eat into the one of the transaction.
+		commissionCtx, cancelCommissions := context.WithTimeout(ctx, s.config.CommissionFetchTimeout)
+		commissions := s.fetchCommissions(commissionCtx)
+		cancelCommissions()
+
+		ctx, cancel := context.WithTimeout(ctx, s.config.CollectorTxTimeout)
 		defer cancel()
 
-		err := utils.ExecTxPoolWithRetry(ctx, s.repo.GetDB(), constant.RetryCount, func(tx pgx.Tx) error {
+		err = utils.ExecTxPoolWithRetry(ctx, s.repo.GetDB(), constant.RetryCount, func(tx pgx.Tx) error {
 			repoTx := s.repo.WithTx(tx)
 
-			delegationSnapshot, err := repoTx.GetLatestDelegationSnapshot(ctx)
+			for validatorAddress, commissionRate := range commissions {
+				_, err := repoTx.CreateValidatorCommission(ctx, querier.CreateValidatorCommissionParams{
+					ValidatorAddress: validatorAddress,
+					Date:             date,
+					CommissionRate:   commissionRate,
+				})
+				if err != nil {
+					s.logger.WithContext(ctx).Error("Error creating validator commission", zap.Error(err))
+					return err
+				}
+			}
+
+			delegationSnapshot, err := repoTx.GetLatestDelegationSnapshot(ctx, querier.GetLatestDelegationSnapshotParams{
+				LatestRunOnly: s.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC,
+				JobName:       constant.HourlyCollectJobName,
+			})
 			if err != nil {
-				s.logger.Error("Error getting latest delegation snapshot", zap.Error(err))
+				s.logger.WithContext(ctx).Error("Error getting latest delegation snapshot", zap.Error(err))
 				return err
 			}
 
 			for _, delegation := range delegationSnapshot {
+				if delegation.AmountUatom == 0 {
+					continue
+				}
+
 				_, err = repoTx.CreateDailyAggregate(ctx, querier.CreateDailyAggregateParams{
 					ValidatorAddress: delegation.ValidatorAddress,
 					DelegatorAddress: delegation.DelegatorAddress,
-					Date:             utils.GetCurrentTimeInJakarta(),
+					Date:             date,
 					TotalAmount:      delegation.AmountUatom,
 				})
 				if err != nil {
-					s.logger.Error("Error creating daily aggregate", zap.Error(err))
+					s.logger.WithContext(ctx).Error("Error creating daily aggregate", zap.Error(err))
 					return err
 				}
 			}
+
+			_, err = repoTx.CreateDailyConcentrationMetrics(ctx, date)
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error creating daily concentration metrics", zap.Error(err))
+				return err
+			}
 			return nil
 		})
 		if err != nil {
-			s.logger.Error("Error executing transaction", zap.Error(err))
+			s.logger.WithContext(ctx).Error("Error executing transaction", zap.Error(err))
+			return
 		}
 
 		s.cache.ClearCaches([]string{constant.ValidatorDailySnapshotCacheKey}, "")
-		s.logger.Info("Successfully collected daily validator data")
-	}()
+		s.cache.ClearCaches([]string{constant.ValidatorConcentrationCacheKey}, "")
+		s.cache.ClearCaches([]string{constant.ValidatorDistributionCacheKey}, "")
+		s.cache.ClearCaches([]string{constant.ValidatorCompareCacheKey}, "")
+		s.logger.WithContext(ctx).Info("Successfully collected daily validator data")
+	})
+}
+
+// fetchCommissions returns the current commission rate of every tracked validator, validators that cannot be fetched are left out
+func (s *ValidatorSchedulerImpl) fetchCommissions(ctx context.Context) map[string]float64 {
+	commissions := make(map[string]float64)
+
+	validatorAddresses, err := s.repo.GetValidatorAddressesBySchedulerRun(ctx, constant.HourlyCollectJobName)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting validator addresses", zap.Error(err))
+		return commissions
+	}
+
+	for _, validatorAddress := range validatorAddresses {
+		ctx := utils.ContextWithValidatorAddress(ctx, validatorAddress)
+		var data message.CosmosValidatorResponse
+		err := utils.RetryWithBackoff(ctx, s.config.CosmosAPIRetryCount, s.config.CosmosAPIRetryBackoff, func() error {
+			return s.fetchValidator(ctx, validatorAddress, &data)
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting validator commission", zap.Error(err))
+			continue
+		}
+
+		commissionRate, err := strconv.ParseFloat(data.Validator.Commission.CommissionRates.Rate, 64)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error parsing validator commission", zap.Error(err))
+			continue
+		}
+
+		commissions[validatorAddress] = commissionRate
+	}
+
+	return commissions
+}
+
+func (s *ValidatorSchedulerImpl) fetchValidator(ctx context.Context, validatorAddress string, data *message.CosmosValidatorResponse) error {
+	apiURL, err := url.JoinPath(s.config.CosmosValidatorAPIURL, validatorAddress)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.httpClient.Get(ctx, apiURL)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting validator", zap.Error(err))
+		utils.IncLCDError(0)
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		utils.IncLCDError(response.StatusCode)
+		s.logger.WithContext(ctx).Error(fmt.Sprintf("Unexpected status code %d from cosmos api", response.StatusCode))
+		return fmt.Errorf("unexpected status code %d from cosmos api", response.StatusCode)
+	}
+
+	err = json.Unmarshal([]byte(response.Body), data)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error unmarshalling validator", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *ValidatorSchedulerImpl) SchedulerForRetentionValidatorData(ctx context.Context, dryRun bool) {
+	s.logger.WithContext(ctx).Info("Scheduler for retention validator data")
+
+	s.jobs.Go(constant.RetentionJobName, func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, s.config.RetentionTimeout)
+		defer cancel()
+
+		loc, err := time.LoadLocation(s.config.ReportingTimezone)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error loading reporting timezone", zap.Error(err))
+			return
+		}
+
+		timestamp := utils.GetCurrentTimeInUTC()
+		cutoff := utils.GetStartOfDayInLocation(timestamp.AddDate(0, 0, -s.config.RetentionDays), loc)
+
+		validatorAddresses, err := s.repo.GetValidatorAddressesBySchedulerRun(ctx, constant.HourlyCollectJobName)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting validator addresses", zap.Error(err))
+			return
+		}
+
+		// A failing validator must not hold back the others, the errors are reported once every validator is done
+		var totalRows int64
+		var errs []error
+		for _, validatorAddress := range validatorAddresses {
+			ctx := utils.ContextWithValidatorAddress(ctx, validatorAddress)
+			release, acquired := s.tryLockJob(ctx, constant.RetentionJobName, validatorAddress)
+			if !acquired {
+				continue
+			}
+
+			var rowsAffected int64
+			err = utils.ExecTxPoolWithRetry(ctx, s.repo.GetDB(), constant.RetryCount, func(tx pgx.Tx) error {
+				repoTx := s.repo.WithTx(tx)
+
+				rows, err := s.applyRetention(ctx, repoTx, validatorAddress, cutoff, timestamp, dryRun)
+				if err != nil {
+					return err
+				}
+
+				rowsAffected = rows
+				return nil
+			})
+			release()
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error executing transaction", zap.Error(err))
+				errs = append(errs, fmt.Errorf("%s: %w", validatorAddress, err))
+				continue
+			}
+			totalRows += rowsAffected
+		}
+		err = errors.Join(errs...)
+
+		if dryRun {
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error applying retention dry run", zap.Error(err))
+				return
+			}
+			s.logger.WithContext(ctx).Info(fmt.Sprintf("Retention dry run, %d hourly rows would be removed", totalRows))
+			return
+		}
+
+		s.cache.ClearCaches([]string{
+			constant.ValidatorHourlySnapshotCacheKey,
+			constant.ValidatorDailySnapshotCacheKey,
+			constant.ValidatorDelegatorHistoryCacheKey,
+			constant.DelegatorSummaryCacheKey,
+			constant.DelegatorChangeHistoryCacheKey,
+			constant.ValidatorCohortCacheKey,
+			constant.ValidatorDistributionCacheKey,
+			constant.ValidatorCompareCacheKey,
+			constant.ValidatorDelegationAsOfCacheKey,
+		}, "")
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error applying retention", zap.Error(err))
+			return
+		}
+		s.logger.WithContext(ctx).Info(fmt.Sprintf("Successfully applied retention, %d hourly rows removed", totalRows))
+	})
+}
+
+// applyRetention downsamples the hourly runs before cutoff into daily aggregates and removes them.
+// Rows are only removed up to the last checkpoint before cutoff, since the runs after it are rebuilt from that checkpoint.
+func (s *ValidatorSchedulerImpl) applyRetention(
+	ctx context.Context,
+	repo querier.Querier,
+	validatorAddress string,
+	cutoff time.Time,
+	timestamp time.Time,
+	dryRun bool,
+) (int64, error) {
+	var rowsAffected int64
+
+	removeBefore, err := repo.GetLatestCheckpointSchedulerRunBefore(ctx, querier.GetLatestCheckpointSchedulerRunBeforeParams{
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		Before:           cutoff,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		s.logger.WithContext(ctx).Error("Error getting latest checkpoint scheduler run", zap.Error(err))
+		return 0, err
+	}
+	hasCheckpoint := err == nil
+
+	jobName := constant.RetentionJobName
+	if dryRun {
+		jobName = constant.RetentionDryRunJobName
+	}
+
+	if hasCheckpoint && dryRun {
+		rowsAffected, err = repo.GetCountDelegationSnapshotBefore(ctx, querier.GetCountDelegationSnapshotBeforeParams{
+			ValidatorAddress: validatorAddress,
+			Before:           removeBefore,
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error counting delegation snapshots", zap.Error(err))
+			return 0, err
+		}
+	}
+
+	if hasCheckpoint && !dryRun {
+		_, err = repo.CreateDownsampledDailyAggregates(ctx, querier.CreateDownsampledDailyAggregatesParams{
+			DayTimezone:      s.config.ReportingTimezone,
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           cutoff,
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error creating downsampled daily aggregates", zap.Error(err))
+			return 0, err
+		}
+
+		rowsAffected, err = repo.DeleteDelegationSnapshotsBefore(ctx, querier.DeleteDelegationSnapshotsBeforeParams{
+			ValidatorAddress: validatorAddress,
+			Before:           removeBefore,
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error deleting delegation snapshots", zap.Error(err))
+			return 0, err
+		}
+
+		_, err = repo.DeleteSchedulerRunsBefore(ctx, querier.DeleteSchedulerRunsBeforeParams{
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           removeBefore,
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error deleting scheduler runs", zap.Error(err))
+			return 0, err
+		}
+	}
+
+	_, err = repo.CreateSchedulerRun(ctx, querier.CreateSchedulerRunParams{
+		JobName:          jobName,
+		ValidatorAddress: validatorAddress,
+		Timestamp:        timestamp,
+		RowsAffected:     rowsAffected,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error creating scheduler run", zap.Error(err))
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+func (s *ValidatorSchedulerImpl) SchedulerForParquetExportValidatorData(ctx context.Context) {
+	s.logger.WithContext(ctx).Info("Scheduler for parquet export validator data")
+
+	s.jobs.Go(constant.ParquetExportJobName, func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, s.config.ExportTimeout)
+		defer cancel()
+
+		loc, err := time.LoadLocation(s.config.ReportingTimezone)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error loading reporting timezone", zap.Error(err))
+			return
+		}
+
+		validatorAddresses, err := s.repo.GetValidatorAddressesBySchedulerRun(ctx, constant.HourlyCollectJobName)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting validator addresses", zap.Error(err))
+			return
+		}
+
+		timestamp := utils.GetCurrentTimeInUTC()
+
+		var totalFiles int
+		var errs []error
+		for _, validatorAddress := range validatorAddresses {
+			ctx := utils.ContextWithValidatorAddress(ctx, validatorAddress)
+			release, acquired := s.tryLockJob(ctx, constant.ParquetExportJobName, validatorAddress)
+			if !acquired {
+				continue
+			}
+
+			files, err := s.exportValidatorData(ctx, validatorAddress, timestamp, loc)
+			release()
+			totalFiles += files
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error exporting validator data", zap.Error(err))
+				errs = append(errs, fmt.Errorf("%s: %w", validatorAddress, err))
+			}
+		}
+
+		if err := errors.Join(errs...); err != nil {
+			s.logger.WithContext(ctx).Error(fmt.Sprintf("Error exporting validator data, %d parquet files written", totalFiles), zap.Error(err))
+			return
+		}
+		s.logger.WithContext(ctx).Info(fmt.Sprintf("Successfully exported validator data, %d parquet files written", totalFiles))
+	})
+}
+
+// exportValidatorData writes every finished day of the validator that is not in the export manifest yet.
+// A day is only recorded in the manifest after its file is written, so a failed run is picked up again by the next one.
+func (s *ValidatorSchedulerImpl) exportValidatorData(
+	ctx context.Context,
+	validatorAddress string,
+	timestamp time.Time,
+	loc *time.Location,
+) (int, error) {
+	var files int
+
+	snapshotDates, err := s.repo.GetUnexportedSnapshotDates(ctx, querier.GetUnexportedSnapshotDatesParams{
+		DayTimezone:      loc.String(),
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		Before:           utils.GetStartOfDayInLocation(timestamp, loc),
+		Dataset:          constant.ExportDatasetDelegationSnapshots,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting unexported snapshot dates", zap.Error(err))
+		return files, err
+	}
+
+	for _, date := range snapshotDates {
+		startTime := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+		snapshots, err := s.repo.GetDelegationSnapshotByValidatorAndTimeRange(ctx, querier.GetDelegationSnapshotByValidatorAndTimeRangeParams{
+			ValidatorAddress: validatorAddress,
+			StartTime:        startTime,
+			EndTime:          startTime.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting delegation snapshots", zap.Error(err))
+			return files, err
+		}
+
+		records := lo.Map(snapshots, func(item querier.GetDelegationSnapshotByValidatorAndTimeRangeRow, _ int) message.DelegationSnapshotRecord {
+			return message.DelegationSnapshotRecord{
+				ValidatorAddress: item.ValidatorAddress,
+				DelegatorAddress: item.DelegatorAddress,
+				AmountUatom:      item.AmountUatom,
+				ChangeUatom:      item.ChangeUatom,
+				Timestamp:        item.Timestamp.UTC(),
+			}
+		})
+
+		err = exportParquet(ctx, s, constant.ExportDatasetDelegationSnapshots, validatorAddress, date, records)
+		if err != nil {
+			return files, err
+		}
+		files++
+	}
+
+	aggregateDates, err := s.repo.GetUnexportedDailyAggregateDates(ctx, querier.GetUnexportedDailyAggregateDatesParams{
+		ValidatorAddress: validatorAddress,
+		Before:           utils.GetDateInLocation(timestamp, loc),
+		Dataset:          constant.ExportDatasetDailyAggregates,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting unexported daily aggregate dates", zap.Error(err))
+		return files, err
+	}
+
+	for _, date := range aggregateDates {
+		aggregates, err := s.repo.GetDailyAggregateByValidatorAndDate(ctx, querier.GetDailyAggregateByValidatorAndDateParams{
+			ValidatorAddress: validatorAddress,
+			Date:             date,
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting daily aggregates", zap.Error(err))
+			return files, err
+		}
+
+		records := lo.Map(aggregates, func(item querier.GetDailyAggregateByValidatorAndDateRow, _ int) message.DailyAggregateRecord {
+			return message.DailyAggregateRecord{
+				ValidatorAddress: item.ValidatorAddress,
+				DelegatorAddress: item.DelegatorAddress,
+				Date:             int32(item.Date.Unix() / int64((24 * time.Hour).Seconds())),
+				TotalAmount:      item.TotalAmount,
+			}
+		})
+
+		err = exportParquet(ctx, s, constant.ExportDatasetDailyAggregates, validatorAddress, date, records)
+		if err != nil {
+			return files, err
+		}
+		files++
+	}
+
+	return files, nil
+}
+
+// exportParquet writes the records of one validator and day as a parquet file and records it in the export manifest.
+func exportParquet[T any](
+	ctx context.Context,
+	s *ValidatorSchedulerImpl,
+	dataset string,
+	validatorAddress string,
+	date time.Time,
+	records []T,
+) error {
+	key := fmt.Sprintf("%s/validator=%s/date=%s/part-0.parquet", dataset, validatorAddress, date.Format(constant.DateFormat))
+
+	var buf bytes.Buffer
+	writer := parquet.NewGenericWriter[T](&buf)
+	if _, err := writer.Write(records); err != nil {
+		s.logger.WithContext(ctx).Error("Error writing parquet records", zap.Error(err))
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		s.logger.WithContext(ctx).Error("Error closing parquet writer", zap.Error(err))
+		return err
+	}
+
+	err := s.storage.Put(ctx, key, &buf, int64(buf.Len()), "application/vnd.apache.parquet")
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error putting parquet object", zap.Error(err))
+		return err
+	}
+
+	_, err = s.repo.CreateExportManifest(ctx, querier.CreateExportManifestParams{
+		Dataset:          dataset,
+		ValidatorAddress: validatorAddress,
+		Date:             date,
+		ObjectKey:        key,
+		RowsExported:     int64(len(records)),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error creating export manifest", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *ValidatorSchedulerImpl) SchedulerForDelegatorEventsValidatorData(ctx context.Context) {
+	s.logger.WithContext(ctx).Info("Scheduler for delegator events validator data")
+
+	s.jobs.Go(constant.DelegatorEventsJobName, func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, s.config.DelegatorEventsTimeout)
+		defer cancel()
+
+		loc, err := time.LoadLocation(s.config.ReportingTimezone)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error loading reporting timezone", zap.Error(err))
+			return
+		}
+
+		validatorAddresses, err := s.repo.GetValidatorAddressesBySchedulerRun(ctx, constant.HourlyCollectJobName)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error getting validator addresses", zap.Error(err))
+			return
+		}
+
+		timestamp := utils.GetCurrentTimeInUTC()
+
+		var totalEvents int64
+		var errs []error
+		for _, validatorAddress := range validatorAddresses {
+			ctx := utils.ContextWithValidatorAddress(ctx, validatorAddress)
+			release, acquired := s.tryLockJob(ctx, constant.DelegatorEventsJobName, validatorAddress)
+			if !acquired {
+				continue
+			}
+
+			events, err := s.collectDelegatorEvents(ctx, validatorAddress, timestamp, loc)
+			release()
+			totalEvents += events
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error collecting delegator events", zap.Error(err))
+				errs = append(errs, fmt.Errorf("%s: %w", validatorAddress, err))
+			}
+		}
+
+		s.cache.ClearCaches([]string{constant.ValidatorDelegatorEventCacheKey}, "")
+		if err := errors.Join(errs...); err != nil {
+			s.logger.WithContext(ctx).Error(fmt.Sprintf("Error collecting delegator events, %d events written", totalEvents), zap.Error(err))
+			return
+		}
+		s.logger.WithContext(ctx).Info(fmt.Sprintf("Successfully collected delegator events, %d events written", totalEvents))
+	})
+}
+
+// collectDelegatorEvents writes the new, churned and returned delegators of every finished day of the validator that is not processed yet.
+// Days are processed in order and each one is recorded as a scheduler run, since a day without events leaves nothing else behind.
+func (s *ValidatorSchedulerImpl) collectDelegatorEvents(
+	ctx context.Context,
+	validatorAddress string,
+	timestamp time.Time,
+	loc *time.Location,
+) (int64, error) {
+	var totalEvents int64
+
+	dates, err := s.repo.GetUnprocessedDelegatorEventDates(ctx, querier.GetUnprocessedDelegatorEventDatesParams{
+		DayTimezone:      loc.String(),
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		Before:           utils.GetStartOfDayInLocation(timestamp, loc),
+		EventJobName:     constant.DelegatorEventsJobName,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error getting unprocessed delegator event dates", zap.Error(err))
+		return totalEvents, err
+	}
+
+	for _, date := range dates {
+		startTime := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+		var events int64
+		err = utils.ExecTxPoolWithRetry(ctx, s.repo.GetDB(), constant.RetryCount, func(tx pgx.Tx) error {
+			repoTx := s.repo.WithTx(tx)
+
+			rows, err := repoTx.CreateDelegatorEvents(ctx, querier.CreateDelegatorEventsParams{
+				ValidatorAddress: validatorAddress,
+				Date:             date,
+				JobName:          constant.HourlyCollectJobName,
+				StartTime:        startTime,
+				EndTime:          startTime.AddDate(0, 0, 1),
+				LatestRunOnly:    s.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC,
+			})
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error creating delegator events", zap.Error(err))
+				return err
+			}
+
+			_, err = repoTx.CreateSchedulerRun(ctx, querier.CreateSchedulerRunParams{
+				JobName:          constant.DelegatorEventsJobName,
+				ValidatorAddress: validatorAddress,
+				Timestamp:        startTime,
+				IsCheckpoint:     false,
+				RowsAffected:     rows,
+			})
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Error creating scheduler run", zap.Error(err))
+				return err
+			}
+
+			events = rows
+			return nil
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Error executing transaction", zap.Error(err))
+			return totalEvents, err
+		}
+		totalEvents += events
+	}
+
+	return totalEvents, nil
 }
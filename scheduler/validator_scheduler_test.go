@@ -1,19 +1,31 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
 	mockrepo "github.com/gadhittana01/cosmos-validation-tracking/db/repository/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/scheduler/message"
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
 	"github.com/gadhittana01/cosmos-validation-tracking/utils/types"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/parquet-go/parquet-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,8 +39,12 @@ func initValidatorScheduler(t *testing.T, ctrl *gomock.Controller) (
 	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
 	mockHTTPClient := mockutl.NewMockHTTPClient(ctrl)
 	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
+	config.ExportLocalDir = t.TempDir()
+	storage := utils.NewObjectStorage(config)
+	mockLock := mockutl.NewMockLockSvc(ctrl)
+	mockLock.EXPECT().TryLock(gomock.Any(), gomock.Any()).Return(func() {}, true, nil).AnyTimes()
 
-	return NewValidatorScheduler(mockRepo, config, mockLogger, mockHTTPClient, cacheSvc), mockRepo, config, mockLogger, mockHTTPClient
+	return NewValidatorScheduler(mockRepo, config, mockLogger, mockHTTPClient, cacheSvc, storage, mockLock, utils.NewJobManager(mockLogger)), mockRepo, config, mockLogger, mockHTTPClient
 }
 
 func TestSchedulerForHourlyCollectValidatorData(t *testing.T) {
@@ -37,7 +53,12 @@ func TestSchedulerForHourlyCollectValidatorData(t *testing.T) {
 	defer ctrl.Finish()
 	validatorScheduler, mockRepo, config, mockLogger, mockHTTPClient := initValidatorScheduler(t, ctrl)
 	mockutl.LoggerMock(mockLogger)
+	mockRepo.EXPECT().CreateDelegationSnapshotPartitions(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg querier.CreateDelegationSnapshotPartitionsParams) (int32, error) {
+		assert.Equal(t, int32(config.SnapshotPartitionMonths+1), arg.MonthCount)
+		return int32(0), nil
+	}).AnyTimes()
 	retryCount := constant.RetryCount + 1
+	fetchCount := config.CosmosAPIRetryCount + 1
 
 	t.Run("success collect hourly validator data", func(t *testing.T) {
 		mockrepo.SetupMockTxPool(ctrl, mockRepo)
@@ -62,54 +83,144 @@ func TestSchedulerForHourlyCollectValidatorData(t *testing.T) {
 			Headers: map[string][]string{},
 		}, nil).Times(1)
 
-		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndDelegator(gomock.Any(), querier.GetDelegationSnapshotByValidatorAndDelegatorParams{
-			ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-			DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-		}).Return(querier.GetDelegationSnapshotByValidatorAndDelegatorRow{
-			AmountUatom: 8000,
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500").Return([]querier.GetLatestDelegationSnapshotByValidatorRow{
+			{
+				DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+				AmountUatom:      8000,
+			},
 		}, nil).Times(1)
 
-		mockRepo.EXPECT().CreateDelegationSnapshot(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDelegationSnapshotParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateDelegationSnapshotParams) (uuid.UUID, error) {
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateSchedulerRunParams) (uuid.UUID, error) {
+			assert.Equal(t, constant.HourlyCollectJobName, arg.JobName)
 			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
-			assert.Equal(t, "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.DelegatorAddress)
-			assert.Equal(t, int64(8000), arg.AmountUatom)
-			assert.Equal(t, int64(0), arg.ChangeUatom)
+			assert.True(t, arg.IsCheckpoint)
+			assert.Equal(t, int64(1), arg.RowsAffected)
 			return uuid.New(), nil
 		}).Times(1)
 
+		mockRepo.EXPECT().CreateDelegationSnapshots(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg []querier.CreateDelegationSnapshotsParams) (int64, error) {
+			assert.Len(t, arg, 1)
+			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg[0].ValidatorAddress)
+			assert.Equal(t, "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg[0].DelegatorAddress)
+			assert.Equal(t, int64(8000), arg[0].AmountUatom)
+			assert.Equal(t, int64(0), arg[0].ChangeUatom)
+			return int64(len(arg)), nil
+		}).Times(1)
+
 		validatorScheduler.SchedulerForHourlyCollectValidatorData(ctx)
-		time.Sleep(1 * time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
 	})
 
-	t.Run("error get validator data", func(t *testing.T) {
-		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
+	t.Run("success collect paginated validator data", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosAPIURL).Return(&types.HTTPResponse{
+			StatusCode: 200,
+			Body: `{
+				"delegation_responses": [
+					{
+						"delegation": {
+							"delegator_address": "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+							"validator_address": "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+							"shares": "8003.200796626260454171"
+						},
+						"balance": {
+							"denom": "uatom",
+							"amount": "8000"
+						}
+					}
+				],
+				"pagination": {
+					"next_key": "FPSomeKey==",
+					"total": "2"
+				}
+			}`,
+			Headers: map[string][]string{},
+		}, nil).Times(1)
+
+		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosAPIURL+"?pagination.key=FPSomeKey%3D%3D").Return(&types.HTTPResponse{
+			StatusCode: 200,
+			Body: `{
+				"delegation_responses": [
+					{
+						"delegation": {
+							"delegator_address": "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv",
+							"validator_address": "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+							"shares": "5000.000000000000000000"
+						},
+						"balance": {
+							"denom": "uatom",
+							"amount": "5000"
+						}
+					}
+				],
+				"pagination": {
+					"next_key": null,
+					"total": "2"
+				}
+			}`,
+			Headers: map[string][]string{},
+		}, nil).Times(1)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500").Return([]querier.GetLatestDelegationSnapshotByValidatorRow{
+			{
+				DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+				AmountUatom:      8000,
+			},
+		}, nil).Times(1)
 
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateSchedulerRunParams) (uuid.UUID, error) {
+			assert.Equal(t, constant.HourlyCollectJobName, arg.JobName)
+			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
+			assert.True(t, arg.IsCheckpoint)
+			assert.Equal(t, int64(2), arg.RowsAffected)
+			return uuid.New(), nil
+		}).Times(1)
+
+		mockRepo.EXPECT().CreateDelegationSnapshots(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg []querier.CreateDelegationSnapshotsParams) (int64, error) {
+			assert.Len(t, arg, 2)
+			assert.Equal(t, int64(0), arg[0].ChangeUatom)
+			assert.Equal(t, "cosmos1pxlmxuzdams3e9j54gdvaell0npa2j695r90jv", arg[1].DelegatorAddress)
+			assert.Equal(t, int64(5000), arg[1].ChangeUatom)
+			assert.Equal(t, arg[0].Timestamp, arg[1].Timestamp)
+			return int64(len(arg)), nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForHourlyCollectValidatorData(ctx)
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	t.Run("error get validator data", func(t *testing.T) {
 		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosAPIURL).Return(&types.HTTPResponse{
 			StatusCode: 400,
 			Body:       ``,
 			Headers:    map[string][]string{},
-		}, errInvalidReq).Times(retryCount)
+		}, errInvalidReq).Times(fetchCount)
 
-		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndDelegator(gomock.Any(), querier.GetDelegationSnapshotByValidatorAndDelegatorParams{
-			ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-			DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-		}).Return(querier.GetDelegationSnapshotByValidatorAndDelegatorRow{
-			AmountUatom: 8000,
-		}, nil).Times(0)
+		mockRepo.EXPECT().GetDB().Times(0)
 
-		mockRepo.EXPECT().CreateDelegationSnapshot(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDelegationSnapshotParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateDelegationSnapshotParams) (uuid.UUID, error) {
-			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
-			assert.Equal(t, "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.DelegatorAddress)
-			assert.Equal(t, int64(8000), arg.AmountUatom)
-			assert.Equal(t, int64(0), arg.ChangeUatom)
-			return uuid.New(), nil
-		}).Times(0)
+		mockRepo.EXPECT().CreateDelegationSnapshots(gomock.Any(), gomock.Any()).Times(0)
 
 		validatorScheduler.SchedulerForHourlyCollectValidatorData(ctx)
 		time.Sleep(1000 * time.Millisecond)
 	})
 
-	t.Run("failed get delegation snapshot", func(t *testing.T) {
+	t.Run("error unexpected status code", func(t *testing.T) {
+		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosAPIURL).Return(&types.HTTPResponse{
+			StatusCode: 503,
+			Body:       ``,
+			Headers:    map[string][]string{},
+		}, nil).Times(fetchCount)
+
+		mockRepo.EXPECT().GetDB().Times(0)
+
+		mockRepo.EXPECT().CreateDelegationSnapshots(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForHourlyCollectValidatorData(ctx)
+		time.Sleep(1000 * time.Millisecond)
+	})
+
+	t.Run("failed get latest delegation snapshot by validator", func(t *testing.T) {
 		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
 
 		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosAPIURL).Return(&types.HTTPResponse{
@@ -130,26 +241,17 @@ func TestSchedulerForHourlyCollectValidatorData(t *testing.T) {
 				]
 			}`,
 			Headers: map[string][]string{},
-		}, nil).Times(retryCount)
+		}, nil).Times(1)
 
-		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndDelegator(gomock.Any(), querier.GetDelegationSnapshotByValidatorAndDelegatorParams{
-			ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-			DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-		}).Return(querier.GetDelegationSnapshotByValidatorAndDelegatorRow{}, errInvalidReq).Times(retryCount)
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500").Return([]querier.GetLatestDelegationSnapshotByValidatorRow{}, errInvalidReq).Times(retryCount)
 
-		mockRepo.EXPECT().CreateDelegationSnapshot(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDelegationSnapshotParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateDelegationSnapshotParams) (uuid.UUID, error) {
-			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
-			assert.Equal(t, "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.DelegatorAddress)
-			assert.Equal(t, int64(8000), arg.AmountUatom)
-			assert.Equal(t, int64(0), arg.ChangeUatom)
-			return uuid.New(), nil
-		}).Times(0)
+		mockRepo.EXPECT().CreateDelegationSnapshots(gomock.Any(), gomock.Any()).Times(0)
 
 		validatorScheduler.SchedulerForHourlyCollectValidatorData(ctx)
 		time.Sleep(1000 * time.Millisecond)
 	})
 
-	t.Run("failed create delegation snapshot", func(t *testing.T) {
+	t.Run("failed create delegation snapshots", func(t *testing.T) {
 		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
 
 		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosAPIURL).Return(&types.HTTPResponse{
@@ -170,22 +272,18 @@ func TestSchedulerForHourlyCollectValidatorData(t *testing.T) {
 				]
 			}`,
 			Headers: map[string][]string{},
-		}, nil).Times(retryCount)
+		}, nil).Times(1)
 
-		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndDelegator(gomock.Any(), querier.GetDelegationSnapshotByValidatorAndDelegatorParams{
-			ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-			DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
-		}).Return(querier.GetDelegationSnapshotByValidatorAndDelegatorRow{
-			AmountUatom: 8000,
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500").Return([]querier.GetLatestDelegationSnapshotByValidatorRow{
+			{
+				DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+				AmountUatom:      8000,
+			},
 		}, nil).Times(retryCount)
 
-		mockRepo.EXPECT().CreateDelegationSnapshot(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDelegationSnapshotParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateDelegationSnapshotParams) (uuid.UUID, error) {
-			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
-			assert.Equal(t, "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.DelegatorAddress)
-			assert.Equal(t, int64(8000), arg.AmountUatom)
-			assert.Equal(t, int64(0), arg.ChangeUatom)
-			return uuid.New(), errInvalidReq
-		}).Times(retryCount)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).Return(uuid.New(), nil).Times(retryCount)
+
+		mockRepo.EXPECT().CreateDelegationSnapshots(gomock.Any(), gomock.Any()).Return(int64(0), errInvalidReq).Times(retryCount)
 
 		validatorScheduler.SchedulerForHourlyCollectValidatorData(ctx)
 		time.Sleep(1000 * time.Millisecond)
@@ -197,14 +295,42 @@ func TestSchedulerForDailyCollectValidatorData(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	validatorScheduler, mockRepo, _, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	validatorScheduler, mockRepo, config, mockLogger, mockHTTPClient := initValidatorScheduler(t, ctrl)
 	mockutl.LoggerMock(mockLogger)
 	retryCount := constant.RetryCount + 1
+	fetchCount := config.CosmosAPIRetryCount + 1
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
 
 	t.Run("success collect daily validator data", func(t *testing.T) {
 		mockrepo.SetupMockTxPool(ctrl, mockRepo)
 
-		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosValidatorAPIURL+"/"+validatorAddress).Return(&types.HTTPResponse{
+			StatusCode: 200,
+			Body: `{
+				"validator": {
+					"operator_address": "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+					"commission": {
+						"commission_rates": {
+							"rate": "0.050000000000000000",
+							"max_rate": "0.200000000000000000",
+							"max_change_rate": "0.010000000000000000"
+						}
+					}
+				}
+			}`,
+			Headers: map[string][]string{},
+		}, nil).Times(1)
+		mockRepo.EXPECT().CreateValidatorCommission(gomock.Any(), querier.CreateValidatorCommissionParams{
+			ValidatorAddress: validatorAddress,
+			Date:             utils.GetDateInLocation(time.Now(), time.UTC),
+			CommissionRate:   0.05,
+		}).Return(uuid.New(), nil).Times(1)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any(), querier.GetLatestDelegationSnapshotParams{
+			LatestRunOnly: true,
+			JobName:       constant.HourlyCollectJobName,
+		}).Return([]querier.GetLatestDelegationSnapshotRow{
 			{
 				ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
 				DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
@@ -216,9 +342,12 @@ func TestSchedulerForDailyCollectValidatorData(t *testing.T) {
 			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
 			assert.Equal(t, "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.DelegatorAddress)
 			assert.Equal(t, int64(8000), arg.TotalAmount)
+			assert.Equal(t, utils.GetDateInLocation(time.Now(), time.UTC), arg.Date)
 			return uuid.New(), nil
 		}).Times(1)
 
+		mockRepo.EXPECT().CreateDailyConcentrationMetrics(gomock.Any(), utils.GetDateInLocation(time.Now(), time.UTC)).Return(int64(1), nil).Times(1)
+
 		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
 		time.Sleep(1 * time.Millisecond)
 	})
@@ -226,7 +355,9 @@ func TestSchedulerForDailyCollectValidatorData(t *testing.T) {
 	t.Run("error get latest delegation snapshot", func(t *testing.T) {
 		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
 
-		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{}, errInvalidReq).Times(retryCount)
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{}, nil).Times(1)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any(), gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{}, errInvalidReq).Times(retryCount)
 
 		mockRepo.EXPECT().CreateDailyAggregate(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDailyAggregateParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateDailyAggregateParams) (uuid.UUID, error) {
 			assert.Equal(t, "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500", arg.ValidatorAddress)
@@ -242,7 +373,9 @@ func TestSchedulerForDailyCollectValidatorData(t *testing.T) {
 	t.Run("failed create daily aggregate", func(t *testing.T) {
 		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
 
-		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{}, nil).Times(1)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any(), gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{
 			{
 				ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
 				DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
@@ -257,7 +390,935 @@ func TestSchedulerForDailyCollectValidatorData(t *testing.T) {
 			return uuid.New(), errInvalidReq
 		}).Times(retryCount)
 
+		mockRepo.EXPECT().CreateDailyConcentrationMetrics(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
+		time.Sleep(1000 * time.Millisecond)
+	})
+
+	t.Run("failed create daily concentration metrics", func(t *testing.T) {
+		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{}, nil).Times(1)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any(), gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{
+			{
+				ValidatorAddress: "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+				DelegatorAddress: "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500",
+				AmountUatom:      8000,
+			},
+		}, nil).Times(retryCount)
+
+		mockRepo.EXPECT().CreateDailyAggregate(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDailyAggregateParams{})).Return(uuid.New(), nil).Times(retryCount)
+
+		mockRepo.EXPECT().CreateDailyConcentrationMetrics(gomock.Any(), gomock.Any()).Return(int64(0), errInvalidReq).Times(retryCount)
+
 		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
 		time.Sleep(1000 * time.Millisecond)
 	})
+
+	t.Run("failed get validator commission still collects daily aggregates", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosValidatorAPIURL+"/"+validatorAddress).Return(&types.HTTPResponse{
+			StatusCode: 500,
+			Body:       `{}`,
+			Headers:    map[string][]string{},
+		}, nil).Times(fetchCount)
+		mockRepo.EXPECT().CreateValidatorCommission(gomock.Any(), gomock.Any()).Times(0)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any(), gomock.Any()).Return([]querier.GetLatestDelegationSnapshotRow{}, nil).Times(1)
+		mockRepo.EXPECT().CreateDailyConcentrationMetrics(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
+		time.Sleep(1000 * time.Millisecond)
+	})
+
+	t.Run("slow validator commission fetch still collects daily aggregates", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+		commissionFetchTimeout := config.CommissionFetchTimeout
+		config.CommissionFetchTimeout = 100 * time.Millisecond
+		defer func() {
+			config.CommissionFetchTimeout = commissionFetchTimeout
+		}()
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockHTTPClient.EXPECT().Get(gomock.Any(), config.CosmosValidatorAPIURL+"/"+validatorAddress).DoAndReturn(func(ctx context.Context, url string) (*types.HTTPResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).MinTimes(1)
+		mockRepo.EXPECT().CreateValidatorCommission(gomock.Any(), gomock.Any()).Times(0)
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg querier.GetLatestDelegationSnapshotParams) ([]querier.GetLatestDelegationSnapshotRow, error) {
+			assert.NoError(t, ctx.Err())
+			return []querier.GetLatestDelegationSnapshotRow{}, nil
+		}).Times(1)
+		mockRepo.EXPECT().CreateDailyConcentrationMetrics(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
+		time.Sleep(1000 * time.Millisecond)
+	})
+}
+
+func TestBuildDelegationSnapshots(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, config, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	schedulerImpl := validatorScheduler.(*ValidatorSchedulerImpl)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	timestamp := time.Now()
+
+	delegations := []delegationBalance{
+		{ValidatorAddress: validatorAddress, DelegatorAddress: "cosmos1unchanged", AmountUatom: 100},
+		{ValidatorAddress: validatorAddress, DelegatorAddress: "cosmos1changed", AmountUatom: 250},
+		{ValidatorAddress: validatorAddress, DelegatorAddress: "cosmos1new", AmountUatom: 50},
+	}
+	latestSnapshots := []querier.GetLatestDelegationSnapshotByValidatorRow{
+		{DelegatorAddress: "cosmos1unchanged", AmountUatom: 100},
+		{DelegatorAddress: "cosmos1changed", AmountUatom: 200},
+		{DelegatorAddress: "cosmos1gone", AmountUatom: 300},
+		{DelegatorAddress: "cosmos1closed", AmountUatom: 0},
+	}
+
+	t.Run("full storage writes every delegator", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), validatorAddress).Return(latestSnapshots, nil).Times(1)
+
+		snapshots, err := schedulerImpl.buildDelegationSnapshots(ctx, mockRepo, validatorAddress, delegations, timestamp, true)
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 3)
+		assert.Equal(t, int64(0), snapshots[0].ChangeUatom)
+		assert.Equal(t, int64(50), snapshots[1].ChangeUatom)
+		assert.Equal(t, int64(50), snapshots[2].ChangeUatom)
+	})
+
+	t.Run("cdc storage writes only changes and closes gone delegators", func(t *testing.T) {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		defer func() {
+			config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+		}()
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), validatorAddress).Return(latestSnapshots, nil).Times(1)
+
+		snapshots, err := schedulerImpl.buildDelegationSnapshots(ctx, mockRepo, validatorAddress, delegations, timestamp, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []querier.CreateDelegationSnapshotsParams{
+			{ValidatorAddress: validatorAddress, DelegatorAddress: "cosmos1changed", AmountUatom: 250, ChangeUatom: 50, Timestamp: timestamp},
+			{ValidatorAddress: validatorAddress, DelegatorAddress: "cosmos1new", AmountUatom: 50, ChangeUatom: 50, Timestamp: timestamp},
+			{ValidatorAddress: validatorAddress, DelegatorAddress: "cosmos1gone", AmountUatom: 0, ChangeUatom: -300, Timestamp: timestamp},
+		}, snapshots)
+	})
+
+	t.Run("cdc storage checkpoint writes every delegator", func(t *testing.T) {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		defer func() {
+			config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+		}()
+
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), validatorAddress).Return(latestSnapshots, nil).Times(1)
+
+		snapshots, err := schedulerImpl.buildDelegationSnapshots(ctx, mockRepo, validatorAddress, delegations, timestamp, true)
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 4)
+		assert.Equal(t, "cosmos1unchanged", snapshots[0].DelegatorAddress)
+		assert.Equal(t, "cosmos1gone", snapshots[3].DelegatorAddress)
+		assert.Equal(t, int64(0), snapshots[3].AmountUatom)
+	})
+
+	t.Run("failed get latest delegation snapshot by validator", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), validatorAddress).Return(nil, errInvalidReq).Times(1)
+
+		snapshots, err := schedulerImpl.buildDelegationSnapshots(ctx, mockRepo, validatorAddress, delegations, timestamp, true)
+		assert.Error(t, err)
+		assert.Empty(t, snapshots)
+	})
+}
+
+func TestIsCheckpointRun(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, config, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	schedulerImpl := validatorScheduler.(*ValidatorSchedulerImpl)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	timestamp := time.Now()
+	params := querier.GetLatestCheckpointSchedulerRunParams{
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+	}
+
+	t.Run("full storage is always a checkpoint", func(t *testing.T) {
+		isCheckpoint, err := schedulerImpl.isCheckpointRun(ctx, mockRepo, validatorAddress, timestamp)
+		assert.NoError(t, err)
+		assert.True(t, isCheckpoint)
+	})
+
+	config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+	defer func() {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+	}()
+
+	t.Run("cdc storage without previous checkpoint", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRun(gomock.Any(), params).Return(time.Time{}, pgx.ErrNoRows).Times(1)
+
+		isCheckpoint, err := schedulerImpl.isCheckpointRun(ctx, mockRepo, validatorAddress, timestamp)
+		assert.NoError(t, err)
+		assert.True(t, isCheckpoint)
+	})
+
+	t.Run("cdc storage with recent checkpoint", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRun(gomock.Any(), params).Return(timestamp.Add(-time.Hour), nil).Times(1)
+
+		isCheckpoint, err := schedulerImpl.isCheckpointRun(ctx, mockRepo, validatorAddress, timestamp)
+		assert.NoError(t, err)
+		assert.False(t, isCheckpoint)
+	})
+
+	t.Run("cdc storage with expired checkpoint", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRun(gomock.Any(), params).Return(timestamp.Add(-config.SnapshotCheckpointInterval), nil).Times(1)
+
+		isCheckpoint, err := schedulerImpl.isCheckpointRun(ctx, mockRepo, validatorAddress, timestamp)
+		assert.NoError(t, err)
+		assert.True(t, isCheckpoint)
+	})
+
+	t.Run("failed get latest checkpoint", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRun(gomock.Any(), params).Return(time.Time{}, errInvalidReq).Times(1)
+
+		_, err := schedulerImpl.isCheckpointRun(ctx, mockRepo, validatorAddress, timestamp)
+		assert.Error(t, err)
+	})
+}
+
+func BenchmarkBuildDelegationSnapshots(b *testing.B) {
+	const delegatorCount = 50000
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	ctx := context.Background()
+	timestamp := time.Now()
+
+	delegations := make([]delegationBalance, 0, delegatorCount)
+	latestSnapshots := make([]querier.GetLatestDelegationSnapshotByValidatorRow, 0, delegatorCount)
+	for i := 0; i < delegatorCount; i++ {
+		delegatorAddress := fmt.Sprintf("cosmos1delegator%d", i)
+		delegations = append(delegations, delegationBalance{
+			ValidatorAddress: validatorAddress,
+			DelegatorAddress: delegatorAddress,
+			AmountUatom:      int64(i + 100),
+		})
+		latestSnapshots = append(latestSnapshots, querier.GetLatestDelegationSnapshotByValidatorRow{
+			DelegatorAddress: delegatorAddress,
+			AmountUatom:      int64(i),
+		})
+	}
+
+	b.Run("per delegator lookup", func(b *testing.B) {
+		ctrl := gomock.NewController(b)
+		mockRepo := mockrepo.NewMockRepository(ctrl)
+		queryCount := 0
+		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndDelegator(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg querier.GetDelegationSnapshotByValidatorAndDelegatorParams) (querier.GetDelegationSnapshotByValidatorAndDelegatorRow, error) {
+			queryCount++
+			return querier.GetDelegationSnapshotByValidatorAndDelegatorRow{AmountUatom: 100}, nil
+		}).AnyTimes()
+
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			snapshots := make([]querier.CreateDelegationSnapshotsParams, 0, len(delegations))
+			for _, delegation := range delegations {
+				delegationSnapshot, err := mockRepo.GetDelegationSnapshotByValidatorAndDelegator(ctx, querier.GetDelegationSnapshotByValidatorAndDelegatorParams{
+					ValidatorAddress: delegation.ValidatorAddress,
+					DelegatorAddress: delegation.DelegatorAddress,
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				snapshots = append(snapshots, querier.CreateDelegationSnapshotsParams{
+					ValidatorAddress: delegation.ValidatorAddress,
+					DelegatorAddress: delegation.DelegatorAddress,
+					AmountUatom:      delegation.AmountUatom,
+					ChangeUatom:      delegation.AmountUatom - delegationSnapshot.AmountUatom,
+					Timestamp:        timestamp,
+				})
+			}
+		}
+		b.ReportMetric(float64(queryCount)/float64(b.N), "queries/op")
+	})
+
+	b.Run("bulk prefetch", func(b *testing.B) {
+		ctrl := gomock.NewController(b)
+		mockRepo := mockrepo.NewMockRepository(ctrl)
+		mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+		mockutl.LoggerMock(mockLogger)
+		queryCount := 0
+		mockRepo.EXPECT().GetLatestDelegationSnapshotByValidator(gomock.Any(), validatorAddress).DoAndReturn(func(ctx context.Context, validatorAddress string) ([]querier.GetLatestDelegationSnapshotByValidatorRow, error) {
+			queryCount++
+			return latestSnapshots, nil
+		}).AnyTimes()
+		validatorScheduler := &ValidatorSchedulerImpl{
+			repo:   mockRepo,
+			config: &utils.BaseConfig{SnapshotStorageMode: constant.SnapshotStorageModeFull},
+			logger: mockLogger,
+		}
+
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			snapshots, err := validatorScheduler.buildDelegationSnapshots(ctx, mockRepo, validatorAddress, delegations, timestamp, true)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(snapshots) != delegatorCount {
+				b.Fatalf("expected %d snapshots, got %d", delegatorCount, len(snapshots))
+			}
+		}
+		b.ReportMetric(float64(queryCount)/float64(b.N), "queries/op")
+	})
+}
+
+func TestSchedulerForRetentionValidatorData(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, _, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	checkpoint := time.Now().AddDate(0, 0, -100)
+
+	t.Run("success apply retention", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.AssignableToTypeOf(querier.GetLatestCheckpointSchedulerRunBeforeParams{})).Return(checkpoint, nil).Times(1)
+		mockRepo.EXPECT().CreateDownsampledDailyAggregates(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDownsampledDailyAggregatesParams{})).Return(int64(2), nil).Times(1)
+		mockRepo.EXPECT().DeleteDelegationSnapshotsBefore(gomock.Any(), querier.DeleteDelegationSnapshotsBeforeParams{
+			ValidatorAddress: validatorAddress,
+			Before:           checkpoint,
+		}).Return(int64(48), nil).Times(1)
+		mockRepo.EXPECT().DeleteSchedulerRunsBefore(gomock.Any(), querier.DeleteSchedulerRunsBeforeParams{
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           checkpoint,
+		}).Return(int64(24), nil).Times(1)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateSchedulerRunParams) (uuid.UUID, error) {
+			assert.Equal(t, constant.RetentionJobName, arg.JobName)
+			assert.Equal(t, validatorAddress, arg.ValidatorAddress)
+			assert.Equal(t, int64(48), arg.RowsAffected)
+			return uuid.New(), nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, false)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("success dry run retention", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.AssignableToTypeOf(querier.GetLatestCheckpointSchedulerRunBeforeParams{})).Return(checkpoint, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegationSnapshotBefore(gomock.Any(), querier.GetCountDelegationSnapshotBeforeParams{
+			ValidatorAddress: validatorAddress,
+			Before:           checkpoint,
+		}).Return(int64(48), nil).Times(1)
+		mockRepo.EXPECT().CreateDownsampledDailyAggregates(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().DeleteDelegationSnapshotsBefore(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().DeleteSchedulerRunsBefore(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateSchedulerRunParams) (uuid.UUID, error) {
+			assert.Equal(t, constant.RetentionDryRunJobName, arg.JobName)
+			assert.Equal(t, int64(48), arg.RowsAffected)
+			return uuid.New(), nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, true)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("success without checkpoint before cutoff", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.AssignableToTypeOf(querier.GetLatestCheckpointSchedulerRunBeforeParams{})).Return(time.Time{}, pgx.ErrNoRows).Times(1)
+		mockRepo.EXPECT().CreateDownsampledDailyAggregates(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().DeleteDelegationSnapshotsBefore(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateSchedulerRunParams) (uuid.UUID, error) {
+			assert.Equal(t, constant.RetentionJobName, arg.JobName)
+			assert.Equal(t, int64(0), arg.RowsAffected)
+			return uuid.New(), nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, false)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("error get validator addresses", func(t *testing.T) {
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetDB().Times(0)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, false)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("failed delete delegation snapshots", func(t *testing.T) {
+		retryCount := constant.RetryCount + 1
+		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.AssignableToTypeOf(querier.GetLatestCheckpointSchedulerRunBeforeParams{})).Return(checkpoint, nil).Times(retryCount)
+		mockRepo.EXPECT().CreateDownsampledDailyAggregates(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDownsampledDailyAggregatesParams{})).Return(int64(2), nil).Times(retryCount)
+		mockRepo.EXPECT().DeleteDelegationSnapshotsBefore(gomock.Any(), gomock.AssignableToTypeOf(querier.DeleteDelegationSnapshotsBeforeParams{})).Return(int64(0), errInvalidReq).Times(retryCount)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, false)
+		time.Sleep(1000 * time.Millisecond)
+	})
+
+	t.Run("failed validator does not stop the retention of the others", func(t *testing.T) {
+		retryCount := constant.RetryCount + 1
+		otherValidatorAddress := "cosmosvaloper1c4k24jzduc365kywrsvf5ujz4ya6mwympnc4en"
+		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress, otherValidatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.AssignableToTypeOf(querier.GetLatestCheckpointSchedulerRunBeforeParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetLatestCheckpointSchedulerRunBeforeParams) (time.Time, error) {
+			if arg.ValidatorAddress == validatorAddress {
+				return time.Time{}, errInvalidReq
+			}
+			return time.Time{}, pgx.ErrNoRows
+		}).Times(retryCount + 1)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateSchedulerRunParams) (uuid.UUID, error) {
+			assert.Equal(t, otherValidatorAddress, arg.ValidatorAddress)
+			return uuid.New(), nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, false)
+		time.Sleep(1000 * time.Millisecond)
+	})
+}
+
+func TestApplyRetention(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, config, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	schedulerImpl := validatorScheduler.(*ValidatorSchedulerImpl)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	timestamp := time.Now()
+	cutoff := timestamp.AddDate(0, 0, -90)
+	checkpoint := cutoff.Add(-6 * time.Hour)
+
+	t.Run("downsamples before cutoff and removes up to checkpoint", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), querier.GetLatestCheckpointSchedulerRunBeforeParams{
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           cutoff,
+		}).Return(checkpoint, nil).Times(1)
+		mockRepo.EXPECT().CreateDownsampledDailyAggregates(gomock.Any(), querier.CreateDownsampledDailyAggregatesParams{
+			DayTimezone:      config.ReportingTimezone,
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           cutoff,
+		}).Return(int64(3), nil).Times(1)
+		mockRepo.EXPECT().DeleteDelegationSnapshotsBefore(gomock.Any(), querier.DeleteDelegationSnapshotsBeforeParams{
+			ValidatorAddress: validatorAddress,
+			Before:           checkpoint,
+		}).Return(int64(10), nil).Times(1)
+		mockRepo.EXPECT().DeleteSchedulerRunsBefore(gomock.Any(), querier.DeleteSchedulerRunsBeforeParams{
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           checkpoint,
+		}).Return(int64(5), nil).Times(1)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), querier.CreateSchedulerRunParams{
+			JobName:          constant.RetentionJobName,
+			ValidatorAddress: validatorAddress,
+			Timestamp:        timestamp,
+			RowsAffected:     10,
+		}).Return(uuid.New(), nil).Times(1)
+
+		rowsAffected, err := schedulerImpl.applyRetention(ctx, mockRepo, validatorAddress, cutoff, timestamp, false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), rowsAffected)
+	})
+
+	t.Run("failed get latest checkpoint", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.Any()).Return(time.Time{}, errInvalidReq).Times(1)
+
+		rowsAffected, err := schedulerImpl.applyRetention(ctx, mockRepo, validatorAddress, cutoff, timestamp, true)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), rowsAffected)
+	})
+
+	t.Run("failed create scheduler run", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestCheckpointSchedulerRunBefore(gomock.Any(), gomock.Any()).Return(checkpoint, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegationSnapshotBefore(gomock.Any(), gomock.Any()).Return(int64(10), nil).Times(1)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(uuid.Nil, errInvalidReq).Times(1)
+
+		rowsAffected, err := schedulerImpl.applyRetention(ctx, mockRepo, validatorAddress, cutoff, timestamp, true)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), rowsAffected)
+	})
+}
+
+func TestSchedulerForParquetExportValidatorData(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, config, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success export validator data", func(t *testing.T) {
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.AssignableToTypeOf(querier.GetUnexportedSnapshotDatesParams{})).Return([]time.Time{date}, nil).Times(1)
+		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndTimeRange(gomock.Any(), gomock.Any()).Return([]querier.GetDelegationSnapshotByValidatorAndTimeRangeRow{}, nil).Times(1)
+		mockRepo.EXPECT().GetUnexportedDailyAggregateDates(gomock.Any(), gomock.AssignableToTypeOf(querier.GetUnexportedDailyAggregateDatesParams{})).Return([]time.Time{}, nil).Times(1)
+		mockRepo.EXPECT().CreateExportManifest(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateExportManifestParams{})).Return(uuid.New(), nil).Times(1)
+
+		validatorScheduler.SchedulerForParquetExportValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+
+		_, err := os.Stat(filepath.Join(config.ExportLocalDir, "delegation_snapshots", "validator="+validatorAddress, "date=2025-04-01", "part-0.parquet"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("error get validator addresses", func(t *testing.T) {
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForParquetExportValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("failed validator does not stop the export of the others", func(t *testing.T) {
+		otherValidatorAddress := "cosmosvaloper1c4k24jzduc365kywrsvf5ujz4ya6mwympnc4en"
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress, otherValidatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.AssignableToTypeOf(querier.GetUnexportedSnapshotDatesParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetUnexportedSnapshotDatesParams) ([]time.Time, error) {
+			if arg.ValidatorAddress == validatorAddress {
+				return nil, errInvalidReq
+			}
+			return []time.Time{}, nil
+		}).Times(2)
+		mockRepo.EXPECT().GetUnexportedDailyAggregateDates(gomock.Any(), gomock.AssignableToTypeOf(querier.GetUnexportedDailyAggregateDatesParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetUnexportedDailyAggregateDatesParams) ([]time.Time, error) {
+			assert.Equal(t, otherValidatorAddress, arg.ValidatorAddress)
+			return []time.Time{}, nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForParquetExportValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+}
+
+func TestExportValidatorData(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, config, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	schedulerImpl := validatorScheduler.(*ValidatorSchedulerImpl)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	delegatorAddress := "cosmos1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	timestamp := time.Date(2025, 4, 3, 12, 0, 0, 0, time.UTC)
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	snapshotKey := "delegation_snapshots/validator=" + validatorAddress + "/date=2025-04-01/part-0.parquet"
+	aggregateKey := "daily_aggregates/validator=" + validatorAddress + "/date=2025-04-01/part-0.parquet"
+
+	expectExport := func() {
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), querier.GetUnexportedSnapshotDatesParams{
+			DayTimezone:      "Asia/Jakarta",
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           time.Date(2025, 4, 3, 0, 0, 0, 0, loc),
+			Dataset:          constant.ExportDatasetDelegationSnapshots,
+		}).Return([]time.Time{date}, nil).Times(1)
+		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndTimeRange(gomock.Any(), querier.GetDelegationSnapshotByValidatorAndTimeRangeParams{
+			ValidatorAddress: validatorAddress,
+			StartTime:        time.Date(2025, 4, 1, 0, 0, 0, 0, loc),
+			EndTime:          time.Date(2025, 4, 2, 0, 0, 0, 0, loc),
+		}).Return([]querier.GetDelegationSnapshotByValidatorAndTimeRangeRow{
+			{
+				ValidatorAddress: validatorAddress,
+				DelegatorAddress: delegatorAddress,
+				AmountUatom:      8000,
+				ChangeUatom:      1000,
+				Timestamp:        time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC),
+			},
+		}, nil).Times(1)
+		mockRepo.EXPECT().GetUnexportedDailyAggregateDates(gomock.Any(), querier.GetUnexportedDailyAggregateDatesParams{
+			ValidatorAddress: validatorAddress,
+			Before:           time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC),
+			Dataset:          constant.ExportDatasetDailyAggregates,
+		}).Return([]time.Time{date}, nil).Times(1)
+		mockRepo.EXPECT().GetDailyAggregateByValidatorAndDate(gomock.Any(), querier.GetDailyAggregateByValidatorAndDateParams{
+			ValidatorAddress: validatorAddress,
+			Date:             date,
+		}).Return([]querier.GetDailyAggregateByValidatorAndDateRow{
+			{
+				ValidatorAddress: validatorAddress,
+				DelegatorAddress: delegatorAddress,
+				Date:             date,
+				TotalAmount:      8000,
+			},
+		}, nil).Times(1)
+		mockRepo.EXPECT().CreateExportManifest(gomock.Any(), querier.CreateExportManifestParams{
+			Dataset:          constant.ExportDatasetDelegationSnapshots,
+			ValidatorAddress: validatorAddress,
+			Date:             date,
+			ObjectKey:        snapshotKey,
+			RowsExported:     1,
+		}).Return(uuid.New(), nil).Times(1)
+		mockRepo.EXPECT().CreateExportManifest(gomock.Any(), querier.CreateExportManifestParams{
+			Dataset:          constant.ExportDatasetDailyAggregates,
+			ValidatorAddress: validatorAddress,
+			Date:             date,
+			ObjectKey:        aggregateKey,
+			RowsExported:     1,
+		}).Return(uuid.New(), nil).Times(1)
+	}
+
+	t.Run("success export to local directory", func(t *testing.T) {
+		expectExport()
+
+		files, err := schedulerImpl.exportValidatorData(ctx, validatorAddress, timestamp, loc)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, files)
+
+		snapshots, err := parquet.ReadFile[message.DelegationSnapshotRecord](filepath.Join(config.ExportLocalDir, filepath.FromSlash(snapshotKey)))
+		assert.NoError(t, err)
+		assert.Equal(t, []message.DelegationSnapshotRecord{
+			{
+				ValidatorAddress: validatorAddress,
+				DelegatorAddress: delegatorAddress,
+				AmountUatom:      8000,
+				ChangeUatom:      1000,
+				Timestamp:        time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC),
+			},
+		}, snapshots)
+
+		aggregates, err := parquet.ReadFile[message.DailyAggregateRecord](filepath.Join(config.ExportLocalDir, filepath.FromSlash(aggregateKey)))
+		assert.NoError(t, err)
+		assert.Equal(t, []message.DailyAggregateRecord{
+			{
+				ValidatorAddress: validatorAddress,
+				DelegatorAddress: delegatorAddress,
+				Date:             20179,
+				TotalAmount:      8000,
+			},
+		}, aggregates)
+	})
+
+	t.Run("success export to s3 bucket", func(t *testing.T) {
+		backend := s3mem.New()
+		server := httptest.NewServer(gofakes3.New(backend).Server())
+		defer server.Close()
+		assert.NoError(t, backend.CreateBucket(config.ExportS3Bucket))
+
+		s3Config := *config
+		s3Config.ExportStorage = constant.ExportStorageS3
+		s3Config.ExportS3Endpoint = strings.TrimPrefix(server.URL, "http://")
+		s3Config.ExportS3AccessKey = "access-key"
+		s3Config.ExportS3SecretKey = "secret-key"
+		schedulerImpl.storage = utils.NewObjectStorage(&s3Config)
+		defer func() {
+			schedulerImpl.storage = utils.NewObjectStorage(config)
+		}()
+
+		expectExport()
+
+		files, err := schedulerImpl.exportValidatorData(ctx, validatorAddress, timestamp, loc)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, files)
+
+		object, err := backend.GetObject(config.ExportS3Bucket, snapshotKey, nil)
+		assert.NoError(t, err)
+		defer object.Contents.Close()
+		body, err := io.ReadAll(object.Contents)
+		assert.NoError(t, err)
+
+		snapshots, err := parquet.Read[message.DelegationSnapshotRecord](bytes.NewReader(body), int64(len(body)))
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 1)
+
+		_, err = backend.GetObject(config.ExportS3Bucket, aggregateKey, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips days already in manifest", func(t *testing.T) {
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.Any()).Return([]time.Time{}, nil).Times(1)
+		mockRepo.EXPECT().GetUnexportedDailyAggregateDates(gomock.Any(), gomock.Any()).Return([]time.Time{}, nil).Times(1)
+		mockRepo.EXPECT().CreateExportManifest(gomock.Any(), gomock.Any()).Times(0)
+
+		files, err := schedulerImpl.exportValidatorData(ctx, validatorAddress, timestamp, loc)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, files)
+	})
+
+	t.Run("failed put object is not recorded in manifest", func(t *testing.T) {
+		mockStorage := mockutl.NewMockObjectStorage(ctrl)
+		schedulerImpl.storage = mockStorage
+		defer func() {
+			schedulerImpl.storage = utils.NewObjectStorage(config)
+		}()
+
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.Any()).Return([]time.Time{date}, nil).Times(1)
+		mockRepo.EXPECT().GetDelegationSnapshotByValidatorAndTimeRange(gomock.Any(), gomock.Any()).Return([]querier.GetDelegationSnapshotByValidatorAndTimeRangeRow{}, nil).Times(1)
+		mockStorage.EXPECT().Put(gomock.Any(), snapshotKey, gomock.Any(), gomock.Any(), gomock.Any()).Return(errInvalidReq).Times(1)
+		mockRepo.EXPECT().CreateExportManifest(gomock.Any(), gomock.Any()).Times(0)
+
+		files, err := schedulerImpl.exportValidatorData(ctx, validatorAddress, timestamp, loc)
+		assert.Error(t, err)
+		assert.Equal(t, 0, files)
+	})
+
+	t.Run("failed get unexported snapshot dates", func(t *testing.T) {
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetUnexportedDailyAggregateDates(gomock.Any(), gomock.Any()).Times(0)
+
+		files, err := schedulerImpl.exportValidatorData(ctx, validatorAddress, timestamp, loc)
+		assert.Error(t, err)
+		assert.Equal(t, 0, files)
+	})
+}
+
+func TestSchedulerForDelegatorEventsValidatorData(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, _, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success collect delegator events", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), gomock.AssignableToTypeOf(querier.GetUnprocessedDelegatorEventDatesParams{})).Return([]time.Time{date}, nil).Times(1)
+		mockRepo.EXPECT().CreateDelegatorEvents(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDelegatorEventsParams{})).Return(int64(2), nil).Times(1)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).Return(uuid.New(), nil).Times(1)
+
+		validatorScheduler.SchedulerForDelegatorEventsValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("error get validator addresses", func(t *testing.T) {
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForDelegatorEventsValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("failed validator does not stop the delegator events of the others", func(t *testing.T) {
+		otherValidatorAddress := "cosmosvaloper1c4k24jzduc365kywrsvf5ujz4ya6mwympnc4en"
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress, otherValidatorAddress}, nil).Times(1)
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), gomock.AssignableToTypeOf(querier.GetUnprocessedDelegatorEventDatesParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetUnprocessedDelegatorEventDatesParams) ([]time.Time, error) {
+			if arg.ValidatorAddress == validatorAddress {
+				return nil, errInvalidReq
+			}
+			return []time.Time{date}, nil
+		}).Times(2)
+		mockRepo.EXPECT().CreateDelegatorEvents(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateDelegatorEventsParams{})).DoAndReturn(func(ctx context.Context, arg querier.CreateDelegatorEventsParams) (int64, error) {
+			assert.Equal(t, otherValidatorAddress, arg.ValidatorAddress)
+			return int64(2), nil
+		}).Times(1)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.AssignableToTypeOf(querier.CreateSchedulerRunParams{})).Return(uuid.New(), nil).Times(1)
+
+		validatorScheduler.SchedulerForDelegatorEventsValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+}
+
+func TestCollectDelegatorEvents(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorScheduler, mockRepo, _, mockLogger, _ := initValidatorScheduler(t, ctrl)
+	mockutl.LoggerMock(mockLogger)
+	schedulerImpl := validatorScheduler.(*ValidatorSchedulerImpl)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	timestamp := time.Date(2025, 4, 3, 12, 0, 0, 0, time.UTC)
+	dates := []time.Time{
+		time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+	}
+	retryCount := constant.RetryCount + 1
+
+	t.Run("collects every unprocessed day in order", func(t *testing.T) {
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+		mockrepo.SetupMockTxPool(ctrl, mockRepo)
+
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), querier.GetUnprocessedDelegatorEventDatesParams{
+			DayTimezone:      "Asia/Jakarta",
+			ValidatorAddress: validatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Before:           time.Date(2025, 4, 3, 0, 0, 0, 0, loc),
+			EventJobName:     constant.DelegatorEventsJobName,
+		}).Return(dates, nil).Times(1)
+
+		for i, date := range dates {
+			startTime := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+			mockRepo.EXPECT().CreateDelegatorEvents(gomock.Any(), querier.CreateDelegatorEventsParams{
+				ValidatorAddress: validatorAddress,
+				Date:             date,
+				JobName:          constant.HourlyCollectJobName,
+				StartTime:        startTime,
+				EndTime:          startTime.AddDate(0, 0, 1),
+				LatestRunOnly:    true,
+			}).Return(int64(i+1), nil).Times(1)
+			mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), querier.CreateSchedulerRunParams{
+				JobName:          constant.DelegatorEventsJobName,
+				ValidatorAddress: validatorAddress,
+				Timestamp:        startTime,
+				RowsAffected:     int64(i + 1),
+			}).Return(uuid.New(), nil).Times(1)
+		}
+
+		events, err := schedulerImpl.collectDelegatorEvents(ctx, validatorAddress, timestamp, loc)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), events)
+	})
+
+	t.Run("failed get unprocessed delegator event dates", func(t *testing.T) {
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().CreateDelegatorEvents(gomock.Any(), gomock.Any()).Times(0)
+
+		events, err := schedulerImpl.collectDelegatorEvents(ctx, validatorAddress, timestamp, loc)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), events)
+	})
+
+	t.Run("failed create delegator events", func(t *testing.T) {
+		mockrepo.SetupMockTxPoolWithRetry(ctrl, mockRepo, retryCount, true)
+
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), gomock.Any()).Return(dates, nil).Times(1)
+		mockRepo.EXPECT().CreateDelegatorEvents(gomock.Any(), gomock.Any()).Return(int64(0), errInvalidReq).Times(retryCount)
+		mockRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Times(0)
+
+		events, err := schedulerImpl.collectDelegatorEvents(ctx, validatorAddress, timestamp, loc)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), events)
+	})
+}
+
+func TestSchedulerJobAlreadyRunning(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	config := utils.CheckAndSetConfig("../config", "test")
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockLock := mockutl.NewMockLockSvc(ctrl)
+	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
+	validatorScheduler := NewValidatorScheduler(mockRepo, config, mockLogger, mockutl.NewMockHTTPClient(ctrl), cacheSvc, utils.NewObjectStorage(config), mockLock, utils.NewJobManager(mockLogger))
+	mockutl.LoggerMock(mockLogger)
+	validatorAddress := "cosmosvaloper1360qkbsgysnhjeddlwqaqwgj84vq4z8a4g0500"
+
+	t.Run("skip daily collect already running", func(t *testing.T) {
+		mockLock.EXPECT().TryLock(gomock.Any(), "job_lock:daily_collect").Return(nil, false, nil).Times(1)
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().GetDB().Times(0)
+
+		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("skip retention of a validator already running", func(t *testing.T) {
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockLock.EXPECT().TryLock(gomock.Any(), fmt.Sprintf("job_lock:retention:%s", validatorAddress)).Return(nil, false, nil).Times(1)
+		mockRepo.EXPECT().GetDB().Times(0)
+
+		validatorScheduler.SchedulerForRetentionValidatorData(ctx, false)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("skip delegator events when the lock cannot be taken", func(t *testing.T) {
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockLock.EXPECT().TryLock(gomock.Any(), fmt.Sprintf("job_lock:delegator_events:%s", validatorAddress)).Return(nil, false, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetUnprocessedDelegatorEventDates(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForDelegatorEventsValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("release the lock after exporting a validator", func(t *testing.T) {
+		released := make(chan struct{})
+		config.ExportLocalDir = t.TempDir()
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return([]string{validatorAddress}, nil).Times(1)
+		mockLock.EXPECT().TryLock(gomock.Any(), fmt.Sprintf("job_lock:parquet_export:%s", validatorAddress)).Return(func() { close(released) }, true, nil).Times(1)
+		mockRepo.EXPECT().GetUnexportedSnapshotDates(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+
+		validatorScheduler.SchedulerForParquetExportValidatorData(ctx)
+
+		select {
+		case <-released:
+		case <-time.After(time.Second):
+			t.Fatal("lock was not released")
+		}
+	})
+}
+
+func TestSchedulerJobShutdown(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	config := utils.CheckAndSetConfig("../config", "test")
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockLock := mockutl.NewMockLockSvc(ctrl)
+	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
+	mockutl.LoggerMock(mockLogger)
+
+	initScheduler := func() (ValidatorScheduler, utils.JobManager) {
+		jobs := utils.NewJobManager(mockLogger)
+		return NewValidatorScheduler(mockRepo, config, mockLogger, mockutl.NewMockHTTPClient(ctrl), cacheSvc, utils.NewObjectStorage(config), mockLock, jobs), jobs
+	}
+
+	t.Run("wait for a running job to finish", func(t *testing.T) {
+		validatorScheduler, jobs := initScheduler()
+		finished := false
+		mockLock.EXPECT().TryLock(gomock.Any(), "job_lock:daily_collect").DoAndReturn(func(ctx context.Context, key string) (func(), bool, error) {
+			time.Sleep(50 * time.Millisecond)
+			finished = true
+			return nil, false, nil
+		}).Times(1)
+
+		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		assert.NoError(t, jobs.Shutdown(shutdownCtx))
+		assert.True(t, finished)
+	})
+
+	t.Run("cancel a running job after the deadline", func(t *testing.T) {
+		validatorScheduler, jobs := initScheduler()
+		mockLock.EXPECT().TryLock(gomock.Any(), "job_lock:daily_collect").DoAndReturn(func(ctx context.Context, key string) (func(), bool, error) {
+			<-ctx.Done()
+			return nil, false, ctx.Err()
+		}).Times(1)
+
+		validatorScheduler.SchedulerForDailyCollectValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, jobs.Shutdown(shutdownCtx), context.DeadlineExceeded)
+	})
+
+	t.Run("skip a job triggered after shutdown", func(t *testing.T) {
+		validatorScheduler, jobs := initScheduler()
+		assert.NoError(t, jobs.Shutdown(ctx))
+		mockRepo.EXPECT().GetValidatorAddressesBySchedulerRun(gomock.Any(), gomock.Any()).Times(0)
+
+		validatorScheduler.SchedulerForDelegatorEventsValidatorData(ctx)
+		time.Sleep(10 * time.Millisecond)
+	})
 }
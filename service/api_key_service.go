@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+type APIKeySvc interface {
+	utils.APIKeyVerifier
+
+	CreateAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.CreateAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context) ([]dto.GetAPIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+}
+
+type apiKeySvc struct {
+	repo   querier.Repository
+	config *utils.BaseConfig
+	logger utils.LoggerSvc
+}
+
+func NewAPIKeySvc(repo querier.Repository, config *utils.BaseConfig, logger utils.LoggerSvc) APIKeySvc {
+	return &apiKeySvc{
+		repo:   repo,
+		config: config,
+		logger: logger,
+	}
+}
+
+var (
+	errInvalidAPIKey  = errors.New("invalid api key")
+	errAPIKeyNotFound = errors.New("api key not found")
+)
+
+// apiKeyLastUsedInterval is how stale last_used_at may get before a request writes it again
+const apiKeyLastUsedInterval = time.Minute
+
+func (a *apiKeySvc) CreateAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.CreateAPIKeyResponse, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return dto.CreateAPIKeyResponse{}, utils.WrapAppError(err, constant.ErrorCodeInternal, "failed to generate api key", http.StatusInternalServerError)
+	}
+
+	apiKey, err := a.repo.CreateAPIKey(ctx, querier.CreateAPIKeyParams{
+		Name:      req.Name,
+		KeyPrefix: key[:constant.APIKeyDisplayLength],
+		KeyHash:   hashAPIKey(key),
+		Scopes:    lo.Uniq(req.Scopes),
+	})
+	if err != nil {
+		return dto.CreateAPIKeyResponse{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to create api key", http.StatusUnprocessableEntity)
+	}
+
+	return dto.CreateAPIKeyResponse{
+		ID:        apiKey.ID.String(),
+		Name:      apiKey.Name,
+		Key:       key,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt.Format(constant.TimeFormat),
+	}, nil
+}
+
+func (a *apiKeySvc) GetAPIKeys(ctx context.Context) ([]dto.GetAPIKeyResponse, error) {
+	apiKeys, err := a.repo.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get api keys", http.StatusUnprocessableEntity)
+	}
+
+	return lo.Map(apiKeys, func(item querier.GetAPIKeysRow, _ int) dto.GetAPIKeyResponse {
+		return dto.GetAPIKeyResponse{
+			ID:         item.ID.String(),
+			Name:       item.Name,
+			KeyPrefix:  item.KeyPrefix,
+			Scopes:     item.Scopes,
+			LastUsedAt: formatNullTime(item.LastUsedAt),
+			RevokedAt:  formatNullTime(item.RevokedAt),
+			CreatedAt:  item.CreatedAt.Format(constant.TimeFormat),
+		}
+	}), nil
+}
+
+func (a *apiKeySvc) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	rows, err := a.repo.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to revoke api key", http.StatusUnprocessableEntity)
+	}
+
+	if rows == 0 {
+		return utils.WrapAppError(errAPIKeyNotFound, constant.ErrorCodeNotFound, "api key not found", http.StatusNotFound)
+	}
+
+	return nil
+}
+
+// VerifyAPIKey accepts the configured admin key, which bootstraps the first stored keys, or any stored key that is not revoked.
+func (a *apiKeySvc) VerifyAPIKey(ctx context.Context, key string) (utils.APIKeyIdentity, error) {
+	if a.config.AuthAdminKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(a.config.AuthAdminKey)) == 1 {
+		return utils.APIKeyIdentity{ID: constant.APIKeyAdminID, Scopes: []string{constant.APIKeyScopeAdmin}}, nil
+	}
+
+	apiKey, err := a.repo.GetActiveAPIKeyByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.APIKeyIdentity{}, utils.WrapAppError(errInvalidAPIKey, constant.ErrorCodeUnauthorized, "unauthorized", http.StatusUnauthorized)
+		}
+
+		return utils.APIKeyIdentity{}, utils.WrapAppError(err, constant.ErrorCodeInternal, "failed to verify api key", http.StatusInternalServerError)
+	}
+
+	// last_used_at only needs minute precision, so a busy key does not write a row on every request
+	if !apiKey.LastUsedAt.Valid || time.Since(apiKey.LastUsedAt.Time) >= apiKeyLastUsedInterval {
+		if err := a.repo.UpdateAPIKeyLastUsed(ctx, apiKey.ID); err != nil {
+			a.logger.WithContext(ctx).Error("Error updating api key last used", zap.Error(err))
+		}
+	}
+
+	return utils.APIKeyIdentity{ID: apiKey.ID.String(), Scopes: apiKey.Scopes}, nil
+}
+
+func generateAPIKey() (string, error) {
+	b := make([]byte, constant.APIKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return constant.APIKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// Keys are random enough that a plain SHA-256 is as good as a slow password hash and keeps lookups by hash possible.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func formatNullTime(t sql.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+
+	return lo.ToPtr(t.Time.Format(constant.TimeFormat))
+}
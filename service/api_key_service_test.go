@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
+	mockrepo "github.com/gadhittana01/cosmos-validation-tracking/db/repository/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func initAPIKeySvc(
+	ctrl *gomock.Controller,
+	config *utils.BaseConfig,
+) (APIKeySvc, *mockrepo.MockRepository, *mockutl.MockLoggerSvc) {
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+
+	return NewAPIKeySvc(mockRepo, config, mockLogger), mockRepo, mockLogger
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	apiKeySvcMock, mockRepo, mockLogger := initAPIKeySvc(ctrl, config)
+	request := dto.CreateAPIKeyRequest{
+		Name:   "collector",
+		Scopes: []string{constant.APIKeyScopeTriggerJobs, constant.APIKeyScopeTriggerJobs},
+	}
+	id := uuid.New()
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success create api key", func(t *testing.T) {
+		var params querier.CreateAPIKeyParams
+		mockRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg querier.CreateAPIKeyParams) (querier.CreateAPIKeyRow, error) {
+			params = arg
+			return querier.CreateAPIKeyRow{
+				ID:        id,
+				Name:      arg.Name,
+				KeyPrefix: arg.KeyPrefix,
+				Scopes:    arg.Scopes,
+				CreatedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			}, nil
+		}).Times(1)
+
+		resp, err := apiKeySvcMock.CreateAPIKey(ctx, request)
+		assert.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(resp.Key, constant.APIKeyPrefix))
+		assert.Equal(t, resp.Key[:constant.APIKeyDisplayLength], params.KeyPrefix)
+		assert.Equal(t, hashAPIKey(resp.Key), params.KeyHash)
+		assert.NotContains(t, params.KeyHash, resp.Key)
+		assert.Equal(t, []string{constant.APIKeyScopeTriggerJobs}, resp.Scopes)
+		assert.Equal(t, id.String(), resp.ID)
+		assert.Equal(t, "2025-04-01T00:00:00Z", resp.CreatedAt)
+	})
+
+	t.Run("failed create api key", func(t *testing.T) {
+		mockRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return(querier.CreateAPIKeyRow{}, errInvalidReq).Times(1)
+
+		_, err := apiKeySvcMock.CreateAPIKey(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to create api key: invalid request")
+	})
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	apiKeySvcMock, mockRepo, mockLogger := initAPIKeySvc(ctrl, config)
+	id := uuid.New()
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get api keys", func(t *testing.T) {
+		mockRepo.EXPECT().GetAPIKeys(gomock.Any()).Return([]querier.GetAPIKeysRow{
+			{
+				ID:        id,
+				Name:      "dashboard",
+				KeyPrefix: "vtk_0123abcd",
+				Scopes:    []string{constant.APIKeyScopeRead},
+				RevokedAt: sql.NullTime{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+				CreatedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}, nil).Times(1)
+
+		resp, err := apiKeySvcMock.GetAPIKeys(ctx)
+		assert.NoError(t, err)
+
+		revokedAt := "2025-04-02T00:00:00Z"
+		assert.Equal(t, []dto.GetAPIKeyResponse{
+			{
+				ID:        id.String(),
+				Name:      "dashboard",
+				KeyPrefix: "vtk_0123abcd",
+				Scopes:    []string{constant.APIKeyScopeRead},
+				RevokedAt: &revokedAt,
+				CreatedAt: "2025-04-01T00:00:00Z",
+			},
+		}, resp)
+	})
+
+	t.Run("failed get api keys", func(t *testing.T) {
+		mockRepo.EXPECT().GetAPIKeys(gomock.Any()).Return(nil, errInvalidReq).Times(1)
+
+		_, err := apiKeySvcMock.GetAPIKeys(ctx)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get api keys: invalid request")
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	apiKeySvcMock, mockRepo, mockLogger := initAPIKeySvc(ctrl, config)
+	id := uuid.New()
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success revoke api key", func(t *testing.T) {
+		mockRepo.EXPECT().RevokeAPIKey(gomock.Any(), id).Return(int64(1), nil).Times(1)
+
+		err := apiKeySvcMock.RevokeAPIKey(ctx, id)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("api key not found", func(t *testing.T) {
+		mockRepo.EXPECT().RevokeAPIKey(gomock.Any(), id).Return(int64(0), nil).Times(1)
+
+		err := apiKeySvcMock.RevokeAPIKey(ctx, id)
+
+		assertAppError(t, err, http.StatusNotFound, constant.ErrorCodeNotFound, "api key not found: api key not found")
+	})
+
+	t.Run("failed revoke api key", func(t *testing.T) {
+		mockRepo.EXPECT().RevokeAPIKey(gomock.Any(), id).Return(int64(0), errInvalidReq).Times(1)
+
+		err := apiKeySvcMock.RevokeAPIKey(ctx, id)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to revoke api key: invalid request")
+	})
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	config.AuthAdminKey = "admin-key"
+	apiKeySvcMock, mockRepo, mockLogger := initAPIKeySvc(ctrl, config)
+	key := "vtk_0123abcd"
+	id := uuid.New()
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success verify admin key", func(t *testing.T) {
+		identity, err := apiKeySvcMock.VerifyAPIKey(ctx, "admin-key")
+
+		assert.NoError(t, err)
+		assert.Equal(t, utils.APIKeyIdentity{ID: constant.APIKeyAdminID, Scopes: []string{constant.APIKeyScopeAdmin}}, identity)
+	})
+
+	t.Run("success verify api key", func(t *testing.T) {
+		mockRepo.EXPECT().GetActiveAPIKeyByHash(gomock.Any(), hashAPIKey(key)).Return(querier.GetActiveAPIKeyByHashRow{
+			ID:     id,
+			Scopes: []string{constant.APIKeyScopeRead},
+		}, nil).Times(1)
+		mockRepo.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), id).Return(errInvalidReq).Times(1)
+
+		identity, err := apiKeySvcMock.VerifyAPIKey(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, utils.APIKeyIdentity{ID: id.String(), Scopes: []string{constant.APIKeyScopeRead}}, identity)
+	})
+
+	t.Run("success verify api key used within the last minute", func(t *testing.T) {
+		mockRepo.EXPECT().GetActiveAPIKeyByHash(gomock.Any(), hashAPIKey(key)).Return(querier.GetActiveAPIKeyByHashRow{
+			ID:         id,
+			Scopes:     []string{constant.APIKeyScopeRead},
+			LastUsedAt: sql.NullTime{Time: time.Now().Add(-10 * time.Second), Valid: true},
+		}, nil).Times(1)
+		mockRepo.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(0)
+
+		identity, err := apiKeySvcMock.VerifyAPIKey(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, utils.APIKeyIdentity{ID: id.String(), Scopes: []string{constant.APIKeyScopeRead}}, identity)
+	})
+
+	t.Run("success verify api key last used more than a minute ago", func(t *testing.T) {
+		mockRepo.EXPECT().GetActiveAPIKeyByHash(gomock.Any(), hashAPIKey(key)).Return(querier.GetActiveAPIKeyByHashRow{
+			ID:         id,
+			Scopes:     []string{constant.APIKeyScopeRead},
+			LastUsedAt: sql.NullTime{Time: time.Now().Add(-2 * time.Minute), Valid: true},
+		}, nil).Times(1)
+		mockRepo.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), id).Return(nil).Times(1)
+
+		_, err := apiKeySvcMock.VerifyAPIKey(ctx, key)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown api key", func(t *testing.T) {
+		mockRepo.EXPECT().GetActiveAPIKeyByHash(gomock.Any(), gomock.Any()).Return(querier.GetActiveAPIKeyByHashRow{}, pgx.ErrNoRows).Times(1)
+
+		identity, err := apiKeySvcMock.VerifyAPIKey(ctx, key)
+
+		assert.Empty(t, identity)
+		assertAppError(t, err, http.StatusUnauthorized, constant.ErrorCodeUnauthorized, "unauthorized: invalid api key")
+	})
+
+	t.Run("failed verify api key", func(t *testing.T) {
+		mockRepo.EXPECT().GetActiveAPIKeyByHash(gomock.Any(), gomock.Any()).Return(querier.GetActiveAPIKeyByHashRow{}, errInvalidReq).Times(1)
+
+		_, err := apiKeySvcMock.VerifyAPIKey(ctx, key)
+
+		assertAppError(t, err, http.StatusInternalServerError, constant.ErrorCodeInternal, "failed to verify api key: invalid request")
+	})
+}
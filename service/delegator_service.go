@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+)
+
+type (
+	PaginationDelegatorChangeHistoryResp = dto.PaginationResp[dto.GetDelegatorChangeResponse]
+)
+
+type DelegatorSvc interface {
+	GetDelegatorSummary(ctx context.Context, req dto.GetDelegatorSummaryRequest) (dto.GetDelegatorSummaryResponse, error)
+	GetDelegatorChangeHistory(ctx context.Context, req dto.GetDelegatorChangeHistoryRequest) (PaginationDelegatorChangeHistoryResp, error)
+}
+
+type delegatorSvc struct {
+	repo     querier.Repository
+	config   *utils.BaseConfig
+	logger   utils.LoggerSvc
+	cacheSvc utils.CacheSvc
+}
+
+func NewDelegatorSvc(repo querier.Repository, config *utils.BaseConfig, logger utils.LoggerSvc, cacheSvc utils.CacheSvc) DelegatorSvc {
+	return &delegatorSvc{
+		repo:     repo,
+		config:   config,
+		logger:   logger,
+		cacheSvc: cacheSvc,
+	}
+}
+
+var (
+	errDelegatorNotFound = errors.New("delegator not found")
+	errInvalidDateRange  = errors.New("from is after to")
+)
+
+func (d *delegatorSvc) GetDelegatorSummary(ctx context.Context, req dto.GetDelegatorSummaryRequest) (resp dto.GetDelegatorSummaryResponse, err error) {
+	defer logError(ctx, d.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, d.cacheSvc, utils.BuildCacheKey(constant.DelegatorSummaryCacheKey, "", "", req), func(ctx context.Context) (dto.GetDelegatorSummaryResponse, error) {
+		loc, err := d.getLocation(req.Timezone)
+		if err != nil {
+			return dto.GetDelegatorSummaryResponse{}, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var delegations []querier.GetCurrentDelegationByDelegatorRow
+		var history []querier.GetDelegatorChangeHistoryRow
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			// In full storage a delegator who left is simply missing from the next run,
+			// so only balances written by the latest run of the validator are current.
+			delegations, err1 = d.repo.GetCurrentDelegationByDelegator(ctx, querier.GetCurrentDelegationByDelegatorParams{
+				DelegatorAddress: req.DelegatorAddress,
+				LatestRunOnly:    d.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC,
+				JobName:          constant.HourlyCollectJobName,
+			})
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		ewg.Go(func() error {
+			history, err2 = d.repo.GetDelegatorChangeHistory(ctx, querier.GetDelegatorChangeHistoryParams{
+				DelegatorAddress: req.DelegatorAddress,
+				JobName:          constant.HourlyCollectJobName,
+				SynthesizeExits:  d.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC,
+				SortBy:           "-date",
+				Limit:            constant.DefaultLimit,
+				Offset:           0,
+			})
+			if err2 != nil {
+				return err2
+			}
+
+			return nil
+		})
+
+		if err := ewg.Wait(); err != nil {
+			return dto.GetDelegatorSummaryResponse{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator summary", http.StatusUnprocessableEntity)
+		}
+
+		if len(delegations) == 0 && len(history) == 0 {
+			return dto.GetDelegatorSummaryResponse{}, utils.WrapAppError(errDelegatorNotFound, constant.ErrorCodeNotFound, "delegator not found", http.StatusNotFound)
+		}
+
+		return dto.GetDelegatorSummaryResponse{
+			DelegatorAddress: req.DelegatorAddress,
+			TotalAmount: lo.SumBy(delegations, func(item querier.GetCurrentDelegationByDelegatorRow) int64 {
+				return item.AmountUatom
+			}),
+			Delegations: lo.Map(delegations, func(item querier.GetCurrentDelegationByDelegatorRow, _ int) dto.GetDelegatorDelegationResponse {
+				return dto.GetDelegatorDelegationResponse{
+					ValidatorAddress: item.ValidatorAddress,
+					Amount:           item.AmountUatom,
+					Timestamp:        item.Timestamp.In(loc).Format(constant.TimeFormat),
+				}
+			}),
+			History: lo.Map(history, func(item querier.GetDelegatorChangeHistoryRow, _ int) dto.GetDelegatorChangeResponse {
+				return toDelegatorChangeResponse(item, loc)
+			}),
+		}, nil
+	}, cacheOptions(d.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator summary", http.StatusUnprocessableEntity)
+}
+
+func (d *delegatorSvc) GetDelegatorChangeHistory(ctx context.Context, req dto.GetDelegatorChangeHistoryRequest) (resp dto.PaginationResp[dto.GetDelegatorChangeResponse], err error) {
+	defer logError(ctx, d.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, d.cacheSvc, utils.BuildCacheKey(constant.DelegatorChangeHistoryCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetDelegatorChangeResponse], error) {
+		loc, err := d.getLocation(req.Timezone)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDelegatorChangeResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
+
+		startTime, endTime, err := getDateRange(req.From, req.To, loc)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDelegatorChangeResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidDateRange, "invalid date range", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var history []querier.GetDelegatorChangeHistoryRow
+		var countHistory int64
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			// In full storage the exits are rebuilt from the runs a delegator is missing from
+			history, err1 = d.repo.GetDelegatorChangeHistory(ctx, querier.GetDelegatorChangeHistoryParams{
+				DelegatorAddress: req.DelegatorAddress,
+				JobName:          constant.HourlyCollectJobName,
+				SynthesizeExits:  d.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC,
+				StartTime:        startTime,
+				EndTime:          endTime,
+				SortBy:           req.SortBy,
+				Limit:            req.Limit,
+				Offset:           dto.GetOffSet(req.Page, req.Limit),
+			})
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		ewg.Go(func() error {
+			countHistory, err2 = d.repo.GetCountDelegatorChangeHistory(ctx, querier.GetCountDelegatorChangeHistoryParams{
+				DelegatorAddress: req.DelegatorAddress,
+				JobName:          constant.HourlyCollectJobName,
+				SynthesizeExits:  d.config.SnapshotStorageMode != constant.SnapshotStorageModeCDC,
+				StartTime:        startTime,
+				EndTime:          endTime,
+			})
+			if err2 != nil {
+				return err2
+			}
+
+			return nil
+		})
+
+		if err := ewg.Wait(); err != nil {
+			return dto.PaginationResp[dto.GetDelegatorChangeResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator change history", http.StatusUnprocessableEntity)
+		}
+
+		return dto.ToPaginationResp(lo.Map(history, func(item querier.GetDelegatorChangeHistoryRow, _ int) dto.GetDelegatorChangeResponse {
+			return toDelegatorChangeResponse(item, loc)
+		}), int(req.Page), int(req.Limit), int(countHistory)), nil
+	}, cacheOptions(d.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator change history", http.StatusUnprocessableEntity)
+}
+
+func toDelegatorChangeResponse(item querier.GetDelegatorChangeHistoryRow, loc *time.Location) dto.GetDelegatorChangeResponse {
+	return dto.GetDelegatorChangeResponse{
+		ValidatorAddress: item.ValidatorAddress,
+		Timestamp:        item.Timestamp.In(loc).Format(constant.TimeFormat),
+		Amount:           item.AmountUatom,
+		Change:           item.ChangeUatom,
+	}
+}
+
+// getLocation resolves the tz query param, falling back to the reporting timezone.
+func (d *delegatorSvc) getLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		timezone = d.config.ReportingTimezone
+	}
+
+	return time.LoadLocation(timezone)
+}
+
+// getDateRange turns the inclusive from and to dates into a half open time range in loc,
+// an empty date leaves that side of the range open.
+func getDateRange(from string, to string, loc *time.Location) (sql.NullTime, sql.NullTime, error) {
+	startTime, err := parseDate(from, loc)
+	if err != nil {
+		return startTime, sql.NullTime{}, err
+	}
+
+	endTime, err := parseDate(to, loc)
+	if err != nil {
+		return startTime, endTime, err
+	}
+
+	if endTime.Valid {
+		endTime.Time = endTime.Time.AddDate(0, 0, 1)
+	}
+
+	if startTime.Valid && endTime.Valid && !startTime.Time.Before(endTime.Time) {
+		return startTime, endTime, errInvalidDateRange
+	}
+
+	return startTime, endTime, nil
+}
+
+// getDateFilter parses the inclusive from and to dates of a daily table, an empty date leaves that side open.
+func getDateFilter(from string, to string) (sql.NullTime, sql.NullTime, error) {
+	startDate, err := parseDate(from, time.UTC)
+	if err != nil {
+		return startDate, sql.NullTime{}, err
+	}
+
+	endDate, err := parseDate(to, time.UTC)
+	if err != nil {
+		return startDate, endDate, err
+	}
+
+	if startDate.Valid && endDate.Valid && startDate.Time.After(endDate.Time) {
+		return startDate, endDate, errInvalidDateRange
+	}
+
+	return startDate, endDate, nil
+}
+
+func parseDate(date string, loc *time.Location) (sql.NullTime, error) {
+	if date == "" {
+		return sql.NullTime{}, nil
+	}
+
+	t, err := time.ParseInLocation(constant.DateFormat, date, loc)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
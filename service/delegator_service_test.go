@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
+	mockrepo "github.com/gadhittana01/cosmos-validation-tracking/db/repository/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func initDelegatorSvc(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	config *utils.BaseConfig,
+) (DelegatorSvc, *mockrepo.MockRepository, *mockutl.MockLoggerSvc, utils.CacheSvc) {
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
+
+	return NewDelegatorSvc(mockRepo, config, mockLogger, cacheSvc), mockRepo, mockLogger, cacheSvc
+}
+
+func TestGetDelegatorSummary(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	delegatorSvcMock, mockRepo, mockLogger, cacheSvc := initDelegatorSvc(t, ctrl, config)
+	request := dto.GetDelegatorSummaryRequest{
+		DelegatorAddress: "cosmos1...",
+	}
+	timestamp := time.Now().UTC()
+	delegations := []querier.GetCurrentDelegationByDelegatorRow{
+		{
+			ValidatorAddress: "cosmosvaloper1...",
+			AmountUatom:      3000,
+			Timestamp:        timestamp,
+		},
+		{
+			ValidatorAddress: "cosmosvaloper2...",
+			AmountUatom:      1000,
+			Timestamp:        timestamp,
+		},
+	}
+	history := []querier.GetDelegatorChangeHistoryRow{
+		{
+			ValidatorAddress: "cosmosvaloper1...",
+			Timestamp:        timestamp,
+			AmountUatom:      3000,
+			ChangeUatom:      500,
+		},
+	}
+	historyParams := querier.GetDelegatorChangeHistoryParams{
+		DelegatorAddress: request.DelegatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		SynthesizeExits:  true,
+		SortBy:           "-date",
+		Limit:            constant.DefaultLimit,
+		Offset:           0,
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get delegator summary", func(t *testing.T) {
+		mockRepo.EXPECT().GetCurrentDelegationByDelegator(gomock.Any(), querier.GetCurrentDelegationByDelegatorParams{
+			DelegatorAddress: request.DelegatorAddress,
+			LatestRunOnly:    true,
+			JobName:          constant.HourlyCollectJobName,
+		}).Return(delegations, nil).Times(1)
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), historyParams).Return(history, nil).Times(1)
+
+		resp, err := delegatorSvcMock.GetDelegatorSummary(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, dto.GetDelegatorSummaryResponse{
+			DelegatorAddress: request.DelegatorAddress,
+			TotalAmount:      4000,
+			Delegations: []dto.GetDelegatorDelegationResponse{
+				{
+					ValidatorAddress: "cosmosvaloper1...",
+					Amount:           3000,
+					Timestamp:        timestamp.Format(constant.TimeFormat),
+				},
+				{
+					ValidatorAddress: "cosmosvaloper2...",
+					Amount:           1000,
+					Timestamp:        timestamp.Format(constant.TimeFormat),
+				},
+			},
+			History: []dto.GetDelegatorChangeResponse{
+				{
+					ValidatorAddress: "cosmosvaloper1...",
+					Timestamp:        timestamp.Format(constant.TimeFormat),
+					Amount:           3000,
+					Change:           500,
+				},
+			},
+		}, resp)
+	})
+
+	t.Run("success get delegator summary (from cache)", func(t *testing.T) {
+		resp, err := delegatorSvcMock.GetDelegatorSummary(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(4000), resp.TotalAmount)
+		assert.Len(t, resp.Delegations, 2)
+	})
+
+	t.Run("success get delegator summary in cdc storage", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.DelegatorSummaryCacheKey)
+		cdcConfig := *config
+		cdcConfig.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		cdcSvc := NewDelegatorSvc(mockRepo, &cdcConfig, mockLogger, cacheSvc)
+
+		mockRepo.EXPECT().GetCurrentDelegationByDelegator(gomock.Any(), querier.GetCurrentDelegationByDelegatorParams{
+			DelegatorAddress: request.DelegatorAddress,
+			LatestRunOnly:    false,
+			JobName:          constant.HourlyCollectJobName,
+		}).Return(delegations[:1], nil).Times(1)
+		cdcHistoryParams := historyParams
+		cdcHistoryParams.SynthesizeExits = false
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), cdcHistoryParams).Return(history, nil).Times(1)
+
+		resp, err := cdcSvc.GetDelegatorSummary(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(3000), resp.TotalAmount)
+		assert.Len(t, resp.Delegations, 1)
+	})
+
+	t.Run("delegator not found", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.DelegatorSummaryCacheKey)
+
+		mockRepo.EXPECT().GetCurrentDelegationByDelegator(gomock.Any(), gomock.Any()).Return([]querier.GetCurrentDelegationByDelegatorRow{}, nil).Times(1)
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), historyParams).Return([]querier.GetDelegatorChangeHistoryRow{}, nil).Times(1)
+
+		_, err := delegatorSvcMock.GetDelegatorSummary(ctx, request)
+
+		assertAppError(t, err, http.StatusNotFound, constant.ErrorCodeNotFound, "delegator not found: delegator not found")
+	})
+
+	t.Run("failed get current delegation by delegator", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.DelegatorSummaryCacheKey)
+
+		mockRepo.EXPECT().GetCurrentDelegationByDelegator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), historyParams).Return(history, nil).Times(1)
+
+		_, err := delegatorSvcMock.GetDelegatorSummary(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator summary: invalid request")
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "Mars/Olympus"
+
+		_, err := delegatorSvcMock.GetDelegatorSummary(ctx, tzRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidTimezone, "invalid timezone: unknown time zone Mars/Olympus")
+	})
+}
+
+func TestGetDelegatorChangeHistory(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	delegatorSvcMock, mockRepo, mockLogger, cacheSvc := initDelegatorSvc(t, ctrl, config)
+	request := dto.GetDelegatorChangeHistoryRequest{
+		DelegatorAddress: "cosmos1...",
+		From:             "2025-04-01",
+		To:               "2025-04-30",
+		SortBy:           "date",
+		Limit:            10,
+		Page:             1,
+	}
+	timestamp := time.Date(2025, 4, 2, 10, 0, 0, 0, time.UTC)
+	startTime := sql.NullTime{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	endTime := sql.NullTime{Time: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	response := dto.GetDelegatorChangeResponse{
+		ValidatorAddress: "cosmosvaloper1...",
+		Timestamp:        timestamp.Format(constant.TimeFormat),
+		Amount:           3000,
+		Change:           500,
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get delegator change history", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), querier.GetDelegatorChangeHistoryParams{
+			DelegatorAddress: request.DelegatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			SynthesizeExits:  true,
+			StartTime:        startTime,
+			EndTime:          endTime,
+			SortBy:           request.SortBy,
+			Limit:            request.Limit,
+			Offset:           dto.GetOffSet(request.Page, request.Limit),
+		}).Return([]querier.GetDelegatorChangeHistoryRow{
+			{
+				ValidatorAddress: response.ValidatorAddress,
+				Timestamp:        timestamp,
+				AmountUatom:      response.Amount,
+				ChangeUatom:      response.Change,
+			},
+		}, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegatorChangeHistory(gomock.Any(), querier.GetCountDelegatorChangeHistoryParams{
+			DelegatorAddress: request.DelegatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			SynthesizeExits:  true,
+			StartTime:        startTime,
+			EndTime:          endTime,
+		}).Return(int64(1), nil).Times(1)
+
+		resp, err := delegatorSvcMock.GetDelegatorChangeHistory(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, resp.Total)
+		assert.Equal(t, response, resp.Data[0])
+	})
+
+	t.Run("success get delegator change history (from cache)", func(t *testing.T) {
+		resp, err := delegatorSvcMock.GetDelegatorChangeHistory(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, response, resp.Data[0])
+	})
+
+	t.Run("success get delegator change history without date range in timezone", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.From = ""
+		tzRequest.To = "2025-04-30"
+		tzRequest.Timezone = "Asia/Jakarta"
+		loc, _ := time.LoadLocation(tzRequest.Timezone)
+
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), gomock.AssignableToTypeOf(querier.GetDelegatorChangeHistoryParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetDelegatorChangeHistoryParams) ([]querier.GetDelegatorChangeHistoryRow, error) {
+			assert.False(t, arg.StartTime.Valid)
+			assert.True(t, arg.EndTime.Time.Equal(time.Date(2025, 5, 1, 0, 0, 0, 0, loc)))
+			return []querier.GetDelegatorChangeHistoryRow{}, nil
+		}).Times(1)
+		mockRepo.EXPECT().GetCountDelegatorChangeHistory(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		resp, err := delegatorSvcMock.GetDelegatorChangeHistory(ctx, tzRequest)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 0, resp.Total)
+		assert.Empty(t, resp.Data)
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		rangeRequest := request
+		rangeRequest.From = "2025-05-01"
+
+		_, err := delegatorSvcMock.GetDelegatorChangeHistory(ctx, rangeRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidDateRange, "invalid date range: from is after to")
+	})
+
+	t.Run("failed get count delegator change history", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.DelegatorChangeHistoryCacheKey)
+
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), gomock.Any()).Return([]querier.GetDelegatorChangeHistoryRow{}, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegatorChangeHistory(gomock.Any(), gomock.Any()).Return(int64(0), errInvalidReq).Times(1)
+
+		_, err := delegatorSvcMock.GetDelegatorChangeHistory(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator change history: invalid request")
+	})
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+)
+
+type HealthSvc interface {
+	CheckReadiness(ctx context.Context) dto.GetReadinessResponse
+}
+
+type healthSvc struct {
+	repo     querier.Repository
+	config   *utils.BaseConfig
+	logger   utils.LoggerSvc
+	cacheSvc utils.CacheSvc
+}
+
+func NewHealthSvc(repo querier.Repository, config *utils.BaseConfig, logger utils.LoggerSvc, cacheSvc utils.CacheSvc) HealthSvc {
+	return &healthSvc{
+		repo:     repo,
+		config:   config,
+		logger:   logger,
+		cacheSvc: cacheSvc,
+	}
+}
+
+type healthCheck func(ctx context.Context, resp *dto.GetHealthCheckResponse) error
+
+// CheckReadiness runs every dependency check concurrently, each within HEALTH_CHECK_TIMEOUT.
+// The service is ready only when all of them pass.
+func (h *healthSvc) CheckReadiness(ctx context.Context) dto.GetReadinessResponse {
+	checks := map[string]healthCheck{
+		constant.HealthCheckPostgres:         h.checkPostgres,
+		constant.HealthCheckRedis:            h.checkRedis,
+		constant.HealthCheckMigrations:       h.checkMigrations,
+		constant.HealthCheckHourlyCollection: h.checkHourlyCollection,
+	}
+
+	names := lo.Keys(checks)
+	results := make([]dto.GetHealthCheckResponse, len(names))
+	ewg := errgroup.Group{}
+	for i, name := range names {
+		ewg.Go(func() error {
+			results[i] = h.runCheck(ctx, checks[name])
+			return nil
+		})
+	}
+	_ = ewg.Wait()
+
+	resp := dto.GetReadinessResponse{
+		Status: constant.HealthStatusOK,
+		Checks: make(map[string]dto.GetHealthCheckResponse, len(names)),
+	}
+	for i, name := range names {
+		resp.Checks[name] = results[i]
+		if results[i].Status != constant.HealthStatusOK {
+			resp.Status = constant.HealthStatusUnavailable
+			h.logger.WithContext(ctx).Warn(fmt.Sprintf("Health check %s failed: %s", name, results[i].Message))
+		}
+	}
+
+	return resp
+}
+
+func (h *healthSvc) runCheck(ctx context.Context, check healthCheck) dto.GetHealthCheckResponse {
+	ctx, cancel := context.WithTimeout(ctx, h.config.HealthCheckTimeout)
+	defer cancel()
+
+	resp := dto.GetHealthCheckResponse{
+		Status: constant.HealthStatusOK,
+	}
+	start := time.Now()
+	err := check(ctx, &resp)
+	resp.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		resp.Status = constant.HealthStatusUnavailable
+		resp.Message = err.Error()
+	}
+
+	return resp
+}
+
+func (h *healthSvc) checkPostgres(ctx context.Context, resp *dto.GetHealthCheckResponse) error {
+	return h.repo.Ping(ctx)
+}
+
+func (h *healthSvc) checkRedis(ctx context.Context, resp *dto.GetHealthCheckResponse) error {
+	return h.cacheSvc.Ping(ctx)
+}
+
+// checkMigrations requires the database to be at the last migration shipped with the service and not left dirty by a failed one
+func (h *healthSvc) checkMigrations(ctx context.Context, resp *dto.GetHealthCheckResponse) error {
+	migration, err := h.repo.GetMigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	resp.Version = lo.ToPtr(migration.Version)
+
+	if migration.Dirty {
+		return fmt.Errorf("migration %d is dirty", migration.Version)
+	}
+
+	latestVersion, err := utils.GetLatestMigrationVersion(h.config.MigrationURL)
+	if err != nil {
+		return err
+	}
+
+	if migration.Version != int64(latestVersion) {
+		return fmt.Errorf("database is at migration %d, expected %d", migration.Version, latestVersion)
+	}
+
+	return nil
+}
+
+// checkHourlyCollection fails when the last successful hourly collection is older than HEALTH_MAX_COLLECTION_AGE.
+// A database that was never collected into is not stale yet, and a zero age turns the check off.
+func (h *healthSvc) checkHourlyCollection(ctx context.Context, resp *dto.GetHealthCheckResponse) error {
+	lastRun, err := h.repo.GetLatestSchedulerRun(ctx, constant.HourlyCollectJobName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		resp.Message = "no hourly collection yet"
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.LastRun = lo.ToPtr(lastRun.UTC().Format(time.RFC3339))
+
+	age := utils.GetCurrentTimeInUTC().Sub(lastRun)
+	if h.config.HealthMaxCollectionAge > 0 && age > h.config.HealthMaxCollectionAge {
+		return fmt.Errorf("last hourly collection is %s old", age.Truncate(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
+	mockrepo "github.com/gadhittana01/cosmos-validation-tracking/db/repository/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func initHealthSvc(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	config *utils.BaseConfig,
+) (HealthSvc, *mockrepo.MockRepository, *mockutl.MockLoggerSvc) {
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
+
+	return NewHealthSvc(mockRepo, config, mockLogger, cacheSvc), mockRepo, mockLogger
+}
+
+func TestCheckReadiness(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	config.MigrationURL = "file://../db/migration"
+	healthSvcMock, mockRepo, mockLogger := initHealthSvc(t, ctrl, config)
+	mockutl.LoggerMock(mockLogger)
+	latestVersion, err := utils.GetLatestMigrationVersion(config.MigrationURL)
+	assert.NoError(t, err)
+
+	t.Run("success check readiness", func(t *testing.T) {
+		lastRun := utils.GetCurrentTimeInUTC().Add(-time.Hour)
+		mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+		mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(querier.GetMigrationVersionRow{Version: int64(latestVersion)}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestSchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(lastRun, nil).Times(1)
+
+		resp := healthSvcMock.CheckReadiness(ctx)
+
+		assert.Equal(t, constant.HealthStatusOK, resp.Status)
+		assert.Len(t, resp.Checks, 4)
+		for _, check := range resp.Checks {
+			assert.Equal(t, constant.HealthStatusOK, check.Status)
+		}
+		assert.Equal(t, int64(latestVersion), *resp.Checks[constant.HealthCheckMigrations].Version)
+		assert.Equal(t, lastRun.Format(time.RFC3339), *resp.Checks[constant.HealthCheckHourlyCollection].LastRun)
+	})
+
+	t.Run("success check readiness (no hourly collection yet)", func(t *testing.T) {
+		mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+		mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(querier.GetMigrationVersionRow{Version: int64(latestVersion)}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestSchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(time.Time{}, pgx.ErrNoRows).Times(1)
+
+		resp := healthSvcMock.CheckReadiness(ctx)
+
+		assert.Equal(t, constant.HealthStatusOK, resp.Status)
+		assert.Equal(t, "no hourly collection yet", resp.Checks[constant.HealthCheckHourlyCollection].Message)
+	})
+
+	t.Run("failed ping postgres", func(t *testing.T) {
+		mockRepo.EXPECT().Ping(gomock.Any()).Return(errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(querier.GetMigrationVersionRow{}, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetLatestSchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(time.Time{}, errInvalidReq).Times(1)
+
+		resp := healthSvcMock.CheckReadiness(ctx)
+
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Status)
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Checks[constant.HealthCheckPostgres].Status)
+		assert.Equal(t, errInvalidReq.Error(), resp.Checks[constant.HealthCheckPostgres].Message)
+		assert.Equal(t, constant.HealthStatusOK, resp.Checks[constant.HealthCheckRedis].Status)
+	})
+
+	t.Run("failed dirty migration", func(t *testing.T) {
+		mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+		mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(querier.GetMigrationVersionRow{Version: int64(latestVersion), Dirty: true}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestSchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(utils.GetCurrentTimeInUTC(), nil).Times(1)
+
+		resp := healthSvcMock.CheckReadiness(ctx)
+
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Status)
+		assert.Contains(t, resp.Checks[constant.HealthCheckMigrations].Message, "dirty")
+	})
+
+	t.Run("failed outdated migration", func(t *testing.T) {
+		mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+		mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(querier.GetMigrationVersionRow{Version: int64(latestVersion) - 1}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestSchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(utils.GetCurrentTimeInUTC(), nil).Times(1)
+
+		resp := healthSvcMock.CheckReadiness(ctx)
+
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Status)
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Checks[constant.HealthCheckMigrations].Status)
+	})
+
+	t.Run("failed stale hourly collection", func(t *testing.T) {
+		mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+		mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(querier.GetMigrationVersionRow{Version: int64(latestVersion)}, nil).Times(1)
+		mockRepo.EXPECT().GetLatestSchedulerRun(gomock.Any(), constant.HourlyCollectJobName).Return(utils.GetCurrentTimeInUTC().Add(-2*config.HealthMaxCollectionAge), nil).Times(1)
+
+		resp := healthSvcMock.CheckReadiness(ctx)
+
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Status)
+		assert.Equal(t, constant.HealthStatusUnavailable, resp.Checks[constant.HealthCheckHourlyCollection].Status)
+		assert.NotNil(t, resp.Checks[constant.HealthCheckHourlyCollection].LastRun)
+	})
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service/api_key_service.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/gadhittana01/cosmos-validation-tracking/dto"
+	utils "github.com/gadhittana01/cosmos-validation-tracking/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAPIKeySvc is a mock of APIKeySvc interface.
+type MockAPIKeySvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeySvcMockRecorder
+}
+
+// MockAPIKeySvcMockRecorder is the mock recorder for MockAPIKeySvc.
+type MockAPIKeySvcMockRecorder struct {
+	mock *MockAPIKeySvc
+}
+
+// NewMockAPIKeySvc creates a new mock instance.
+func NewMockAPIKeySvc(ctrl *gomock.Controller) *MockAPIKeySvc {
+	mock := &MockAPIKeySvc{ctrl: ctrl}
+	mock.recorder = &MockAPIKeySvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeySvc) EXPECT() *MockAPIKeySvcMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeySvc) CreateAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.CreateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, req)
+	ret0, _ := ret[0].(dto.CreateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeySvcMockRecorder) CreateAPIKey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeySvc)(nil).CreateAPIKey), ctx, req)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeySvc) GetAPIKeys(ctx context.Context) ([]dto.GetAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx)
+	ret0, _ := ret[0].([]dto.GetAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeySvcMockRecorder) GetAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeySvc)(nil).GetAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeySvc) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeySvcMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeySvc)(nil).RevokeAPIKey), ctx, id)
+}
+
+// VerifyAPIKey mocks base method.
+func (m *MockAPIKeySvc) VerifyAPIKey(ctx context.Context, key string) (utils.APIKeyIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAPIKey", ctx, key)
+	ret0, _ := ret[0].(utils.APIKeyIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAPIKey indicates an expected call of VerifyAPIKey.
+func (mr *MockAPIKeySvcMockRecorder) VerifyAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAPIKey", reflect.TypeOf((*MockAPIKeySvc)(nil).VerifyAPIKey), ctx, key)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service/delegator_service.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/gadhittana01/cosmos-validation-tracking/dto"
+	service "github.com/gadhittana01/cosmos-validation-tracking/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDelegatorSvc is a mock of DelegatorSvc interface.
+type MockDelegatorSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockDelegatorSvcMockRecorder
+}
+
+// MockDelegatorSvcMockRecorder is the mock recorder for MockDelegatorSvc.
+type MockDelegatorSvcMockRecorder struct {
+	mock *MockDelegatorSvc
+}
+
+// NewMockDelegatorSvc creates a new mock instance.
+func NewMockDelegatorSvc(ctrl *gomock.Controller) *MockDelegatorSvc {
+	mock := &MockDelegatorSvc{ctrl: ctrl}
+	mock.recorder = &MockDelegatorSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDelegatorSvc) EXPECT() *MockDelegatorSvcMockRecorder {
+	return m.recorder
+}
+
+// GetDelegatorChangeHistory mocks base method.
+func (m *MockDelegatorSvc) GetDelegatorChangeHistory(ctx context.Context, req dto.GetDelegatorChangeHistoryRequest) (service.PaginationDelegatorChangeHistoryResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorChangeHistory", ctx, req)
+	ret0, _ := ret[0].(service.PaginationDelegatorChangeHistoryResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorChangeHistory indicates an expected call of GetDelegatorChangeHistory.
+func (mr *MockDelegatorSvcMockRecorder) GetDelegatorChangeHistory(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorChangeHistory", reflect.TypeOf((*MockDelegatorSvc)(nil).GetDelegatorChangeHistory), ctx, req)
+}
+
+// GetDelegatorSummary mocks base method.
+func (m *MockDelegatorSvc) GetDelegatorSummary(ctx context.Context, req dto.GetDelegatorSummaryRequest) (dto.GetDelegatorSummaryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorSummary", ctx, req)
+	ret0, _ := ret[0].(dto.GetDelegatorSummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorSummary indicates an expected call of GetDelegatorSummary.
+func (mr *MockDelegatorSvcMockRecorder) GetDelegatorSummary(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorSummary", reflect.TypeOf((*MockDelegatorSvc)(nil).GetDelegatorSummary), ctx, req)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service/health_service.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/gadhittana01/cosmos-validation-tracking/dto"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHealthSvc is a mock of HealthSvc interface.
+type MockHealthSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthSvcMockRecorder
+}
+
+// MockHealthSvcMockRecorder is the mock recorder for MockHealthSvc.
+type MockHealthSvcMockRecorder struct {
+	mock *MockHealthSvc
+}
+
+// NewMockHealthSvc creates a new mock instance.
+func NewMockHealthSvc(ctrl *gomock.Controller) *MockHealthSvc {
+	mock := &MockHealthSvc{ctrl: ctrl}
+	mock.recorder = &MockHealthSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthSvc) EXPECT() *MockHealthSvcMockRecorder {
+	return m.recorder
+}
+
+// CheckReadiness mocks base method.
+func (m *MockHealthSvc) CheckReadiness(ctx context.Context) dto.GetReadinessResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadiness", ctx)
+	ret0, _ := ret[0].(dto.GetReadinessResponse)
+	return ret0
+}
+
+// CheckReadiness indicates an expected call of CheckReadiness.
+func (mr *MockHealthSvcMockRecorder) CheckReadiness(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockHealthSvc)(nil).CheckReadiness), ctx)
+}
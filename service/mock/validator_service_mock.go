@@ -36,12 +36,100 @@ func (m *MockValidatorSvc) EXPECT() *MockValidatorSvcMockRecorder {
 	return m.recorder
 }
 
+// CompareValidator mocks base method.
+func (m *MockValidatorSvc) CompareValidator(ctx context.Context, req dto.CompareValidatorRequest) (dto.CompareValidatorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareValidator", ctx, req)
+	ret0, _ := ret[0].(dto.CompareValidatorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareValidator indicates an expected call of CompareValidator.
+func (mr *MockValidatorSvcMockRecorder) CompareValidator(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareValidator", reflect.TypeOf((*MockValidatorSvc)(nil).CompareValidator), ctx, req)
+}
+
+// ExportDailySnapshot mocks base method.
+func (m *MockValidatorSvc) ExportDailySnapshot(ctx context.Context, req dto.ExportDailySnapshotRequest, fn func(dto.GetDailySnapshotResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDailySnapshot", ctx, req, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDailySnapshot indicates an expected call of ExportDailySnapshot.
+func (mr *MockValidatorSvcMockRecorder) ExportDailySnapshot(ctx, req, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDailySnapshot", reflect.TypeOf((*MockValidatorSvc)(nil).ExportDailySnapshot), ctx, req, fn)
+}
+
+// ExportDelegatorHistory mocks base method.
+func (m *MockValidatorSvc) ExportDelegatorHistory(ctx context.Context, req dto.ExportDelegatorHistoryRequest, fn func(dto.GetDelegatorHistoryResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDelegatorHistory", ctx, req, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDelegatorHistory indicates an expected call of ExportDelegatorHistory.
+func (mr *MockValidatorSvcMockRecorder) ExportDelegatorHistory(ctx, req, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDelegatorHistory", reflect.TypeOf((*MockValidatorSvc)(nil).ExportDelegatorHistory), ctx, req, fn)
+}
+
+// ExportHourlySnapshot mocks base method.
+func (m *MockValidatorSvc) ExportHourlySnapshot(ctx context.Context, req dto.ExportHourlySnapshotRequest, fn func(dto.GetHourlySnapshotResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportHourlySnapshot", ctx, req, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportHourlySnapshot indicates an expected call of ExportHourlySnapshot.
+func (mr *MockValidatorSvcMockRecorder) ExportHourlySnapshot(ctx, req, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportHourlySnapshot", reflect.TypeOf((*MockValidatorSvc)(nil).ExportHourlySnapshot), ctx, req, fn)
+}
+
+// GetConcentrationMetric mocks base method.
+func (m *MockValidatorSvc) GetConcentrationMetric(ctx context.Context, req dto.GetConcentrationMetricRequest) (service.PaginationValidatorConcentrationResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConcentrationMetric", ctx, req)
+	ret0, _ := ret[0].(service.PaginationValidatorConcentrationResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConcentrationMetric indicates an expected call of GetConcentrationMetric.
+func (mr *MockValidatorSvcMockRecorder) GetConcentrationMetric(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConcentrationMetric", reflect.TypeOf((*MockValidatorSvc)(nil).GetConcentrationMetric), ctx, req)
+}
+
+// GetDailyDelegatorEvent mocks base method.
+func (m *MockValidatorSvc) GetDailyDelegatorEvent(ctx context.Context, req dto.GetDailyDelegatorEventRequest) ([]dto.GetDailyDelegatorEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyDelegatorEvent", ctx, req)
+	ret0, _ := ret[0].([]dto.GetDailyDelegatorEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyDelegatorEvent indicates an expected call of GetDailyDelegatorEvent.
+func (mr *MockValidatorSvcMockRecorder) GetDailyDelegatorEvent(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyDelegatorEvent", reflect.TypeOf((*MockValidatorSvc)(nil).GetDailyDelegatorEvent), ctx, req)
+}
+
 // GetDailySnapshot mocks base method.
-func (m *MockValidatorSvc) GetDailySnapshot(ctx context.Context, req dto.GetDailySnapshotRequest) service.PaginationValidatorDailySnapshotResp {
+func (m *MockValidatorSvc) GetDailySnapshot(ctx context.Context, req dto.GetDailySnapshotRequest) (service.PaginationValidatorDailySnapshotResp, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDailySnapshot", ctx, req)
 	ret0, _ := ret[0].(service.PaginationValidatorDailySnapshotResp)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetDailySnapshot indicates an expected call of GetDailySnapshot.
@@ -50,12 +138,73 @@ func (mr *MockValidatorSvcMockRecorder) GetDailySnapshot(ctx, req interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailySnapshot", reflect.TypeOf((*MockValidatorSvc)(nil).GetDailySnapshot), ctx, req)
 }
 
+// GetDelegationAsOf mocks base method.
+func (m *MockValidatorSvc) GetDelegationAsOf(ctx context.Context, req dto.GetDelegationAsOfRequest) (service.PaginationValidatorDelegationAsOfResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationAsOf", ctx, req)
+	ret0, _ := ret[0].(service.PaginationValidatorDelegationAsOfResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationAsOf indicates an expected call of GetDelegationAsOf.
+func (mr *MockValidatorSvcMockRecorder) GetDelegationAsOf(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationAsOf", reflect.TypeOf((*MockValidatorSvc)(nil).GetDelegationAsOf), ctx, req)
+}
+
+// GetDelegatorCohort mocks base method.
+func (m *MockValidatorSvc) GetDelegatorCohort(ctx context.Context, req dto.GetDelegatorCohortRequest) ([]dto.GetDelegatorCohortResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorCohort", ctx, req)
+	ret0, _ := ret[0].([]dto.GetDelegatorCohortResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorCohort indicates an expected call of GetDelegatorCohort.
+func (mr *MockValidatorSvcMockRecorder) GetDelegatorCohort(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorCohort", reflect.TypeOf((*MockValidatorSvc)(nil).GetDelegatorCohort), ctx, req)
+}
+
+// GetDelegatorDistribution mocks base method.
+func (m *MockValidatorSvc) GetDelegatorDistribution(ctx context.Context, req dto.GetDelegatorDistributionRequest) (dto.GetDelegatorDistributionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorDistribution", ctx, req)
+	ret0, _ := ret[0].(dto.GetDelegatorDistributionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorDistribution indicates an expected call of GetDelegatorDistribution.
+func (mr *MockValidatorSvcMockRecorder) GetDelegatorDistribution(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorDistribution", reflect.TypeOf((*MockValidatorSvc)(nil).GetDelegatorDistribution), ctx, req)
+}
+
+// GetDelegatorEvent mocks base method.
+func (m *MockValidatorSvc) GetDelegatorEvent(ctx context.Context, req dto.GetDelegatorEventRequest) (service.PaginationValidatorDelegatorEventResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatorEvent", ctx, req)
+	ret0, _ := ret[0].(service.PaginationValidatorDelegatorEventResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatorEvent indicates an expected call of GetDelegatorEvent.
+func (mr *MockValidatorSvcMockRecorder) GetDelegatorEvent(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatorEvent", reflect.TypeOf((*MockValidatorSvc)(nil).GetDelegatorEvent), ctx, req)
+}
+
 // GetDelegatorHistory mocks base method.
-func (m *MockValidatorSvc) GetDelegatorHistory(ctx context.Context, req dto.GetDelegatorHistoryRequest) service.PaginationValidatorDelegatorHistoryResp {
+func (m *MockValidatorSvc) GetDelegatorHistory(ctx context.Context, req dto.GetDelegatorHistoryRequest) (service.PaginationValidatorDelegatorHistoryResp, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDelegatorHistory", ctx, req)
 	ret0, _ := ret[0].(service.PaginationValidatorDelegatorHistoryResp)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetDelegatorHistory indicates an expected call of GetDelegatorHistory.
@@ -65,11 +214,12 @@ func (mr *MockValidatorSvcMockRecorder) GetDelegatorHistory(ctx, req interface{}
 }
 
 // GetHourlySnapshot mocks base method.
-func (m *MockValidatorSvc) GetHourlySnapshot(ctx context.Context, req dto.GetHourlySnapshotRequest) service.PaginationValidatorSnapshotResp {
+func (m *MockValidatorSvc) GetHourlySnapshot(ctx context.Context, req dto.GetHourlySnapshotRequest) (service.PaginationValidatorSnapshotResp, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetHourlySnapshot", ctx, req)
 	ret0, _ := ret[0].(service.PaginationValidatorSnapshotResp)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetHourlySnapshot indicates an expected call of GetHourlySnapshot.
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"go.uber.org/zap"
+)
+
+// logError logs the error stored in errp by the time a service call returns, tagged with the request ID, route and
+// validator address of ctx. Rejected requests, such as an invalid tz, are logged as warnings.
+func logError(ctx context.Context, logger utils.LoggerSvc, errp *error) {
+	if *errp == nil {
+		return
+	}
+
+	appErr := utils.ToAppError(*errp)
+	if appErr.StatusCode < http.StatusUnprocessableEntity {
+		logger.WithContext(ctx).Warn(appErr.Message, zap.Error(*errp))
+		return
+	}
+
+	logger.WithContext(ctx).Error(appErr.Message, zap.Error(*errp))
+}
+
+// cacheOptions serves entries past CACHE_SOFT_TTL while they are refreshed and lets a single replica rebuild an entry,
+// for the endpoints reading the latest runs of a validator or delegator
+func cacheOptions(config *utils.BaseConfig) []utils.CacheOption {
+	return []utils.CacheOption{
+		utils.WithSoftTTL(config.CacheSoftTTL),
+		utils.WithRefreshLock(config.CacheLockTimeout),
+	}
+}
+
+// analyticsCacheOptions applies CACHE_ANALYTICS_SOFT_TTL and CACHE_ANALYTICS_LOCK_TIMEOUT instead, for the endpoints
+// scanning the whole history of a validator, whose slower loads are refreshed sooner and waited for longer
+func analyticsCacheOptions(config *utils.BaseConfig) []utils.CacheOption {
+	return []utils.CacheOption{
+		utils.WithSoftTTL(config.CacheAnalyticsSoftTTL),
+		utils.WithRefreshLock(config.CacheAnalyticsLockTimeout),
+	}
+}
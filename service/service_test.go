@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	mockrepo "github.com/gadhittana01/cosmos-validation-tracking/db/repository/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/dto"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type requestIDKey struct{}
+
+func TestLogError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "request-id")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	// The cache logs its own misses, so only the service logger is asserted
+	cacheLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockutl.LoggerMock(cacheLogger)
+
+	t.Run("rejected request is logged as a warning with the request context", func(t *testing.T) {
+		mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+		validatorSvc := NewValidatorSvc(mockrepo.NewMockRepository(ctrl), config, mockLogger, utils.InitCacheSvc(t, config, cacheLogger))
+		mockLogger.EXPECT().WithContext(gomock.Any()).DoAndReturn(func(logCtx context.Context) utils.LoggerSvc {
+			assert.Equal(t, "request-id", logCtx.Value(requestIDKey{}))
+			return mockLogger
+		}).Times(1)
+		mockLogger.EXPECT().Warn("invalid timezone", gomock.Any()).Times(1)
+
+		_, err := validatorSvc.GetHourlySnapshot(ctx, dto.GetHourlySnapshotRequest{
+			ValidatorAddress: "cosmosvaloper1...",
+			Limit:            10,
+			Page:             1,
+			Timezone:         "Mars/Olympus",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("failed query is logged as an error with the request context", func(t *testing.T) {
+		mockRepo := mockrepo.NewMockRepository(ctrl)
+		mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+		delegatorSvc := NewDelegatorSvc(mockRepo, config, mockLogger, utils.InitCacheSvc(t, config, cacheLogger))
+		mockRepo.EXPECT().GetCurrentDelegationByDelegator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetDelegatorChangeHistory(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		mockLogger.EXPECT().WithContext(gomock.Any()).DoAndReturn(func(logCtx context.Context) utils.LoggerSvc {
+			assert.Equal(t, "request-id", logCtx.Value(requestIDKey{}))
+			return mockLogger
+		}).Times(1)
+		mockLogger.EXPECT().Error("failed to get delegator summary", gomock.Any()).Times(1)
+
+		_, err := delegatorSvc.GetDelegatorSummary(ctx, dto.GetDelegatorSummaryRequest{
+			DelegatorAddress: "cosmos1...",
+		})
+		assert.Error(t, err)
+	})
+}
@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 	querier "github.com/gadhittana01/cosmos-validation-tracking/db/repository"
@@ -16,37 +22,72 @@ type (
 	PaginationValidatorSnapshotResp         = dto.PaginationResp[dto.GetHourlySnapshotResponse]
 	PaginationValidatorDailySnapshotResp    = dto.PaginationResp[dto.GetDailySnapshotResponse]
 	PaginationValidatorDelegatorHistoryResp = dto.PaginationResp[dto.GetDelegatorHistoryResponse]
+	PaginationValidatorConcentrationResp    = dto.PaginationResp[dto.GetConcentrationMetricResponse]
+	PaginationValidatorDelegatorEventResp   = dto.PaginationResp[dto.GetDelegatorEventResponse]
+	PaginationValidatorDelegationAsOfResp   = dto.PaginationResp[dto.GetDelegationAsOfResponse]
+)
+
+var (
+	errInvalidCohortPeriod = errors.New("period must be week or month")
+	errInvalidBuckets      = errors.New("buckets must be ascending positive numbers")
+	errInvalidEventType    = errors.New("type must be new, churned or returned")
+	errInvalidAddresses    = errors.New("addresses must list between 1 and 10 validators")
+	errInvalidCompareRange = errors.New("date range must not exceed 366 days")
+	errInvalidAsOfSort     = errors.New("sortBy must be amount or -amount")
+	errTimezoneRetention   = errors.New("tz other than the reporting timezone is only available while the hourly runs of every day are retained")
+	errInvalidDiffSort     = errors.New("sortBy must be amount, -amount, change or -change")
 )
 
 type ValidatorSvc interface {
-	GetHourlySnapshot(ctx context.Context, req dto.GetHourlySnapshotRequest) PaginationValidatorSnapshotResp
-	GetDailySnapshot(ctx context.Context, req dto.GetDailySnapshotRequest) PaginationValidatorDailySnapshotResp
-	GetDelegatorHistory(ctx context.Context, req dto.GetDelegatorHistoryRequest) PaginationValidatorDelegatorHistoryResp
+	GetHourlySnapshot(ctx context.Context, req dto.GetHourlySnapshotRequest) (PaginationValidatorSnapshotResp, error)
+	GetDailySnapshot(ctx context.Context, req dto.GetDailySnapshotRequest) (PaginationValidatorDailySnapshotResp, error)
+	GetDelegatorHistory(ctx context.Context, req dto.GetDelegatorHistoryRequest) (PaginationValidatorDelegatorHistoryResp, error)
+	ExportHourlySnapshot(ctx context.Context, req dto.ExportHourlySnapshotRequest, fn func(dto.GetHourlySnapshotResponse) error) error
+	ExportDailySnapshot(ctx context.Context, req dto.ExportDailySnapshotRequest, fn func(dto.GetDailySnapshotResponse) error) error
+	ExportDelegatorHistory(ctx context.Context, req dto.ExportDelegatorHistoryRequest, fn func(dto.GetDelegatorHistoryResponse) error) error
+	GetDelegatorCohort(ctx context.Context, req dto.GetDelegatorCohortRequest) ([]dto.GetDelegatorCohortResponse, error)
+	GetConcentrationMetric(ctx context.Context, req dto.GetConcentrationMetricRequest) (PaginationValidatorConcentrationResp, error)
+	GetDelegatorDistribution(ctx context.Context, req dto.GetDelegatorDistributionRequest) (dto.GetDelegatorDistributionResponse, error)
+	GetDelegatorEvent(ctx context.Context, req dto.GetDelegatorEventRequest) (PaginationValidatorDelegatorEventResp, error)
+	GetDailyDelegatorEvent(ctx context.Context, req dto.GetDailyDelegatorEventRequest) ([]dto.GetDailyDelegatorEventResponse, error)
+	CompareValidator(ctx context.Context, req dto.CompareValidatorRequest) (dto.CompareValidatorResponse, error)
+	GetDelegationAsOf(ctx context.Context, req dto.GetDelegationAsOfRequest) (PaginationValidatorDelegationAsOfResp, error)
 }
 
 type validatorSvc struct {
 	repo     querier.Repository
+	config   *utils.BaseConfig
 	logger   utils.LoggerSvc
 	cacheSvc utils.CacheSvc
 }
 
-func NewValidatorSvc(repo querier.Repository, logger utils.LoggerSvc, cacheSvc utils.CacheSvc) ValidatorSvc {
+func NewValidatorSvc(repo querier.Repository, config *utils.BaseConfig, logger utils.LoggerSvc, cacheSvc utils.CacheSvc) ValidatorSvc {
 	return &validatorSvc{
 		repo:     repo,
+		config:   config,
 		logger:   logger,
 		cacheSvc: cacheSvc,
 	}
 }
 
-func (v *validatorSvc) GetHourlySnapshot(ctx context.Context, req dto.GetHourlySnapshotRequest) dto.PaginationResp[dto.GetHourlySnapshotResponse] {
-	resp, err := utils.GetOrSetData(v.cacheSvc, utils.BuildCacheKey(constant.ValidatorHourlySnapshotCacheKey, "", "", req), func() (dto.PaginationResp[dto.GetHourlySnapshotResponse], error) {
+func (v *validatorSvc) GetHourlySnapshot(ctx context.Context, req dto.GetHourlySnapshotRequest) (resp dto.PaginationResp[dto.GetHourlySnapshotResponse], err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetHourlySnapshot")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorHourlySnapshotCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetHourlySnapshotResponse], error) {
+		loc, err := v.getLocation(req.Timezone)
+		if err != nil {
+			return dto.PaginationResp[dto.GetHourlySnapshotResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
+
 		ewg := errgroup.Group{}
 		var delegationSnapshot []querier.GetDelegationSnapshotByValidatorRow
 		var countDelegationSnapshot int64
 		var err1, err2 error
 
 		ewg.Go(func() error {
-			delegationSnapshot, err1 = v.repo.GetDelegationSnapshotByValidator(ctx, querier.GetDelegationSnapshotByValidatorParams{
+			delegationSnapshot, err1 = v.getDelegationSnapshotByValidator(ctx, querier.GetDelegationSnapshotByValidatorParams{
 				ValidatorAddress: req.ValidatorAddress,
 				Limit:            req.Limit,
 				Offset:           dto.GetOffSet(req.Page, req.Limit),
@@ -59,7 +100,7 @@ func (v *validatorSvc) GetHourlySnapshot(ctx context.Context, req dto.GetHourlyS
 		})
 
 		ewg.Go(func() error {
-			countDelegationSnapshot, err2 = v.repo.GetCountDelegationSnapshotByValidator(ctx, req.ValidatorAddress)
+			countDelegationSnapshot, err2 = v.getCountDelegationSnapshotByValidator(ctx, req.ValidatorAddress)
 			if err2 != nil {
 				return err2
 			}
@@ -68,34 +109,38 @@ func (v *validatorSvc) GetHourlySnapshot(ctx context.Context, req dto.GetHourlyS
 		})
 
 		if err := ewg.Wait(); err != nil {
-			return dto.PaginationResp[dto.GetHourlySnapshotResponse]{}, utils.CustomErrorWithTrace(err, "failed to get hourly snapshot", http.StatusUnprocessableEntity)
+			return dto.PaginationResp[dto.GetHourlySnapshotResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get hourly snapshot", http.StatusUnprocessableEntity)
 		}
 
 		return dto.ToPaginationResp(lo.Map(delegationSnapshot, func(item querier.GetDelegationSnapshotByValidatorRow, _ int) dto.GetHourlySnapshotResponse {
-			return dto.GetHourlySnapshotResponse{
-				Address:   item.DelegatorAddress,
-				Amount:    item.AmountUatom,
-				Change:    item.ChangeUatom,
-				Date:      item.Timestamp.Format(constant.DateFormat),
-				Timestamp: item.Timestamp.Format(constant.TimeFormat),
-			}
+			return toHourlySnapshotResponse(item, loc)
 		}), int(req.Page), int(req.Limit), int(countDelegationSnapshot)), nil
-	})
-	utils.PanicIfAppError(err, "failed to get hourly snapshot", http.StatusUnprocessableEntity)
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get hourly snapshot", http.StatusUnprocessableEntity)
+}
 
-	return resp
+func (v *validatorSvc) GetDailySnapshot(ctx context.Context, req dto.GetDailySnapshotRequest) (resp dto.PaginationResp[dto.GetDailySnapshotResponse], err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDailySnapshot")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
 
-}
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDailySnapshotCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetDailySnapshotResponse], error) {
+		loc, err := v.getLocation(req.Timezone)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDailySnapshotResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
+
+		if err := v.checkDailyTimezoneRetained(ctx, loc, req.ValidatorAddress); err != nil {
+			return dto.PaginationResp[dto.GetDailySnapshotResponse]{}, err
+		}
 
-func (v *validatorSvc) GetDailySnapshot(ctx context.Context, req dto.GetDailySnapshotRequest) dto.PaginationResp[dto.GetDailySnapshotResponse] {
-	resp, err := utils.GetOrSetData(v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDailySnapshotCacheKey, "", "", req), func() (dto.PaginationResp[dto.GetDailySnapshotResponse], error) {
 		ewg := errgroup.Group{}
 		var delegationSnapshot []querier.GetDailyAggregateByValidatorRow
 		var countDelegationSnapshot int64
 		var err1, err2 error
 
 		ewg.Go(func() error {
-			delegationSnapshot, err1 = v.repo.GetDailyAggregateByValidator(ctx, querier.GetDailyAggregateByValidatorParams{
+			delegationSnapshot, err1 = v.getDailyAggregateByValidator(ctx, loc, querier.GetDailyAggregateByValidatorParams{
 				ValidatorAddress: req.ValidatorAddress,
 				Limit:            req.Limit,
 				Offset:           dto.GetOffSet(req.Page, req.Limit),
@@ -108,7 +153,7 @@ func (v *validatorSvc) GetDailySnapshot(ctx context.Context, req dto.GetDailySna
 		})
 
 		ewg.Go(func() error {
-			countDelegationSnapshot, err2 = v.repo.GetCountDailyAggregateByValidator(ctx, req.ValidatorAddress)
+			countDelegationSnapshot, err2 = v.getCountDailyAggregateByValidator(ctx, loc, req.ValidatorAddress)
 			if err2 != nil {
 				return err2
 			}
@@ -117,32 +162,34 @@ func (v *validatorSvc) GetDailySnapshot(ctx context.Context, req dto.GetDailySna
 		})
 
 		if err := ewg.Wait(); err != nil {
-			return dto.PaginationResp[dto.GetDailySnapshotResponse]{}, utils.CustomErrorWithTrace(err, "failed to get daily aggregate by validator", http.StatusUnprocessableEntity)
+			return dto.PaginationResp[dto.GetDailySnapshotResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get daily aggregate by validator", http.StatusUnprocessableEntity)
 		}
 
 		return dto.ToPaginationResp(lo.Map(delegationSnapshot, func(item querier.GetDailyAggregateByValidatorRow, _ int) dto.GetDailySnapshotResponse {
-			return dto.GetDailySnapshotResponse{
-				Address: item.DelegatorAddress,
-				Date:    item.Date.Format(constant.DateFormat),
-				Total:   item.TotalAmount,
-			}
+			return toDailySnapshotResponse(item)
 		}), int(req.Page), int(req.Limit), int(countDelegationSnapshot)), nil
-	})
-	utils.PanicIfAppError(err, "failed to get daily snapshot", http.StatusUnprocessableEntity)
-
-	return resp
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get daily snapshot", http.StatusUnprocessableEntity)
 }
 
-func (v *validatorSvc) GetDelegatorHistory(ctx context.Context, req dto.GetDelegatorHistoryRequest) dto.PaginationResp[dto.GetDelegatorHistoryResponse] {
+func (v *validatorSvc) GetDelegatorHistory(ctx context.Context, req dto.GetDelegatorHistoryRequest) (resp dto.PaginationResp[dto.GetDelegatorHistoryResponse], err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDelegatorHistory")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDelegatorHistoryCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetDelegatorHistoryResponse], error) {
+		loc, err := v.getLocation(req.Timezone)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDelegatorHistoryResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
 
-	resp, err := utils.GetOrSetData(v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDelegatorHistoryCacheKey, "", "", req), func() (dto.PaginationResp[dto.GetDelegatorHistoryResponse], error) {
 		ewg := errgroup.Group{}
 		var delegationSnapshot []querier.GetDelegatorHistoryByValidatorRow
 		var countDelegationSnapshot int64
 		var err1, err2 error
 
 		ewg.Go(func() error {
-			delegationSnapshot, err1 = v.repo.GetDelegatorHistoryByValidator(ctx, querier.GetDelegatorHistoryByValidatorParams{
+			delegationSnapshot, err1 = v.getDelegatorHistoryByValidator(ctx, querier.GetDelegatorHistoryByValidatorParams{
 				ValidatorAddress: req.ValidatorAddress,
 				DelegatorAddress: req.DelegatorAddress,
 				SortBy:           req.SortBy,
@@ -157,7 +204,7 @@ func (v *validatorSvc) GetDelegatorHistory(ctx context.Context, req dto.GetDeleg
 		})
 
 		ewg.Go(func() error {
-			countDelegationSnapshot, err2 = v.repo.GetCountDelegatorHistoryByValidator(ctx, querier.GetCountDelegatorHistoryByValidatorParams{
+			countDelegationSnapshot, err2 = v.getCountDelegatorHistoryByValidator(ctx, querier.GetCountDelegatorHistoryByValidatorParams{
 				ValidatorAddress: req.ValidatorAddress,
 				DelegatorAddress: req.DelegatorAddress,
 			})
@@ -169,18 +216,939 @@ func (v *validatorSvc) GetDelegatorHistory(ctx context.Context, req dto.GetDeleg
 		})
 
 		if err := ewg.Wait(); err != nil {
-			return dto.PaginationResp[dto.GetDelegatorHistoryResponse]{}, utils.CustomErrorWithTrace(err, "failed to get delegator history by validator", http.StatusUnprocessableEntity)
+			return dto.PaginationResp[dto.GetDelegatorHistoryResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator history by validator", http.StatusUnprocessableEntity)
 		}
 
 		return dto.ToPaginationResp(lo.Map(delegationSnapshot, func(item querier.GetDelegatorHistoryByValidatorRow, _ int) dto.GetDelegatorHistoryResponse {
-			return dto.GetDelegatorHistoryResponse{
-				Timestamp: item.Timestamp.Format(constant.TimeFormat),
-				Amount:    item.AmountUatom,
-				Change:    item.ChangeUatom,
-			}
+			return toDelegatorHistoryResponse(item, loc)
 		}), int(req.Page), int(req.Limit), int(countDelegationSnapshot)), nil
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator history by validator", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) ExportHourlySnapshot(ctx context.Context, req dto.ExportHourlySnapshotRequest, fn func(dto.GetHourlySnapshotResponse) error) (err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.ExportHourlySnapshot")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	loc, err := v.getLocation(req.Timezone)
+	if err != nil {
+		return utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+	}
+
+	write := func(item querier.GetDelegationSnapshotByValidatorRow) error {
+		return fn(toHourlySnapshotResponse(item, loc))
+	}
+
+	if v.isCDCStorage() {
+		err = v.repo.ExportReconstructedDelegationSnapshotByValidator(ctx, querier.GetCountReconstructedDelegationSnapshotByValidatorParams{
+			ValidatorAddress: req.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}, func(item querier.GetReconstructedDelegationSnapshotByValidatorRow) error {
+			return write(querier.GetDelegationSnapshotByValidatorRow(item))
+		})
+	} else {
+		err = v.repo.ExportDelegationSnapshotByValidator(ctx, req.ValidatorAddress, write)
+	}
+	return utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to export hourly snapshot", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) ExportDailySnapshot(ctx context.Context, req dto.ExportDailySnapshotRequest, fn func(dto.GetDailySnapshotResponse) error) (err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.ExportDailySnapshot")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	loc, err := v.getLocation(req.Timezone)
+	if err != nil {
+		return utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+	}
+
+	if err := v.checkDailyTimezoneRetained(ctx, loc, req.ValidatorAddress); err != nil {
+		return err
+	}
+
+	write := func(item querier.GetDailyAggregateByValidatorRow) error {
+		return fn(toDailySnapshotResponse(item))
+	}
+
+	if loc.String() == v.config.ReportingTimezone {
+		err = v.repo.ExportDailyAggregateByValidator(ctx, req.ValidatorAddress, write)
+	} else {
+		err = v.repo.ExportDailyAggregateInTimezoneByValidator(ctx, querier.GetCountDailyAggregateInTimezoneByValidatorParams{
+			DayTimezone:      loc.String(),
+			ValidatorAddress: req.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}, func(item querier.GetDailyAggregateInTimezoneByValidatorRow) error {
+			return write(querier.GetDailyAggregateByValidatorRow(item))
+		})
+	}
+	return utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to export daily snapshot", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) ExportDelegatorHistory(ctx context.Context, req dto.ExportDelegatorHistoryRequest, fn func(dto.GetDelegatorHistoryResponse) error) (err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.ExportDelegatorHistory")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	loc, err := v.getLocation(req.Timezone)
+	if err != nil {
+		return utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+	}
+
+	write := func(item querier.GetDelegatorHistoryByValidatorRow) error {
+		return fn(toDelegatorHistoryResponse(item, loc))
+	}
+
+	if v.isCDCStorage() {
+		err = v.repo.ExportReconstructedDelegatorHistoryByValidator(ctx, querier.ExportReconstructedDelegatorHistoryByValidatorParams{
+			ValidatorAddress: req.ValidatorAddress,
+			DelegatorAddress: req.DelegatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			SortBy:           req.SortBy,
+		}, func(item querier.GetReconstructedDelegatorHistoryByValidatorRow) error {
+			return write(querier.GetDelegatorHistoryByValidatorRow(item))
+		})
+	} else {
+		err = v.repo.ExportDelegatorHistoryByValidator(ctx, querier.ExportDelegatorHistoryByValidatorParams{
+			ValidatorAddress: req.ValidatorAddress,
+			DelegatorAddress: req.DelegatorAddress,
+			SortBy:           req.SortBy,
+		}, write)
+	}
+	return utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to export delegator history", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) GetDelegatorCohort(ctx context.Context, req dto.GetDelegatorCohortRequest) (resp []dto.GetDelegatorCohortResponse, err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDelegatorCohort")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorCohortCacheKey, "", "", req), func(ctx context.Context) ([]dto.GetDelegatorCohortResponse, error) {
+		loc, err := v.getLocation(req.Timezone)
+		if err != nil {
+			return nil, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
+
+		if req.Period != constant.CohortPeriodWeek && req.Period != constant.CohortPeriodMonth {
+			return nil, utils.WrapAppError(errInvalidCohortPeriod, constant.ErrorCodeInvalidRequest, "invalid cohort period", http.StatusBadRequest)
+		}
+
+		// In full storage a delegator who left is simply missing from the next run,
+		// so a balance only counts when it was written by the latest run before the period end.
+		rows, err := v.repo.GetDelegatorCohortRetentionByValidator(ctx, querier.GetDelegatorCohortRetentionByValidatorParams{
+			LatestRunOnly:    !v.isCDCStorage(),
+			ValidatorAddress: req.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Period:           req.Period,
+			DayTimezone:      loc.String(),
+			AsOf:             utils.GetCurrentTimeInUTC(),
+			PeriodCount:      req.Periods,
+		})
+		if err != nil {
+			return nil, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator cohort", http.StatusUnprocessableEntity)
+		}
+
+		return toDelegatorCohortResponse(rows), nil
+	}, analyticsCacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator cohort", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) GetConcentrationMetric(ctx context.Context, req dto.GetConcentrationMetricRequest) (resp dto.PaginationResp[dto.GetConcentrationMetricResponse], err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetConcentrationMetric")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorConcentrationCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetConcentrationMetricResponse], error) {
+		startDate, endDate, err := getDateFilter(req.From, req.To)
+		if err != nil {
+			return dto.PaginationResp[dto.GetConcentrationMetricResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidDateRange, "invalid date range", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var metrics []querier.GetDailyConcentrationMetricByValidatorRow
+		var countMetrics int64
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			metrics, err1 = v.repo.GetDailyConcentrationMetricByValidator(ctx, querier.GetDailyConcentrationMetricByValidatorParams{
+				ValidatorAddress: req.ValidatorAddress,
+				StartDate:        startDate,
+				EndDate:          endDate,
+				Limit:            req.Limit,
+				Offset:           dto.GetOffSet(req.Page, req.Limit),
+			})
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		ewg.Go(func() error {
+			countMetrics, err2 = v.repo.GetCountDailyConcentrationMetricByValidator(ctx, querier.GetCountDailyConcentrationMetricByValidatorParams{
+				ValidatorAddress: req.ValidatorAddress,
+				StartDate:        startDate,
+				EndDate:          endDate,
+			})
+			if err2 != nil {
+				return err2
+			}
+
+			return nil
+		})
+
+		if err := ewg.Wait(); err != nil {
+			return dto.PaginationResp[dto.GetConcentrationMetricResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get concentration metric", http.StatusUnprocessableEntity)
+		}
+
+		return dto.ToPaginationResp(lo.Map(metrics, func(item querier.GetDailyConcentrationMetricByValidatorRow, _ int) dto.GetConcentrationMetricResponse {
+			return dto.GetConcentrationMetricResponse{
+				Date:            item.Date.Format(constant.DateFormat),
+				Delegators:      item.DelegatorCount,
+				Total:           item.TotalAmount,
+				GiniCoefficient: item.GiniCoefficient,
+				HerfindahlIndex: item.HerfindahlIndex,
+				Top10Share:      item.Top10Share,
+				Top100Share:     item.Top100Share,
+				Nakamoto33:      item.Nakamoto33,
+				Nakamoto50:      item.Nakamoto50,
+			}
+		}), int(req.Page), int(req.Limit), int(countMetrics)), nil
+	}, analyticsCacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get concentration metric", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) GetDelegatorDistribution(ctx context.Context, req dto.GetDelegatorDistributionRequest) (resp dto.GetDelegatorDistributionResponse, err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDelegatorDistribution")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDistributionCacheKey, "", "", req), func(ctx context.Context) (dto.GetDelegatorDistributionResponse, error) {
+		buckets, boundaries, err := parseDistributionBuckets(req.Buckets)
+		if err != nil {
+			return dto.GetDelegatorDistributionResponse{}, utils.WrapAppError(err, constant.ErrorCodeInvalidRequest, "invalid buckets", http.StatusBadRequest)
+		}
+
+		date, err := parseDate(req.Date, time.UTC)
+		if err != nil {
+			return dto.GetDelegatorDistributionResponse{}, utils.WrapAppError(err, constant.ErrorCodeInvalidRequest, "invalid date", http.StatusBadRequest)
+		}
+
+		compareDate, err := parseDate(req.CompareDate, time.UTC)
+		if err != nil {
+			return dto.GetDelegatorDistributionResponse{}, utils.WrapAppError(err, constant.ErrorCodeInvalidRequest, "invalid compare date", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var distribution, compareDistribution []querier.GetDailyDelegatorDistributionByValidatorRow
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			distribution, err1 = v.getDelegatorDistribution(ctx, req.ValidatorAddress, boundaries, date)
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		if compareDate.Valid {
+			ewg.Go(func() error {
+				compareDistribution, err2 = v.getDelegatorDistribution(ctx, req.ValidatorAddress, boundaries, compareDate)
+				if err2 != nil {
+					return err2
+				}
+
+				return nil
+			})
+		}
+
+		if err := ewg.Wait(); err != nil {
+			return dto.GetDelegatorDistributionResponse{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator distribution", http.StatusUnprocessableEntity)
+		}
+
+		resp := toDelegatorDistributionResponse(buckets, distribution, compareDistribution, compareDate.Valid)
+		resp.Date = req.Date
+		resp.CompareDate = req.CompareDate
+
+		return resp, nil
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator distribution", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) GetDelegatorEvent(ctx context.Context, req dto.GetDelegatorEventRequest) (resp dto.PaginationResp[dto.GetDelegatorEventResponse], err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDelegatorEvent")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDelegatorEventCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetDelegatorEventResponse], error) {
+		if !isDelegatorEventType(req.Type) {
+			return dto.PaginationResp[dto.GetDelegatorEventResponse]{}, utils.WrapAppError(errInvalidEventType, constant.ErrorCodeInvalidRequest, "invalid event type", http.StatusBadRequest)
+		}
+
+		startDate, endDate, err := getDateFilter(req.From, req.To)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDelegatorEventResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidDateRange, "invalid date range", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var events []querier.GetDelegatorEventByValidatorRow
+		var countEvents int64
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			events, err1 = v.repo.GetDelegatorEventByValidator(ctx, querier.GetDelegatorEventByValidatorParams{
+				ValidatorAddress: req.ValidatorAddress,
+				Type:             req.Type,
+				StartDate:        startDate,
+				EndDate:          endDate,
+				Limit:            req.Limit,
+				Offset:           dto.GetOffSet(req.Page, req.Limit),
+			})
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		ewg.Go(func() error {
+			countEvents, err2 = v.repo.GetCountDelegatorEventByValidator(ctx, querier.GetCountDelegatorEventByValidatorParams{
+				ValidatorAddress: req.ValidatorAddress,
+				Type:             req.Type,
+				StartDate:        startDate,
+				EndDate:          endDate,
+			})
+			if err2 != nil {
+				return err2
+			}
+
+			return nil
+		})
+
+		if err := ewg.Wait(); err != nil {
+			return dto.PaginationResp[dto.GetDelegatorEventResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator event", http.StatusUnprocessableEntity)
+		}
+
+		return dto.ToPaginationResp(lo.Map(events, func(item querier.GetDelegatorEventByValidatorRow, _ int) dto.GetDelegatorEventResponse {
+			return dto.GetDelegatorEventResponse{
+				Date:             item.Date.Format(constant.DateFormat),
+				DelegatorAddress: item.DelegatorAddress,
+				Type:             item.Type,
+				Amount:           item.AmountUatom,
+			}
+		}), int(req.Page), int(req.Limit), int(countEvents)), nil
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegator event", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) GetDailyDelegatorEvent(ctx context.Context, req dto.GetDailyDelegatorEventRequest) (resp []dto.GetDailyDelegatorEventResponse, err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDailyDelegatorEvent")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDelegatorEventCacheKey, "", "", req), func(ctx context.Context) ([]dto.GetDailyDelegatorEventResponse, error) {
+		if !isDelegatorEventType(req.Type) {
+			return nil, utils.WrapAppError(errInvalidEventType, constant.ErrorCodeInvalidRequest, "invalid event type", http.StatusBadRequest)
+		}
+
+		startDate, endDate, err := getDateFilter(req.From, req.To)
+		if err != nil {
+			return nil, utils.WrapAppError(err, constant.ErrorCodeInvalidDateRange, "invalid date range", http.StatusBadRequest)
+		}
+
+		rows, err := v.repo.GetDailyDelegatorEventCountByValidator(ctx, querier.GetDailyDelegatorEventCountByValidatorParams{
+			ValidatorAddress: req.ValidatorAddress,
+			Type:             req.Type,
+			StartDate:        startDate,
+			EndDate:          endDate,
+		})
+		if err != nil {
+			return nil, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get daily delegator event", http.StatusUnprocessableEntity)
+		}
+
+		return lo.Map(rows, func(item querier.GetDailyDelegatorEventCountByValidatorRow, _ int) dto.GetDailyDelegatorEventResponse {
+			return dto.GetDailyDelegatorEventResponse{
+				Date:       item.Date.Format(constant.DateFormat),
+				Type:       item.Type,
+				Delegators: item.DelegatorCount,
+				Amount:     item.TotalAmount,
+			}
+		}), nil
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get daily delegator event", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) CompareValidator(ctx context.Context, req dto.CompareValidatorRequest) (resp dto.CompareValidatorResponse, err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.CompareValidator")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorCompareCacheKey, "", "", req), func(ctx context.Context) (dto.CompareValidatorResponse, error) {
+		if len(req.Addresses) == 0 || len(req.Addresses) > constant.MaxCompareValidators {
+			return dto.CompareValidatorResponse{}, utils.WrapAppError(errInvalidAddresses, constant.ErrorCodeInvalidRequest, "invalid addresses", http.StatusBadRequest)
+		}
+
+		startDate, endDate, err := v.getCompareDateRange(req.From, req.To)
+		if err != nil {
+			return dto.CompareValidatorResponse{}, utils.WrapAppError(err, constant.ErrorCodeInvalidDateRange, "invalid date range", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var totals []querier.GetDailyTotalByValidatorsRow
+		var commissions []querier.GetValidatorCommissionByValidatorsRow
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			// The day before the range is read as well, for the net flow of the first day
+			totals, err1 = v.repo.GetDailyTotalByValidators(ctx, querier.GetDailyTotalByValidatorsParams{
+				ValidatorAddresses: req.Addresses,
+				StartDate:          startDate.AddDate(0, 0, -1),
+				EndDate:            endDate,
+			})
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		ewg.Go(func() error {
+			commissions, err2 = v.repo.GetValidatorCommissionByValidators(ctx, querier.GetValidatorCommissionByValidatorsParams{
+				ValidatorAddresses: req.Addresses,
+				StartDate:          startDate,
+				EndDate:            endDate,
+			})
+			if err2 != nil {
+				return err2
+			}
+
+			return nil
+		})
+
+		if err := ewg.Wait(); err != nil {
+			return dto.CompareValidatorResponse{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to compare validator", http.StatusUnprocessableEntity)
+		}
+
+		return toCompareValidatorResponse(req.Addresses, startDate, endDate, totals, commissions), nil
+	}, analyticsCacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to compare validator", http.StatusUnprocessableEntity)
+}
+
+// GetDelegationAsOf returns the balance of every delegator of the validator at a point in time, now by default,
+// or with compareTo the change of every balance between the two points in time
+func (v *validatorSvc) GetDelegationAsOf(ctx context.Context, req dto.GetDelegationAsOfRequest) (resp dto.PaginationResp[dto.GetDelegationAsOfResponse], err error) {
+	ctx, span := utils.StartSpan(ctx, "validatorSvc.GetDelegationAsOf")
+	defer utils.EndSpan(span, &err)
+	defer logError(ctx, v.logger, &err)
+
+	resp, err = utils.GetOrSetData(ctx, v.cacheSvc, utils.BuildCacheKey(constant.ValidatorDelegationAsOfCacheKey, "", "", req), func(ctx context.Context) (dto.PaginationResp[dto.GetDelegationAsOfResponse], error) {
+		loc, err := v.getLocation(req.Timezone)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+		}
+
+		at, err := parseTimestamp(req.At)
+		if err != nil {
+			return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidRequest, "invalid at", http.StatusBadRequest)
+		}
+
+		if req.CompareTo != "" {
+			compareTo, err := parseTimestamp(req.CompareTo)
+			if err != nil {
+				return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(err, constant.ErrorCodeInvalidRequest, "invalid compareTo", http.StatusBadRequest)
+			}
+
+			return v.getDelegationDiff(ctx, req, at, compareTo)
+		}
+
+		if !lo.Contains([]string{constant.DelegationSortByAmount, constant.DelegationSortByAmountDesc}, req.SortBy) {
+			return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(errInvalidAsOfSort, constant.ErrorCodeInvalidRequest, "invalid sort", http.StatusBadRequest)
+		}
+
+		ewg := errgroup.Group{}
+		var delegations []querier.GetDelegationAsOfByValidatorRow
+		var countDelegations int64
+		var err1, err2 error
+
+		ewg.Go(func() error {
+			delegations, err1 = v.repo.GetDelegationAsOfByValidator(ctx, querier.GetDelegationAsOfByValidatorParams{
+				ValidatorAddress: req.ValidatorAddress,
+				At:               at,
+				LatestRunOnly:    !v.isCDCStorage(),
+				JobName:          constant.HourlyCollectJobName,
+				SortBy:           req.SortBy,
+				Limit:            req.Limit,
+				Offset:           dto.GetOffSet(req.Page, req.Limit),
+			})
+			if err1 != nil {
+				return err1
+			}
+
+			return nil
+		})
+
+		ewg.Go(func() error {
+			countDelegations, err2 = v.repo.GetCountDelegationAsOfByValidator(ctx, querier.GetCountDelegationAsOfByValidatorParams{
+				ValidatorAddress: req.ValidatorAddress,
+				At:               at,
+				LatestRunOnly:    !v.isCDCStorage(),
+				JobName:          constant.HourlyCollectJobName,
+			})
+			if err2 != nil {
+				return err2
+			}
+
+			return nil
+		})
+
+		if err := ewg.Wait(); err != nil {
+			return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegation as of", http.StatusUnprocessableEntity)
+		}
+
+		return dto.ToPaginationResp(lo.Map(delegations, func(item querier.GetDelegationAsOfByValidatorRow, _ int) dto.GetDelegationAsOfResponse {
+			return dto.GetDelegationAsOfResponse{
+				DelegatorAddress: item.DelegatorAddress,
+				Amount:           item.AmountUatom,
+				Timestamp:        item.Timestamp.In(loc).Format(constant.TimeFormat),
+			}
+		}), int(req.Page), int(req.Limit), int(countDelegations)), nil
+	}, cacheOptions(v.config)...)
+	return resp, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegation as of", http.StatusUnprocessableEntity)
+}
+
+func (v *validatorSvc) getDelegationDiff(ctx context.Context, req dto.GetDelegationAsOfRequest, at time.Time, compareTo time.Time) (dto.PaginationResp[dto.GetDelegationAsOfResponse], error) {
+	if !lo.Contains([]string{constant.DelegationSortByAmount, constant.DelegationSortByAmountDesc, constant.DelegationSortByChange, constant.DelegationSortByChangeDesc}, req.SortBy) {
+		return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(errInvalidDiffSort, constant.ErrorCodeInvalidRequest, "invalid sort", http.StatusBadRequest)
+	}
+
+	ewg := errgroup.Group{}
+	var diffs []querier.GetDelegationDiffByValidatorRow
+	var countDiffs int64
+	var err1, err2 error
+
+	ewg.Go(func() error {
+		diffs, err1 = v.repo.GetDelegationDiffByValidator(ctx, querier.GetDelegationDiffByValidatorParams{
+			ValidatorAddress: req.ValidatorAddress,
+			At:               at,
+			CompareTo:        compareTo,
+			LatestRunOnly:    !v.isCDCStorage(),
+			JobName:          constant.HourlyCollectJobName,
+			SortBy:           req.SortBy,
+			Limit:            req.Limit,
+			Offset:           dto.GetOffSet(req.Page, req.Limit),
+		})
+		if err1 != nil {
+			return err1
+		}
+
+		return nil
+	})
+
+	ewg.Go(func() error {
+		countDiffs, err2 = v.repo.GetCountDelegationDiffByValidator(ctx, querier.GetCountDelegationDiffByValidatorParams{
+			ValidatorAddress: req.ValidatorAddress,
+			At:               at,
+			CompareTo:        compareTo,
+			LatestRunOnly:    !v.isCDCStorage(),
+			JobName:          constant.HourlyCollectJobName,
+		})
+		if err2 != nil {
+			return err2
+		}
+
+		return nil
+	})
+
+	if err := ewg.Wait(); err != nil {
+		return dto.PaginationResp[dto.GetDelegationAsOfResponse]{}, utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get delegation diff", http.StatusUnprocessableEntity)
+	}
+
+	return dto.ToPaginationResp(lo.Map(diffs, func(item querier.GetDelegationDiffByValidatorRow, _ int) dto.GetDelegationAsOfResponse {
+		return dto.GetDelegationAsOfResponse{
+			DelegatorAddress: item.DelegatorAddress,
+			Amount:           item.AmountUatom,
+			CompareAmount:    lo.ToPtr(item.CompareAmountUatom),
+			Change:           lo.ToPtr(item.ChangeUatom),
+		}
+	}), int(req.Page), int(req.Limit), int(countDiffs)), nil
+}
+
+// getCompareDateRange defaults the range to the last DefaultCompareDays days up to today in the reporting timezone
+func (v *validatorSvc) getCompareDateRange(from string, to string) (time.Time, time.Time, error) {
+	startDate, endDate, err := getDateFilter(from, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if !endDate.Valid {
+		loc, err := v.getLocation("")
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		endDate.Time = utils.GetDateInLocation(utils.GetCurrentTimeInUTC(), loc)
+	}
+
+	if !startDate.Valid {
+		startDate.Time = endDate.Time.AddDate(0, 0, 1-constant.DefaultCompareDays)
+	}
+
+	if startDate.Time.After(endDate.Time) {
+		return time.Time{}, time.Time{}, errInvalidDateRange
+	}
+
+	if !startDate.Time.AddDate(0, 0, constant.MaxCompareDays).After(endDate.Time) {
+		return time.Time{}, time.Time{}, errInvalidCompareRange
+	}
+
+	return startDate.Time, endDate.Time, nil
+}
+
+// getDelegatorDistribution reads the distribution on date from daily_aggregates, or the current one when date is not set
+func (v *validatorSvc) getDelegatorDistribution(ctx context.Context, validatorAddress string, boundaries []int64, date sql.NullTime) ([]querier.GetDailyDelegatorDistributionByValidatorRow, error) {
+	if date.Valid {
+		return v.repo.GetDailyDelegatorDistributionByValidator(ctx, querier.GetDailyDelegatorDistributionByValidatorParams{
+			Boundaries:       boundaries,
+			ValidatorAddress: validatorAddress,
+			Date:             date.Time,
+		})
+	}
+
+	rows, err := v.repo.GetCurrentDelegatorDistributionByValidator(ctx, querier.GetCurrentDelegatorDistributionByValidatorParams{
+		Boundaries:       boundaries,
+		ValidatorAddress: validatorAddress,
+		LatestRunOnly:    !v.isCDCStorage(),
+		JobName:          constant.HourlyCollectJobName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(rows, func(item querier.GetCurrentDelegatorDistributionByValidatorRow, _ int) querier.GetDailyDelegatorDistributionByValidatorRow {
+		return querier.GetDailyDelegatorDistributionByValidatorRow(item)
+	}), nil
+}
+
+// parseDistributionBuckets parses the comma separated bucket boundaries in ATOM and returns them with their value in uatom
+func parseDistributionBuckets(buckets string) ([]float64, []int64, error) {
+	parts := strings.Split(buckets, ",")
+	if len(parts) > constant.MaxDistributionBuckets {
+		return nil, nil, errInvalidBuckets
+	}
+
+	atoms := make([]float64, 0, len(parts))
+	boundaries := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		atom, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		boundary := int64(math.Round(atom * constant.UatomPerAtom))
+		if boundary <= 0 || (len(boundaries) > 0 && boundary <= boundaries[len(boundaries)-1]) {
+			return nil, nil, errInvalidBuckets
+		}
+
+		atoms = append(atoms, atom)
+		boundaries = append(boundaries, boundary)
+	}
+
+	return atoms, boundaries, nil
+}
+
+// toDelegatorDistributionResponse spreads the rows over every bucket, bucket i holds the balances from boundary i-1 up to boundary i
+func toDelegatorDistributionResponse(buckets []float64, rows []querier.GetDailyDelegatorDistributionByValidatorRow, compareRows []querier.GetDailyDelegatorDistributionByValidatorRow, compare bool) dto.GetDelegatorDistributionResponse {
+	current := lo.KeyBy(rows, func(item querier.GetDailyDelegatorDistributionByValidatorRow) int32 {
+		return item.Bucket
+	})
+	previous := lo.KeyBy(compareRows, func(item querier.GetDailyDelegatorDistributionByValidatorRow) int32 {
+		return item.Bucket
+	})
+
+	resp := dto.GetDelegatorDistributionResponse{
+		Buckets: make([]dto.GetDelegatorDistributionBucketResponse, 0, len(buckets)+1),
+	}
+	var compareDelegators, compareTotal int64
+	for i := 0; i <= len(buckets); i++ {
+		bucket := dto.GetDelegatorDistributionBucketResponse{
+			Delegators: current[int32(i)].DelegatorCount,
+			Amount:     current[int32(i)].TotalAmount,
+		}
+		if i > 0 {
+			bucket.Min = buckets[i-1]
+		}
+		if i < len(buckets) {
+			bucket.Max = lo.ToPtr(buckets[i])
+		}
+		if compare {
+			bucket.DelegatorsChange = lo.ToPtr(bucket.Delegators - previous[int32(i)].DelegatorCount)
+			bucket.AmountChange = lo.ToPtr(bucket.Amount - previous[int32(i)].TotalAmount)
+		}
+
+		resp.Delegators += bucket.Delegators
+		resp.Total += bucket.Amount
+		compareDelegators += previous[int32(i)].DelegatorCount
+		compareTotal += previous[int32(i)].TotalAmount
+		resp.Buckets = append(resp.Buckets, bucket)
+	}
+
+	if compare {
+		resp.DelegatorsChange = lo.ToPtr(resp.Delegators - compareDelegators)
+		resp.TotalChange = lo.ToPtr(resp.Total - compareTotal)
+	}
+
+	return resp
+}
+
+// toCompareValidatorResponse aligns the daily rows of every validator on the same dates, a day without data is left null
+func toCompareValidatorResponse(
+	addresses []string,
+	startDate time.Time,
+	endDate time.Time,
+	totals []querier.GetDailyTotalByValidatorsRow,
+	commissions []querier.GetValidatorCommissionByValidatorsRow,
+) dto.CompareValidatorResponse {
+	totalByDay := lo.KeyBy(totals, func(item querier.GetDailyTotalByValidatorsRow) string {
+		return item.ValidatorAddress + "|" + item.Date.Format(constant.DateFormat)
+	})
+	commissionByDay := lo.KeyBy(commissions, func(item querier.GetValidatorCommissionByValidatorsRow) string {
+		return item.ValidatorAddress + "|" + item.Date.Format(constant.DateFormat)
 	})
-	utils.PanicIfAppError(err, "failed to get delegator history by validator", http.StatusUnprocessableEntity)
+
+	resp := dto.CompareValidatorResponse{
+		From:       startDate.Format(constant.DateFormat),
+		To:         endDate.Format(constant.DateFormat),
+		Validators: make([]dto.CompareValidatorSeriesResponse, 0, len(addresses)),
+	}
+	for _, address := range addresses {
+		series := dto.CompareValidatorSeriesResponse{
+			Address: address,
+		}
+
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			key := address + "|" + date.Format(constant.DateFormat)
+			point := dto.CompareValidatorPointResponse{
+				Date: date.Format(constant.DateFormat),
+			}
+
+			if total, ok := totalByDay[key]; ok {
+				point.Total = lo.ToPtr(total.TotalAmount)
+				point.Delegators = lo.ToPtr(total.DelegatorCount)
+
+				if previous, ok := totalByDay[address+"|"+date.AddDate(0, 0, -1).Format(constant.DateFormat)]; ok {
+					point.NetFlow = lo.ToPtr(total.TotalAmount - previous.TotalAmount)
+				}
+			}
+
+			if commission, ok := commissionByDay[key]; ok {
+				point.Commission = lo.ToPtr(commission.CommissionRate)
+			}
+
+			series.Series = append(series.Series, point)
+		}
+
+		resp.Validators = append(resp.Validators, series)
+	}
 
 	return resp
 }
+
+// parseTimestamp reads a timestamp in TimeFormat, an empty one being now
+func parseTimestamp(timestamp string) (time.Time, error) {
+	if timestamp == "" {
+		return time.Now().UTC(), nil
+	}
+
+	return time.Parse(constant.TimeFormat, timestamp)
+}
+
+// isDelegatorEventType reports whether eventType is a known event type, an empty type matches every event
+func isDelegatorEventType(eventType string) bool {
+	return lo.Contains([]string{"", constant.DelegatorEventTypeNew, constant.DelegatorEventTypeChurned, constant.DelegatorEventTypeReturned}, eventType)
+}
+
+func toHourlySnapshotResponse(item querier.GetDelegationSnapshotByValidatorRow, loc *time.Location) dto.GetHourlySnapshotResponse {
+	return dto.GetHourlySnapshotResponse{
+		Address:   item.DelegatorAddress,
+		Amount:    item.AmountUatom,
+		Change:    item.ChangeUatom,
+		Date:      item.Timestamp.In(loc).Format(constant.DateFormat),
+		Timestamp: item.Timestamp.In(loc).Format(constant.TimeFormat),
+	}
+}
+
+func toDailySnapshotResponse(item querier.GetDailyAggregateByValidatorRow) dto.GetDailySnapshotResponse {
+	return dto.GetDailySnapshotResponse{
+		Address: item.DelegatorAddress,
+		Date:    item.Date.Format(constant.DateFormat),
+		Total:   item.TotalAmount,
+	}
+}
+
+func toDelegatorHistoryResponse(item querier.GetDelegatorHistoryByValidatorRow, loc *time.Location) dto.GetDelegatorHistoryResponse {
+	return dto.GetDelegatorHistoryResponse{
+		Timestamp: item.Timestamp.In(loc).Format(constant.TimeFormat),
+		Amount:    item.AmountUatom,
+		Change:    item.ChangeUatom,
+	}
+}
+
+// toDelegatorCohortResponse groups the per period rows, which are ordered by cohort and period, into one entry per cohort.
+func toDelegatorCohortResponse(rows []querier.GetDelegatorCohortRetentionByValidatorRow) []dto.GetDelegatorCohortResponse {
+	cohorts := []dto.GetDelegatorCohortResponse{}
+
+	for _, row := range rows {
+		cohortStart := row.CohortStart.Format(constant.DateFormat)
+		if len(cohorts) == 0 || cohorts[len(cohorts)-1].CohortStart != cohortStart {
+			cohorts = append(cohorts, dto.GetDelegatorCohortResponse{
+				CohortStart:   cohortStart,
+				Delegators:    row.CohortSize,
+				InitialAmount: row.InitialAmount,
+				Periods:       []dto.GetDelegatorCohortPeriodResponse{},
+			})
+		}
+
+		cohort := &cohorts[len(cohorts)-1]
+		cohort.Periods = append(cohort.Periods, dto.GetDelegatorCohortPeriodResponse{
+			Period:             row.PeriodOffset,
+			RetainedDelegators: row.RetainedCount,
+			RetainedRate:       getRate(row.RetainedCount, row.CohortSize),
+			RemainingAmount:    row.RemainingAmount,
+			RemainingStakeRate: getRate(row.RemainingAmount, row.InitialAmount),
+		})
+	}
+
+	return cohorts
+}
+
+func getRate(part int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(part) / float64(total)
+}
+
+// getLocation resolves the tz query param, falling back to the reporting timezone.
+func (v *validatorSvc) getLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		timezone = v.config.ReportingTimezone
+	}
+
+	return time.LoadLocation(timezone)
+}
+
+func (v *validatorSvc) isCDCStorage() bool {
+	return v.config.SnapshotStorageMode == constant.SnapshotStorageModeCDC
+}
+
+// In CDC mode only changed balances are stored, so the hourly state of every
+// delegator is rebuilt from their last change at or before each run.
+func (v *validatorSvc) getDelegationSnapshotByValidator(ctx context.Context, arg querier.GetDelegationSnapshotByValidatorParams) ([]querier.GetDelegationSnapshotByValidatorRow, error) {
+	if !v.isCDCStorage() {
+		return v.repo.GetDelegationSnapshotByValidator(ctx, arg)
+	}
+
+	rows, err := v.repo.GetReconstructedDelegationSnapshotByValidator(ctx, querier.GetReconstructedDelegationSnapshotByValidatorParams{
+		ValidatorAddress: arg.ValidatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		Limit:            arg.Limit,
+		Offset:           arg.Offset,
+	})
+	return lo.Map(rows, func(item querier.GetReconstructedDelegationSnapshotByValidatorRow, _ int) querier.GetDelegationSnapshotByValidatorRow {
+		return querier.GetDelegationSnapshotByValidatorRow(item)
+	}), err
+}
+
+func (v *validatorSvc) getCountDelegationSnapshotByValidator(ctx context.Context, validatorAddress string) (int64, error) {
+	if !v.isCDCStorage() {
+		return v.repo.GetCountDelegationSnapshotByValidator(ctx, validatorAddress)
+	}
+
+	return v.repo.GetCountReconstructedDelegationSnapshotByValidator(ctx, querier.GetCountReconstructedDelegationSnapshotByValidatorParams{
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+	})
+}
+
+func (v *validatorSvc) getDelegatorHistoryByValidator(ctx context.Context, arg querier.GetDelegatorHistoryByValidatorParams) ([]querier.GetDelegatorHistoryByValidatorRow, error) {
+	if !v.isCDCStorage() {
+		return v.repo.GetDelegatorHistoryByValidator(ctx, arg)
+	}
+
+	rows, err := v.repo.GetReconstructedDelegatorHistoryByValidator(ctx, querier.GetReconstructedDelegatorHistoryByValidatorParams{
+		ValidatorAddress: arg.ValidatorAddress,
+		DelegatorAddress: arg.DelegatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		SortBy:           arg.SortBy,
+		Limit:            arg.Limit,
+		Offset:           arg.Offset,
+	})
+	return lo.Map(rows, func(item querier.GetReconstructedDelegatorHistoryByValidatorRow, _ int) querier.GetDelegatorHistoryByValidatorRow {
+		return querier.GetDelegatorHistoryByValidatorRow(item)
+	}), err
+}
+
+func (v *validatorSvc) getCountDelegatorHistoryByValidator(ctx context.Context, arg querier.GetCountDelegatorHistoryByValidatorParams) (int64, error) {
+	if !v.isCDCStorage() {
+		return v.repo.GetCountDelegatorHistoryByValidator(ctx, arg)
+	}
+
+	return v.repo.GetCountReconstructedDelegatorHistoryByValidator(ctx, querier.GetCountReconstructedDelegatorHistoryByValidatorParams{
+		ValidatorAddress: arg.ValidatorAddress,
+		DelegatorAddress: arg.DelegatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+	})
+}
+
+// checkDailyTimezoneRetained rejects a timezone other than the reporting one once the retention job removed hourly runs of the validator,
+// since the days before the retention window only survive as daily aggregates of the reporting timezone and cannot be bucketed again.
+func (v *validatorSvc) checkDailyTimezoneRetained(ctx context.Context, loc *time.Location, validatorAddress string) error {
+	if loc.String() == v.config.ReportingTimezone {
+		return nil
+	}
+
+	removed, err := v.repo.GetExistsSchedulerRunWithRowsAffected(ctx, querier.GetExistsSchedulerRunWithRowsAffectedParams{
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.RetentionJobName,
+	})
+	if err != nil {
+		return utils.WrapAppError(err, constant.ErrorCodeQueryFailed, "failed to get retention scheduler run", http.StatusUnprocessableEntity)
+	}
+	if removed {
+		return utils.WrapAppError(errTimezoneRetention, constant.ErrorCodeInvalidTimezone, "invalid timezone", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Daily aggregates are stored with the reporting timezone day boundary, any other
+// timezone is bucketed on the fly from the hourly runs that are still retained.
+func (v *validatorSvc) getDailyAggregateByValidator(ctx context.Context, loc *time.Location, arg querier.GetDailyAggregateByValidatorParams) ([]querier.GetDailyAggregateByValidatorRow, error) {
+	if loc.String() == v.config.ReportingTimezone {
+		return v.repo.GetDailyAggregateByValidator(ctx, arg)
+	}
+
+	rows, err := v.repo.GetDailyAggregateInTimezoneByValidator(ctx, querier.GetDailyAggregateInTimezoneByValidatorParams{
+		DayTimezone:      loc.String(),
+		ValidatorAddress: arg.ValidatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+		Limit:            arg.Limit,
+		Offset:           arg.Offset,
+	})
+	return lo.Map(rows, func(item querier.GetDailyAggregateInTimezoneByValidatorRow, _ int) querier.GetDailyAggregateByValidatorRow {
+		return querier.GetDailyAggregateByValidatorRow(item)
+	}), err
+}
+
+func (v *validatorSvc) getCountDailyAggregateByValidator(ctx context.Context, loc *time.Location, validatorAddress string) (int64, error) {
+	if loc.String() == v.config.ReportingTimezone {
+		return v.repo.GetCountDailyAggregateByValidator(ctx, validatorAddress)
+	}
+
+	return v.repo.GetCountDailyAggregateInTimezoneByValidator(ctx, querier.GetCountDailyAggregateInTimezoneByValidatorParams{
+		DayTimezone:      loc.String(),
+		ValidatorAddress: validatorAddress,
+		JobName:          constant.HourlyCollectJobName,
+	})
+}
@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
-	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,11 +16,29 @@ import (
 	"github.com/gadhittana01/cosmos-validation-tracking/utils"
 	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
 	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var errInvalidReq = errors.New("invalid request")
 
+// assertAppError checks err is an AppError responded with statusCode and code, errString includes the wrapped cause.
+func assertAppError(t *testing.T, err error, statusCode int, code string, errString string) {
+	t.Helper()
+
+	var appErr *utils.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, statusCode, appErr.StatusCode)
+		assert.Equal(t, code, appErr.Code)
+		assert.EqualError(t, err, errString)
+	}
+}
+
 func initValidatorSvc(
 	t *testing.T,
 	ctrl *gomock.Controller,
@@ -29,7 +48,7 @@ func initValidatorSvc(
 	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
 	cacheSvc := utils.InitCacheSvc(t, config, mockLogger)
 
-	return NewValidatorSvc(mockRepo, mockLogger, cacheSvc), mockRepo, mockLogger, cacheSvc
+	return NewValidatorSvc(mockRepo, config, mockLogger, cacheSvc), mockRepo, mockLogger, cacheSvc
 }
 
 func TestGetHourlySnapshot(t *testing.T) {
@@ -44,7 +63,7 @@ func TestGetHourlySnapshot(t *testing.T) {
 		Limit:            10,
 		Page:             1,
 	}
-	timestamp := time.Now()
+	timestamp := time.Now().UTC()
 	response := dto.GetHourlySnapshotResponse{
 		Address:   "cosmos1...",
 		Amount:    1000,
@@ -71,14 +90,16 @@ func TestGetHourlySnapshot(t *testing.T) {
 
 		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
 
-		resp := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		resp, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		assert.NoError(t, err)
 
 		assert.NotEmpty(t, resp)
 		assert.Equal(t, response, resp.Data[0])
 	})
 
 	t.Run("success get hourly snapshot (from cache)", func(t *testing.T) {
-		resp := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		resp, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		assert.NoError(t, err)
 
 		assert.NotEmpty(t, resp)
 		assert.Equal(t, response, resp.Data[0])
@@ -102,12 +123,9 @@ func TestGetHourlySnapshot(t *testing.T) {
 
 		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(0), errInvalidReq).Times(1)
 
-		assert.PanicsWithValue(t, utils.AppError{
-			StatusCode: http.StatusUnprocessableEntity,
-			Message:    fmt.Sprintf("invalid request|%s", "failed to get hourly snapshot"),
-		}, func() {
-			validatorSvcMock.GetHourlySnapshot(ctx, request)
-		})
+		_, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get hourly snapshot: invalid request")
 	})
 
 	t.Run("failed get hourly snapshot", func(t *testing.T) {
@@ -119,14 +137,164 @@ func TestGetHourlySnapshot(t *testing.T) {
 
 		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
 
-		assert.PanicsWithValue(t, utils.AppError{
-			StatusCode: http.StatusUnprocessableEntity,
-			Message:    fmt.Sprintf("invalid request|%s", "failed to get hourly snapshot"),
-		}, func() {
-			validatorSvcMock.GetHourlySnapshot(ctx, request)
-		})
+		_, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get hourly snapshot: invalid request")
+	})
+
+	t.Run("success get hourly snapshot (tz)", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "Asia/Jakarta"
+		loc, _ := time.LoadLocation(tzRequest.Timezone)
+
+		mockRepo.EXPECT().GetDelegationSnapshotByValidator(gomock.Any(), querier.GetDelegationSnapshotByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			Limit:            request.Limit,
+			Offset:           dto.GetOffSet(request.Page, request.Limit),
+		}).Return([]querier.GetDelegationSnapshotByValidatorRow{
+			{
+				DelegatorAddress: response.Address,
+				AmountUatom:      response.Amount,
+				Timestamp:        timestamp,
+				ChangeUatom:      response.Change,
+			},
+		}, nil).Times(1)
+
+		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetHourlySnapshot(ctx, tzRequest)
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, resp)
+		assert.Equal(t, timestamp.In(loc).Format(constant.DateFormat), resp.Data[0].Date)
+		assert.Equal(t, timestamp.In(loc).Format(constant.TimeFormat), resp.Data[0].Timestamp)
+	})
+
+	t.Run("failed get hourly snapshot (invalid tz)", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "Mars/Olympus"
+
+		_, err := validatorSvcMock.GetHourlySnapshot(ctx, tzRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidTimezone, "invalid timezone: unknown time zone Mars/Olympus")
+	})
+
+	t.Run("success get hourly snapshot (cdc storage)", func(t *testing.T) {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		defer func() {
+			config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+		}()
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorHourlySnapshotCacheKey)
+
+		mockRepo.EXPECT().GetReconstructedDelegationSnapshotByValidator(gomock.Any(), querier.GetReconstructedDelegationSnapshotByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Limit:            request.Limit,
+			Offset:           dto.GetOffSet(request.Page, request.Limit),
+		}).Return([]querier.GetReconstructedDelegationSnapshotByValidatorRow{
+			{
+				DelegatorAddress: response.Address,
+				AmountUatom:      response.Amount,
+				Timestamp:        timestamp,
+				ChangeUatom:      response.Change,
+			},
+		}, nil).Times(1)
+
+		mockRepo.EXPECT().GetCountReconstructedDelegationSnapshotByValidator(gomock.Any(), querier.GetCountReconstructedDelegationSnapshotByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, resp)
+		assert.Equal(t, 1, resp.Total)
+		assert.Equal(t, response, resp.Data[0])
+	})
+
+}
+
+func TestGetHourlySnapshotCacheLoad(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetHourlySnapshotRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		Limit:            10,
+		Page:             1,
+	}
+	cacheKey := utils.BuildCacheKey(constant.ValidatorHourlySnapshotCacheKey, "", "", request)
+	rows := []querier.GetDelegationSnapshotByValidatorRow{
+		{
+			DelegatorAddress: "cosmos1...",
+			AmountUatom:      1000,
+			Timestamp:        time.Now().UTC(),
+			ChangeUatom:      1000,
+		},
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("concurrent misses share a single query", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegationSnapshotByValidator(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg querier.GetDelegationSnapshotByValidatorParams) ([]querier.GetDelegationSnapshotByValidatorRow, error) {
+			time.Sleep(100 * time.Millisecond)
+			return rows, nil
+		}).Times(1)
+
+		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+				assert.NoError(t, err)
+				assert.Len(t, resp.Data, 1)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("waits for the replica holding the refresh lock", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorHourlySnapshotCacheKey)
+		release, acquired, err := cacheSvc.TryLock(ctx, cacheKey)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		cached := dto.ToPaginationResp([]dto.GetHourlySnapshotResponse{{Address: "cosmos1cached"}}, 1, 10, 1)
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			assert.NoError(t, cacheSvc.Set(ctx, cacheKey, cached))
+			release()
+		}()
+
+		resp, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, "cosmos1cached", resp.Data[0].Address)
 	})
 
+	t.Run("takes over the refresh lock released without an entry", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorHourlySnapshotCacheKey)
+		release, acquired, err := cacheSvc.TryLock(ctx, cacheKey)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			release()
+		}()
+
+		mockRepo.EXPECT().GetDelegationSnapshotByValidator(gomock.Any(), gomock.Any()).Return(rows, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, rows[0].DelegatorAddress, resp.Data[0].Address)
+	})
 }
 
 func TestGetDailySnapshot(t *testing.T) {
@@ -142,7 +310,7 @@ func TestGetDailySnapshot(t *testing.T) {
 		Limit:            10,
 		Page:             1,
 	}
-	timestamp := time.Now()
+	timestamp := time.Now().UTC()
 	response := dto.GetDailySnapshotResponse{
 		Address: "cosmos1...",
 		Date:    timestamp.Format(constant.DateFormat),
@@ -165,14 +333,16 @@ func TestGetDailySnapshot(t *testing.T) {
 
 		mockRepo.EXPECT().GetCountDailyAggregateByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
 
-		resp := validatorSvcMock.GetDailySnapshot(ctx, request)
+		resp, err := validatorSvcMock.GetDailySnapshot(ctx, request)
+		assert.NoError(t, err)
 
 		assert.NotEmpty(t, resp)
 		assert.Equal(t, response, resp.Data[0])
 	})
 
 	t.Run("success get daily snapshot (from cache)", func(t *testing.T) {
-		resp := validatorSvcMock.GetDailySnapshot(ctx, request)
+		resp, err := validatorSvcMock.GetDailySnapshot(ctx, request)
+		assert.NoError(t, err)
 
 		assert.NotEmpty(t, resp)
 		assert.Equal(t, response, resp.Data[0])
@@ -195,12 +365,60 @@ func TestGetDailySnapshot(t *testing.T) {
 
 		mockRepo.EXPECT().GetCountDailyAggregateByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(0), errInvalidReq).Times(1)
 
-		assert.PanicsWithValue(t, utils.AppError{
-			StatusCode: http.StatusUnprocessableEntity,
-			Message:    fmt.Sprintf("invalid request|%s", "failed to get daily aggregate by validator"),
-		}, func() {
-			validatorSvcMock.GetDailySnapshot(ctx, request)
-		})
+		_, err := validatorSvcMock.GetDailySnapshot(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get daily aggregate by validator: invalid request")
+	})
+
+	t.Run("success get daily snapshot (tz)", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "Europe/Berlin"
+
+		mockRepo.EXPECT().GetExistsSchedulerRunWithRowsAffected(gomock.Any(), querier.GetExistsSchedulerRunWithRowsAffectedParams{
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.RetentionJobName,
+		}).Return(false, nil).Times(1)
+		mockRepo.EXPECT().GetDailyAggregateInTimezoneByValidator(gomock.Any(), querier.GetDailyAggregateInTimezoneByValidatorParams{
+			DayTimezone:      tzRequest.Timezone,
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Limit:            request.Limit,
+			Offset:           dto.GetOffSet(request.Page, request.Limit),
+		}).Return([]querier.GetDailyAggregateInTimezoneByValidatorRow{
+			{
+				DelegatorAddress: response.Address,
+				Date:             timestamp,
+				TotalAmount:      response.Total,
+			},
+		}, nil).Times(1)
+
+		mockRepo.EXPECT().GetCountDailyAggregateInTimezoneByValidator(gomock.Any(), querier.GetCountDailyAggregateInTimezoneByValidatorParams{
+			DayTimezone:      tzRequest.Timezone,
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDailySnapshot(ctx, tzRequest)
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, resp)
+		assert.Equal(t, response, resp.Data[0])
+	})
+
+	t.Run("failed get daily snapshot (tz before the retention window)", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "America/New_York"
+
+		mockRepo.EXPECT().GetExistsSchedulerRunWithRowsAffected(gomock.Any(), querier.GetExistsSchedulerRunWithRowsAffectedParams{
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.RetentionJobName,
+		}).Return(true, nil).Times(1)
+		mockRepo.EXPECT().GetDailyAggregateInTimezoneByValidator(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().GetCountDailyAggregateInTimezoneByValidator(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := validatorSvcMock.GetDailySnapshot(ctx, tzRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidTimezone, "invalid timezone: "+errTimezoneRetention.Error())
 	})
 
 	t.Run("failed get daily snapshot", func(t *testing.T) {
@@ -212,12 +430,9 @@ func TestGetDailySnapshot(t *testing.T) {
 
 		mockRepo.EXPECT().GetCountDailyAggregateByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
 
-		assert.PanicsWithValue(t, utils.AppError{
-			StatusCode: http.StatusUnprocessableEntity,
-			Message:    fmt.Sprintf("invalid request|%s", "failed to get daily aggregate by validator"),
-		}, func() {
-			validatorSvcMock.GetDailySnapshot(ctx, request)
-		})
+		_, err := validatorSvcMock.GetDailySnapshot(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get daily aggregate by validator: invalid request")
 	})
 
 }
@@ -235,7 +450,7 @@ func TestGetDelegatorHistory(t *testing.T) {
 		Limit:            10,
 		Page:             1,
 	}
-	timestamp := time.Now()
+	timestamp := time.Now().UTC()
 	response := dto.GetDelegatorHistoryResponse{
 		Timestamp: timestamp.Format(constant.TimeFormat),
 		Amount:    1000,
@@ -262,14 +477,16 @@ func TestGetDelegatorHistory(t *testing.T) {
 			DelegatorAddress: request.DelegatorAddress,
 		}).Return(int64(1), nil).Times(1)
 
-		resp := validatorSvcMock.GetDelegatorHistory(ctx, request)
+		resp, err := validatorSvcMock.GetDelegatorHistory(ctx, request)
+		assert.NoError(t, err)
 
 		assert.NotEmpty(t, resp)
 		assert.Equal(t, response, resp.Data[0])
 	})
 
 	t.Run("success get delegator history (from cache)", func(t *testing.T) {
-		resp := validatorSvcMock.GetDelegatorHistory(ctx, request)
+		resp, err := validatorSvcMock.GetDelegatorHistory(ctx, request)
+		assert.NoError(t, err)
 
 		assert.NotEmpty(t, resp)
 		assert.Equal(t, response, resp.Data[0])
@@ -296,12 +513,9 @@ func TestGetDelegatorHistory(t *testing.T) {
 			DelegatorAddress: request.DelegatorAddress,
 		}).Return(int64(0), errInvalidReq).Times(1)
 
-		assert.PanicsWithValue(t, utils.AppError{
-			StatusCode: http.StatusUnprocessableEntity,
-			Message:    fmt.Sprintf("invalid request|%s", "failed to get delegator history by validator"),
-		}, func() {
-			validatorSvcMock.GetDelegatorHistory(ctx, request)
-		})
+		_, err := validatorSvcMock.GetDelegatorHistory(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator history by validator: invalid request")
 	})
 
 	t.Run("failed get delegator history", func(t *testing.T) {
@@ -317,12 +531,1017 @@ func TestGetDelegatorHistory(t *testing.T) {
 			DelegatorAddress: request.DelegatorAddress,
 		}).Return(int64(1), nil).Times(1)
 
-		assert.PanicsWithValue(t, utils.AppError{
-			StatusCode: http.StatusUnprocessableEntity,
-			Message:    fmt.Sprintf("invalid request|%s", "failed to get delegator history by validator"),
-		}, func() {
-			validatorSvcMock.GetDelegatorHistory(ctx, request)
+		_, err := validatorSvcMock.GetDelegatorHistory(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator history by validator: invalid request")
+	})
+
+	t.Run("success get delegator history (cdc storage)", func(t *testing.T) {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		defer func() {
+			config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+		}()
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorDelegatorHistoryCacheKey)
+
+		mockRepo.EXPECT().GetReconstructedDelegatorHistoryByValidator(gomock.Any(), querier.GetReconstructedDelegatorHistoryByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			DelegatorAddress: request.DelegatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			Limit:            request.Limit,
+			Offset:           dto.GetOffSet(request.Page, request.Limit),
+		}).Return([]querier.GetReconstructedDelegatorHistoryByValidatorRow{
+			{
+				Timestamp:   timestamp,
+				AmountUatom: response.Amount,
+				ChangeUatom: response.Change,
+			},
+		}, nil).Times(1)
+
+		mockRepo.EXPECT().GetCountReconstructedDelegatorHistoryByValidator(gomock.Any(), querier.GetCountReconstructedDelegatorHistoryByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			DelegatorAddress: request.DelegatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegatorHistory(ctx, request)
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, resp)
+		assert.Equal(t, response, resp.Data[0])
+	})
+
+}
+
+func TestExportHourlySnapshot(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, _ := initValidatorSvc(t, ctrl, config)
+	mockutl.LoggerMock(mockLogger)
+	request := dto.ExportHourlySnapshotRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+	}
+	timestamp := time.Now().UTC()
+	row := querier.GetDelegationSnapshotByValidatorRow{
+		DelegatorAddress: "cosmos1...",
+		AmountUatom:      1000,
+		Timestamp:        timestamp,
+		ChangeUatom:      1000,
+	}
+	response := dto.GetHourlySnapshotResponse{
+		Address:   row.DelegatorAddress,
+		Amount:    row.AmountUatom,
+		Change:    row.ChangeUatom,
+		Date:      timestamp.Format(constant.DateFormat),
+		Timestamp: timestamp.Format(constant.TimeFormat),
+	}
+
+	t.Run("success export hourly snapshot", func(t *testing.T) {
+		mockRepo.EXPECT().ExportDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, validatorAddress string, fn func(querier.GetDelegationSnapshotByValidatorRow) error) error {
+				return fn(row)
+			}).Times(1)
+
+		var resp []dto.GetHourlySnapshotResponse
+		err := validatorSvcMock.ExportHourlySnapshot(ctx, request, func(item dto.GetHourlySnapshotResponse) error {
+			resp = append(resp, item)
+			return nil
+		})
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetHourlySnapshotResponse{response}, resp)
+	})
+
+	t.Run("success export hourly snapshot (cdc storage)", func(t *testing.T) {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		defer func() {
+			config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+		}()
+
+		mockRepo.EXPECT().ExportReconstructedDelegationSnapshotByValidator(gomock.Any(), querier.GetCountReconstructedDelegationSnapshotByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, arg querier.GetCountReconstructedDelegationSnapshotByValidatorParams, fn func(querier.GetReconstructedDelegationSnapshotByValidatorRow) error) error {
+				return fn(querier.GetReconstructedDelegationSnapshotByValidatorRow(row))
+			}).Times(1)
+
+		var resp []dto.GetHourlySnapshotResponse
+		err := validatorSvcMock.ExportHourlySnapshot(ctx, request, func(item dto.GetHourlySnapshotResponse) error {
+			resp = append(resp, item)
+			return nil
+		})
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetHourlySnapshotResponse{response}, resp)
+	})
+
+	t.Run("failed export hourly snapshot", func(t *testing.T) {
+		mockRepo.EXPECT().ExportDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress, gomock.Any()).Return(errInvalidReq).Times(1)
+
+		err := validatorSvcMock.ExportHourlySnapshot(ctx, request, func(item dto.GetHourlySnapshotResponse) error {
+			return nil
+		})
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to export hourly snapshot: invalid request")
+	})
+}
+
+func TestExportDailySnapshot(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, _ := initValidatorSvc(t, ctrl, config)
+	mockutl.LoggerMock(mockLogger)
+	request := dto.ExportDailySnapshotRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+	}
+	timestamp := time.Now().UTC()
+	row := querier.GetDailyAggregateByValidatorRow{
+		DelegatorAddress: "cosmos1...",
+		Date:             timestamp,
+		TotalAmount:      1000,
+	}
+	response := dto.GetDailySnapshotResponse{
+		Address: row.DelegatorAddress,
+		Date:    timestamp.Format(constant.DateFormat),
+		Total:   row.TotalAmount,
+	}
+
+	t.Run("success export daily snapshot", func(t *testing.T) {
+		mockRepo.EXPECT().ExportDailyAggregateByValidator(gomock.Any(), request.ValidatorAddress, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, validatorAddress string, fn func(querier.GetDailyAggregateByValidatorRow) error) error {
+				return fn(row)
+			}).Times(1)
+
+		var resp []dto.GetDailySnapshotResponse
+		err := validatorSvcMock.ExportDailySnapshot(ctx, request, func(item dto.GetDailySnapshotResponse) error {
+			resp = append(resp, item)
+			return nil
 		})
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDailySnapshotResponse{response}, resp)
+	})
+
+	t.Run("success export daily snapshot (tz)", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "Europe/Berlin"
+
+		mockRepo.EXPECT().GetExistsSchedulerRunWithRowsAffected(gomock.Any(), querier.GetExistsSchedulerRunWithRowsAffectedParams{
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.RetentionJobName,
+		}).Return(false, nil).Times(1)
+
+		mockRepo.EXPECT().ExportDailyAggregateInTimezoneByValidator(gomock.Any(), querier.GetCountDailyAggregateInTimezoneByValidatorParams{
+			DayTimezone:      tzRequest.Timezone,
+			ValidatorAddress: request.ValidatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+		}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, arg querier.GetCountDailyAggregateInTimezoneByValidatorParams, fn func(querier.GetDailyAggregateInTimezoneByValidatorRow) error) error {
+				return fn(querier.GetDailyAggregateInTimezoneByValidatorRow(row))
+			}).Times(1)
+
+		var resp []dto.GetDailySnapshotResponse
+		err := validatorSvcMock.ExportDailySnapshot(ctx, tzRequest, func(item dto.GetDailySnapshotResponse) error {
+			resp = append(resp, item)
+			return nil
+		})
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDailySnapshotResponse{response}, resp)
+	})
+
+	t.Run("failed export daily snapshot (tz before the retention window)", func(t *testing.T) {
+		tzRequest := request
+		tzRequest.Timezone = "Europe/Berlin"
+
+		mockRepo.EXPECT().GetExistsSchedulerRunWithRowsAffected(gomock.Any(), gomock.AssignableToTypeOf(querier.GetExistsSchedulerRunWithRowsAffectedParams{})).Return(true, nil).Times(1)
+		mockRepo.EXPECT().ExportDailyAggregateInTimezoneByValidator(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := validatorSvcMock.ExportDailySnapshot(ctx, tzRequest, func(item dto.GetDailySnapshotResponse) error {
+			return nil
+		})
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidTimezone, "invalid timezone: "+errTimezoneRetention.Error())
+	})
+
+	t.Run("failed export daily snapshot", func(t *testing.T) {
+		mockRepo.EXPECT().ExportDailyAggregateByValidator(gomock.Any(), request.ValidatorAddress, gomock.Any()).Return(errInvalidReq).Times(1)
+
+		err := validatorSvcMock.ExportDailySnapshot(ctx, request, func(item dto.GetDailySnapshotResponse) error {
+			return nil
+		})
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to export daily snapshot: invalid request")
+	})
+}
+
+func TestExportDelegatorHistory(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, _ := initValidatorSvc(t, ctrl, config)
+	mockutl.LoggerMock(mockLogger)
+	request := dto.ExportDelegatorHistoryRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		DelegatorAddress: "cosmos1...",
+		SortBy:           "date",
+	}
+	timestamp := time.Now().UTC()
+	row := querier.GetDelegatorHistoryByValidatorRow{
+		Timestamp:   timestamp,
+		AmountUatom: 1000,
+		ChangeUatom: 1000,
+	}
+	response := dto.GetDelegatorHistoryResponse{
+		Timestamp: timestamp.Format(constant.TimeFormat),
+		Amount:    row.AmountUatom,
+		Change:    row.ChangeUatom,
+	}
+
+	t.Run("success export delegator history", func(t *testing.T) {
+		mockRepo.EXPECT().ExportDelegatorHistoryByValidator(gomock.Any(), querier.ExportDelegatorHistoryByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			DelegatorAddress: request.DelegatorAddress,
+			SortBy:           request.SortBy,
+		}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, arg querier.ExportDelegatorHistoryByValidatorParams, fn func(querier.GetDelegatorHistoryByValidatorRow) error) error {
+				return fn(row)
+			}).Times(1)
+
+		var resp []dto.GetDelegatorHistoryResponse
+		err := validatorSvcMock.ExportDelegatorHistory(ctx, request, func(item dto.GetDelegatorHistoryResponse) error {
+			resp = append(resp, item)
+			return nil
+		})
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDelegatorHistoryResponse{response}, resp)
+	})
+
+	t.Run("success export delegator history (cdc storage)", func(t *testing.T) {
+		config.SnapshotStorageMode = constant.SnapshotStorageModeCDC
+		defer func() {
+			config.SnapshotStorageMode = constant.SnapshotStorageModeFull
+		}()
+
+		mockRepo.EXPECT().ExportReconstructedDelegatorHistoryByValidator(gomock.Any(), querier.ExportReconstructedDelegatorHistoryByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			DelegatorAddress: request.DelegatorAddress,
+			JobName:          constant.HourlyCollectJobName,
+			SortBy:           request.SortBy,
+		}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, arg querier.ExportReconstructedDelegatorHistoryByValidatorParams, fn func(querier.GetReconstructedDelegatorHistoryByValidatorRow) error) error {
+				return fn(querier.GetReconstructedDelegatorHistoryByValidatorRow(row))
+			}).Times(1)
+
+		var resp []dto.GetDelegatorHistoryResponse
+		err := validatorSvcMock.ExportDelegatorHistory(ctx, request, func(item dto.GetDelegatorHistoryResponse) error {
+			resp = append(resp, item)
+			return nil
+		})
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDelegatorHistoryResponse{response}, resp)
+	})
+
+	t.Run("failed export delegator history", func(t *testing.T) {
+		mockRepo.EXPECT().ExportDelegatorHistoryByValidator(gomock.Any(), gomock.Any(), gomock.Any()).Return(errInvalidReq).Times(1)
+
+		err := validatorSvcMock.ExportDelegatorHistory(ctx, request, func(item dto.GetDelegatorHistoryResponse) error {
+			return nil
+		})
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to export delegator history: invalid request")
 	})
+}
 
+func TestGetDelegatorCohort(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetDelegatorCohortRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		Period:           constant.CohortPeriodMonth,
+		Periods:          2,
+	}
+	rows := []querier.GetDelegatorCohortRetentionByValidatorRow{
+		{CohortStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), PeriodOffset: 0, CohortSize: 4, RetainedCount: 4, InitialAmount: 1000, RemainingAmount: 1200},
+		{CohortStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), PeriodOffset: 1, CohortSize: 4, RetainedCount: 2, InitialAmount: 1000, RemainingAmount: 500},
+		{CohortStart: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), PeriodOffset: 0, CohortSize: 1, RetainedCount: 0, InitialAmount: 300, RemainingAmount: 0},
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get delegator cohort", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegatorCohortRetentionByValidator(gomock.Any(), gomock.AssignableToTypeOf(querier.GetDelegatorCohortRetentionByValidatorParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetDelegatorCohortRetentionByValidatorParams) ([]querier.GetDelegatorCohortRetentionByValidatorRow, error) {
+			assert.True(t, arg.LatestRunOnly)
+			assert.Equal(t, request.ValidatorAddress, arg.ValidatorAddress)
+			assert.Equal(t, constant.HourlyCollectJobName, arg.JobName)
+			assert.Equal(t, constant.CohortPeriodMonth, arg.Period)
+			assert.Equal(t, config.ReportingTimezone, arg.DayTimezone)
+			assert.Equal(t, int32(2), arg.PeriodCount)
+			return rows, nil
+		}).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegatorCohort(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDelegatorCohortResponse{
+			{
+				CohortStart:   "2025-01-01",
+				Delegators:    4,
+				InitialAmount: 1000,
+				Periods: []dto.GetDelegatorCohortPeriodResponse{
+					{Period: 0, RetainedDelegators: 4, RetainedRate: 1, RemainingAmount: 1200, RemainingStakeRate: 1.2},
+					{Period: 1, RetainedDelegators: 2, RetainedRate: 0.5, RemainingAmount: 500, RemainingStakeRate: 0.5},
+				},
+			},
+			{
+				CohortStart:   "2025-02-01",
+				Delegators:    1,
+				InitialAmount: 300,
+				Periods: []dto.GetDelegatorCohortPeriodResponse{
+					{Period: 0, RetainedDelegators: 0, RetainedRate: 0, RemainingAmount: 0, RemainingStakeRate: 0},
+				},
+			},
+		}, resp)
+	})
+
+	t.Run("success get delegator cohort (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.GetDelegatorCohort(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp, 2)
+	})
+
+	t.Run("invalid cohort period", func(t *testing.T) {
+		periodRequest := request
+		periodRequest.Period = "year"
+
+		_, err := validatorSvcMock.GetDelegatorCohort(ctx, periodRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidRequest, "invalid cohort period: period must be week or month")
+	})
+
+	t.Run("failed get delegator cohort retention", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorCohortCacheKey)
+
+		mockRepo.EXPECT().GetDelegatorCohortRetentionByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+
+		_, err := validatorSvcMock.GetDelegatorCohort(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator cohort: invalid request")
+	})
+}
+
+func TestGetConcentrationMetric(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetConcentrationMetricRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		From:             "2025-04-01",
+		To:               "2025-04-30",
+		Limit:            10,
+		Page:             1,
+	}
+	rows := []querier.GetDailyConcentrationMetricByValidatorRow{
+		{
+			Date:            time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			DelegatorCount:  3,
+			TotalAmount:     1000,
+			GiniCoefficient: 0.4,
+			HerfindahlIndex: 0.38,
+			Top10Share:      1,
+			Top100Share:     1,
+			Nakamoto33:      1,
+			Nakamoto50:      2,
+		},
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get concentration metric", func(t *testing.T) {
+		mockRepo.EXPECT().GetDailyConcentrationMetricByValidator(gomock.Any(), gomock.AssignableToTypeOf(querier.GetDailyConcentrationMetricByValidatorParams{})).DoAndReturn(func(ctx context.Context, arg querier.GetDailyConcentrationMetricByValidatorParams) ([]querier.GetDailyConcentrationMetricByValidatorRow, error) {
+			assert.Equal(t, request.ValidatorAddress, arg.ValidatorAddress)
+			assert.Equal(t, sql.NullTime{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true}, arg.StartDate)
+			assert.Equal(t, sql.NullTime{Time: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), Valid: true}, arg.EndDate)
+			assert.Equal(t, int32(10), arg.Limit)
+			assert.Equal(t, int32(0), arg.Offset)
+			return rows, nil
+		}).Times(1)
+
+		mockRepo.EXPECT().GetCountDailyConcentrationMetricByValidator(gomock.Any(), gomock.AssignableToTypeOf(querier.GetCountDailyConcentrationMetricByValidatorParams{})).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetConcentrationMetric(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetConcentrationMetricResponse{
+			{
+				Date:            "2025-04-01",
+				Delegators:      3,
+				Total:           1000,
+				GiniCoefficient: 0.4,
+				HerfindahlIndex: 0.38,
+				Top10Share:      1,
+				Top100Share:     1,
+				Nakamoto33:      1,
+				Nakamoto50:      2,
+			},
+		}, resp.Data)
+		assert.Equal(t, 1, resp.Total)
+	})
+
+	t.Run("success get concentration metric (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.GetConcentrationMetric(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Data, 1)
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		rangeRequest := request
+		rangeRequest.From = "2025-05-01"
+
+		_, err := validatorSvcMock.GetConcentrationMetric(ctx, rangeRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidDateRange, "invalid date range: from is after to")
+	})
+
+	t.Run("failed get concentration metric", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorConcentrationCacheKey)
+
+		mockRepo.EXPECT().GetDailyConcentrationMetricByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetCountDailyConcentrationMetricByValidator(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		_, err := validatorSvcMock.GetConcentrationMetric(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get concentration metric: invalid request")
+	})
+}
+
+func TestGetDelegatorDistribution(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetDelegatorDistributionRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		Buckets:          "1,10",
+	}
+	boundaries := []int64{1000000, 10000000}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get current delegator distribution", func(t *testing.T) {
+		mockRepo.EXPECT().GetCurrentDelegatorDistributionByValidator(gomock.Any(), querier.GetCurrentDelegatorDistributionByValidatorParams{
+			Boundaries:       boundaries,
+			ValidatorAddress: request.ValidatorAddress,
+			LatestRunOnly:    true,
+			JobName:          constant.HourlyCollectJobName,
+		}).Return([]querier.GetCurrentDelegatorDistributionByValidatorRow{
+			{Bucket: 0, DelegatorCount: 3, TotalAmount: 1500000},
+			{Bucket: 2, DelegatorCount: 1, TotalAmount: 20000000},
+		}, nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegatorDistribution(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, dto.GetDelegatorDistributionResponse{
+			Delegators: 4,
+			Total:      21500000,
+			Buckets: []dto.GetDelegatorDistributionBucketResponse{
+				{Min: 0, Max: lo.ToPtr(1.0), Delegators: 3, Amount: 1500000},
+				{Min: 1, Max: lo.ToPtr(10.0), Delegators: 0, Amount: 0},
+				{Min: 10, Max: nil, Delegators: 1, Amount: 20000000},
+			},
+		}, resp)
+	})
+
+	t.Run("success get current delegator distribution (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.GetDelegatorDistribution(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Buckets, 3)
+	})
+
+	t.Run("success get daily delegator distribution with compare date", func(t *testing.T) {
+		dateRequest := request
+		dateRequest.Date = "2025-04-08"
+		dateRequest.CompareDate = "2025-04-01"
+
+		mockRepo.EXPECT().GetDailyDelegatorDistributionByValidator(gomock.Any(), querier.GetDailyDelegatorDistributionByValidatorParams{
+			Boundaries:       boundaries,
+			ValidatorAddress: request.ValidatorAddress,
+			Date:             time.Date(2025, 4, 8, 0, 0, 0, 0, time.UTC),
+		}).Return([]querier.GetDailyDelegatorDistributionByValidatorRow{
+			{Bucket: 1, DelegatorCount: 2, TotalAmount: 6000000},
+		}, nil).Times(1)
+		mockRepo.EXPECT().GetDailyDelegatorDistributionByValidator(gomock.Any(), querier.GetDailyDelegatorDistributionByValidatorParams{
+			Boundaries:       boundaries,
+			ValidatorAddress: request.ValidatorAddress,
+			Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		}).Return([]querier.GetDailyDelegatorDistributionByValidatorRow{
+			{Bucket: 0, DelegatorCount: 1, TotalAmount: 500000},
+			{Bucket: 1, DelegatorCount: 1, TotalAmount: 4000000},
+		}, nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegatorDistribution(ctx, dateRequest)
+		assert.NoError(t, err)
+
+		assert.Equal(t, dto.GetDelegatorDistributionResponse{
+			Date:             "2025-04-08",
+			CompareDate:      "2025-04-01",
+			Delegators:       2,
+			Total:            6000000,
+			DelegatorsChange: lo.ToPtr(int64(0)),
+			TotalChange:      lo.ToPtr(int64(1500000)),
+			Buckets: []dto.GetDelegatorDistributionBucketResponse{
+				{Min: 0, Max: lo.ToPtr(1.0), Delegators: 0, Amount: 0, DelegatorsChange: lo.ToPtr(int64(-1)), AmountChange: lo.ToPtr(int64(-500000))},
+				{Min: 1, Max: lo.ToPtr(10.0), Delegators: 2, Amount: 6000000, DelegatorsChange: lo.ToPtr(int64(1)), AmountChange: lo.ToPtr(int64(2000000))},
+				{Min: 10, Max: nil, Delegators: 0, Amount: 0, DelegatorsChange: lo.ToPtr(int64(0)), AmountChange: lo.ToPtr(int64(0))},
+			},
+		}, resp)
+	})
+
+	t.Run("invalid buckets", func(t *testing.T) {
+		bucketsRequest := request
+		bucketsRequest.Buckets = "10,1"
+
+		_, err := validatorSvcMock.GetDelegatorDistribution(ctx, bucketsRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidRequest, "invalid buckets: buckets must be ascending positive numbers")
+	})
+
+	t.Run("failed get delegator distribution", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorDistributionCacheKey)
+
+		mockRepo.EXPECT().GetCurrentDelegatorDistributionByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+
+		_, err := validatorSvcMock.GetDelegatorDistribution(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator distribution: invalid request")
+	})
+}
+
+func TestGetDelegatorEvent(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetDelegatorEventRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		Type:             constant.DelegatorEventTypeNew,
+		From:             "2025-04-01",
+		Limit:            10,
+		Page:             1,
+	}
+	rows := []querier.GetDelegatorEventByValidatorRow{
+		{
+			Date:             time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			DelegatorAddress: "cosmos1...",
+			Type:             constant.DelegatorEventTypeNew,
+			AmountUatom:      8000,
+		},
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get delegator event", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegatorEventByValidator(gomock.Any(), querier.GetDelegatorEventByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			Type:             constant.DelegatorEventTypeNew,
+			StartDate:        sql.NullTime{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			Offset:           0,
+			Limit:            10,
+		}).Return(rows, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegatorEventByValidator(gomock.Any(), gomock.AssignableToTypeOf(querier.GetCountDelegatorEventByValidatorParams{})).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegatorEvent(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDelegatorEventResponse{
+			{Date: "2025-04-01", DelegatorAddress: "cosmos1...", Type: constant.DelegatorEventTypeNew, Amount: 8000},
+		}, resp.Data)
+		assert.Equal(t, 1, resp.Total)
+	})
+
+	t.Run("success get delegator event (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.GetDelegatorEvent(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Data, 1)
+	})
+
+	t.Run("invalid event type", func(t *testing.T) {
+		typeRequest := request
+		typeRequest.Type = "left"
+
+		_, err := validatorSvcMock.GetDelegatorEvent(ctx, typeRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidRequest, "invalid event type: type must be new, churned or returned")
+	})
+
+	t.Run("failed get delegator event", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorDelegatorEventCacheKey)
+
+		mockRepo.EXPECT().GetDelegatorEventByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetCountDelegatorEventByValidator(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		_, err := validatorSvcMock.GetDelegatorEvent(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegator event: invalid request")
+	})
+}
+
+func TestGetDailyDelegatorEvent(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetDailyDelegatorEventRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		From:             "2025-04-01",
+		To:               "2025-04-30",
+	}
+	rows := []querier.GetDailyDelegatorEventCountByValidatorRow{
+		{Date: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Type: constant.DelegatorEventTypeChurned, DelegatorCount: 2, TotalAmount: 12000},
+		{Date: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Type: constant.DelegatorEventTypeNew, DelegatorCount: 1, TotalAmount: 8000},
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get daily delegator event", func(t *testing.T) {
+		mockRepo.EXPECT().GetDailyDelegatorEventCountByValidator(gomock.Any(), querier.GetDailyDelegatorEventCountByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			StartDate:        sql.NullTime{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			EndDate:          sql.NullTime{Time: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+		}).Return(rows, nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDailyDelegatorEvent(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDailyDelegatorEventResponse{
+			{Date: "2025-04-01", Type: constant.DelegatorEventTypeChurned, Delegators: 2, Amount: 12000},
+			{Date: "2025-04-01", Type: constant.DelegatorEventTypeNew, Delegators: 1, Amount: 8000},
+		}, resp)
+	})
+
+	t.Run("success get daily delegator event (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.GetDailyDelegatorEvent(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp, 2)
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		rangeRequest := request
+		rangeRequest.From = "2025-05-01"
+
+		_, err := validatorSvcMock.GetDailyDelegatorEvent(ctx, rangeRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidDateRange, "invalid date range: from is after to")
+	})
+
+	t.Run("failed get daily delegator event", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorDelegatorEventCacheKey)
+
+		mockRepo.EXPECT().GetDailyDelegatorEventCountByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+
+		_, err := validatorSvcMock.GetDailyDelegatorEvent(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get daily delegator event: invalid request")
+	})
+}
+
+func TestCompareValidator(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.CompareValidatorRequest{
+		Addresses: []string{"cosmosvaloper1a...", "cosmosvaloper1b..."},
+		From:      "2025-04-01",
+		To:        "2025-04-02",
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success compare validator", func(t *testing.T) {
+		mockRepo.EXPECT().GetDailyTotalByValidators(gomock.Any(), querier.GetDailyTotalByValidatorsParams{
+			ValidatorAddresses: request.Addresses,
+			StartDate:          time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			EndDate:            time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+		}).Return([]querier.GetDailyTotalByValidatorsRow{
+			{ValidatorAddress: "cosmosvaloper1a...", Date: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), DelegatorCount: 2, TotalAmount: 1000},
+			{ValidatorAddress: "cosmosvaloper1a...", Date: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), DelegatorCount: 3, TotalAmount: 1500},
+			{ValidatorAddress: "cosmosvaloper1a...", Date: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), DelegatorCount: 3, TotalAmount: 1200},
+			{ValidatorAddress: "cosmosvaloper1b...", Date: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), DelegatorCount: 1, TotalAmount: 700},
+		}, nil).Times(1)
+		mockRepo.EXPECT().GetValidatorCommissionByValidators(gomock.Any(), querier.GetValidatorCommissionByValidatorsParams{
+			ValidatorAddresses: request.Addresses,
+			StartDate:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:            time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+		}).Return([]querier.GetValidatorCommissionByValidatorsRow{
+			{ValidatorAddress: "cosmosvaloper1a...", Date: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), CommissionRate: 0.05},
+		}, nil).Times(1)
+
+		resp, err := validatorSvcMock.CompareValidator(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, dto.CompareValidatorResponse{
+			From: "2025-04-01",
+			To:   "2025-04-02",
+			Validators: []dto.CompareValidatorSeriesResponse{
+				{
+					Address: "cosmosvaloper1a...",
+					Series: []dto.CompareValidatorPointResponse{
+						{Date: "2025-04-01", Total: lo.ToPtr(int64(1500)), Delegators: lo.ToPtr(int64(3)), NetFlow: lo.ToPtr(int64(500))},
+						{Date: "2025-04-02", Total: lo.ToPtr(int64(1200)), Delegators: lo.ToPtr(int64(3)), NetFlow: lo.ToPtr(int64(-300)), Commission: lo.ToPtr(0.05)},
+					},
+				},
+				{
+					Address: "cosmosvaloper1b...",
+					Series: []dto.CompareValidatorPointResponse{
+						{Date: "2025-04-01"},
+						{Date: "2025-04-02", Total: lo.ToPtr(int64(700)), Delegators: lo.ToPtr(int64(1))},
+					},
+				},
+			},
+		}, resp)
+	})
+
+	t.Run("success compare validator (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.CompareValidator(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Validators, 2)
+	})
+
+	t.Run("success compare validator with default date range", func(t *testing.T) {
+		defaultRequest := request
+		defaultRequest.From = ""
+		defaultRequest.To = ""
+		endDate := utils.GetDateInLocation(time.Now(), time.UTC)
+
+		mockRepo.EXPECT().GetDailyTotalByValidators(gomock.Any(), querier.GetDailyTotalByValidatorsParams{
+			ValidatorAddresses: request.Addresses,
+			StartDate:          endDate.AddDate(0, 0, -constant.DefaultCompareDays),
+			EndDate:            endDate,
+		}).Return([]querier.GetDailyTotalByValidatorsRow{}, nil).Times(1)
+		mockRepo.EXPECT().GetValidatorCommissionByValidators(gomock.Any(), gomock.Any()).Return([]querier.GetValidatorCommissionByValidatorsRow{}, nil).Times(1)
+
+		resp, err := validatorSvcMock.CompareValidator(ctx, defaultRequest)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Validators[0].Series, constant.DefaultCompareDays)
+		assert.Equal(t, endDate.Format(constant.DateFormat), resp.To)
+	})
+
+	t.Run("invalid addresses", func(t *testing.T) {
+		addressRequest := request
+		addressRequest.Addresses = []string{}
+
+		_, err := validatorSvcMock.CompareValidator(ctx, addressRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidRequest, "invalid addresses: addresses must list between 1 and 10 validators")
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		rangeRequest := request
+		rangeRequest.From = "2024-01-01"
+
+		_, err := validatorSvcMock.CompareValidator(ctx, rangeRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidDateRange, "invalid date range: date range must not exceed 366 days")
+	})
+
+	t.Run("failed compare validator", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorCompareCacheKey)
+
+		mockRepo.EXPECT().GetDailyTotalByValidators(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetValidatorCommissionByValidators(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+		_, err := validatorSvcMock.CompareValidator(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to compare validator: invalid request")
+	})
+}
+
+func TestGetDelegationAsOf(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	request := dto.GetDelegationAsOfRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		At:               "2025-04-01T12:00:00Z",
+		SortBy:           constant.DelegationSortByAmountDesc,
+		Limit:            10,
+		Page:             1,
+	}
+	diffRequest := request
+	diffRequest.At = "2025-04-02T00:00:00Z"
+	diffRequest.CompareTo = "2025-04-01T00:00:00Z"
+	diffRequest.SortBy = constant.DelegationSortByChangeDesc
+	rows := []querier.GetDelegationAsOfByValidatorRow{
+		{
+			DelegatorAddress: "cosmos1...",
+			AmountUatom:      8000,
+			Timestamp:        time.Date(2025, 4, 1, 11, 0, 0, 0, time.UTC),
+		},
+	}
+	diffRows := []querier.GetDelegationDiffByValidatorRow{
+		{
+			DelegatorAddress:   "cosmos1...",
+			AmountUatom:        8000,
+			CompareAmountUatom: 5000,
+			ChangeUatom:        3000,
+		},
+	}
+	mockutl.LoggerMock(mockLogger)
+
+	t.Run("success get delegation as of", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegationAsOfByValidator(gomock.Any(), querier.GetDelegationAsOfByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			At:               time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+			LatestRunOnly:    true,
+			JobName:          constant.HourlyCollectJobName,
+			SortBy:           constant.DelegationSortByAmountDesc,
+			Offset:           0,
+			Limit:            10,
+		}).Return(rows, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegationAsOfByValidator(gomock.Any(), gomock.AssignableToTypeOf(querier.GetCountDelegationAsOfByValidatorParams{})).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegationAsOf(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDelegationAsOfResponse{
+			{DelegatorAddress: "cosmos1...", Amount: 8000, Timestamp: "2025-04-01T11:00:00Z"},
+		}, resp.Data)
+		assert.Equal(t, 1, resp.Total)
+	})
+
+	t.Run("success get delegation as of (from cache)", func(t *testing.T) {
+		resp, err := validatorSvcMock.GetDelegationAsOf(ctx, request)
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Data, 1)
+	})
+
+	t.Run("success get delegation diff", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegationDiffByValidator(gomock.Any(), querier.GetDelegationDiffByValidatorParams{
+			ValidatorAddress: request.ValidatorAddress,
+			At:               time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+			CompareTo:        time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			LatestRunOnly:    true,
+			JobName:          constant.HourlyCollectJobName,
+			SortBy:           constant.DelegationSortByChangeDesc,
+			Offset:           0,
+			Limit:            10,
+		}).Return(diffRows, nil).Times(1)
+		mockRepo.EXPECT().GetCountDelegationDiffByValidator(gomock.Any(), gomock.AssignableToTypeOf(querier.GetCountDelegationDiffByValidatorParams{})).Return(int64(1), nil).Times(1)
+
+		resp, err := validatorSvcMock.GetDelegationAsOf(ctx, diffRequest)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []dto.GetDelegationAsOfResponse{
+			{DelegatorAddress: "cosmos1...", Amount: 8000, CompareAmount: lo.ToPtr(int64(5000)), Change: lo.ToPtr(int64(3000))},
+		}, resp.Data)
+		assert.Equal(t, 1, resp.Total)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		sortRequest := request
+		sortRequest.SortBy = constant.DelegationSortByChange
+
+		_, err := validatorSvcMock.GetDelegationAsOf(ctx, sortRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidRequest, "invalid sort: sortBy must be amount or -amount")
+	})
+
+	t.Run("invalid diff sort", func(t *testing.T) {
+		sortRequest := diffRequest
+		sortRequest.SortBy = "date"
+
+		_, err := validatorSvcMock.GetDelegationAsOf(ctx, sortRequest)
+
+		assertAppError(t, err, http.StatusBadRequest, constant.ErrorCodeInvalidRequest, "invalid sort: sortBy must be amount, -amount, change or -change")
+	})
+
+	t.Run("failed get delegation as of", func(t *testing.T) {
+		cacheSvc.DelByPrefix(ctx, constant.ValidatorDelegationAsOfCacheKey)
+
+		mockRepo.EXPECT().GetDelegationAsOfByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetCountDelegationAsOfByValidator(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		_, err := validatorSvcMock.GetDelegationAsOf(ctx, request)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegation as of: invalid request")
+	})
+
+	t.Run("failed get delegation diff", func(t *testing.T) {
+		mockRepo.EXPECT().GetDelegationDiffByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetCountDelegationDiffByValidator(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+		_, err := validatorSvcMock.GetDelegationAsOf(ctx, diffRequest)
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, constant.ErrorCodeQueryFailed, "failed to get delegation diff: invalid request")
+	})
+}
+
+func TestValidatorSvcTracing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	validatorSvcMock, mockRepo, mockLogger, cacheSvc := initValidatorSvc(t, ctrl, config)
+	mockutl.LoggerMock(mockLogger)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previousProvider)
+
+	request := dto.GetHourlySnapshotRequest{
+		ValidatorAddress: "cosmosvaloper1...",
+		Limit:            10,
+		Page:             1,
+	}
+	spanByName := func(spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+		for _, span := range spans {
+			if span.Name == name {
+				return span
+			}
+		}
+		t.Fatalf("span %s not found", name)
+		return tracetest.SpanStub{}
+	}
+
+	t.Run("success trace service, cache and repository calls", func(t *testing.T) {
+		exporter.Reset()
+		cacheSvc.DelByPrefix(context.Background(), constant.ValidatorHourlySnapshotCacheKey)
+		ctx, root := provider.Tracer("test").Start(context.Background(), "GET /api/v1/validators/{validatorAddress}/hourly")
+
+		var repoSpanContext trace.SpanContext
+		mockRepo.EXPECT().GetDelegationSnapshotByValidator(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg querier.GetDelegationSnapshotByValidatorParams) ([]querier.GetDelegationSnapshotByValidatorRow, error) {
+			repoSpanContext = trace.SpanContextFromContext(ctx)
+			return []querier.GetDelegationSnapshotByValidatorRow{{DelegatorAddress: "cosmos1...", AmountUatom: 1000}}, nil
+		}).Times(1)
+		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
+
+		_, err := validatorSvcMock.GetHourlySnapshot(ctx, request)
+		root.End()
+
+		assert.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		svcSpan := spanByName(spans, "validatorSvc.GetHourlySnapshot")
+		assert.Equal(t, root.SpanContext().SpanID(), svcSpan.Parent.SpanID())
+		assert.Equal(t, root.SpanContext().TraceID(), svcSpan.SpanContext.TraceID())
+		assert.Equal(t, svcSpan.SpanContext.SpanID(), spanByName(spans, "cache.Get").Parent.SpanID())
+		assert.Equal(t, svcSpan.SpanContext.SpanID(), spanByName(spans, "cache.Set").Parent.SpanID())
+		assert.Equal(t, svcSpan.SpanContext.SpanID(), repoSpanContext.SpanID())
+	})
+
+	t.Run("failed get hourly snapshot records the error on the span", func(t *testing.T) {
+		exporter.Reset()
+		cacheSvc.DelByPrefix(context.Background(), constant.ValidatorHourlySnapshotCacheKey)
+
+		mockRepo.EXPECT().GetDelegationSnapshotByValidator(gomock.Any(), gomock.Any()).Return(nil, errInvalidReq).Times(1)
+		mockRepo.EXPECT().GetCountDelegationSnapshotByValidator(gomock.Any(), request.ValidatorAddress).Return(int64(1), nil).Times(1)
+
+		_, err := validatorSvcMock.GetHourlySnapshot(context.Background(), request)
+
+		assert.Error(t, err)
+
+		svcSpan := spanByName(exporter.GetSpans(), "validatorSvc.GetHourlySnapshot")
+		assert.Equal(t, codes.Error, svcSpan.Status.Code)
+		assert.Len(t, svcSpan.Events, 1)
+	})
 }
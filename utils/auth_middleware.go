@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+)
+
+var (
+	errMissingAPIKey     = errors.New("missing api key")
+	errInsufficientScope = errors.New("api key does not have the required scope")
+)
+
+type authContextKey int
+
+const apiKeyIDKey authContextKey = iota
+
+// APIKeyIdentity is a verified API key, its ID naming it without revealing the key.
+type APIKeyIdentity struct {
+	ID     string
+	Scopes []string
+}
+
+// APIKeyVerifier resolves a plain API key to its identity, failing with an unauthorized error when the key is unknown or revoked.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (APIKeyIdentity, error)
+}
+
+type AuthMiddlewareSvc interface {
+	RequireScope(scope string) func(next http.Handler) http.Handler
+}
+
+type AuthMiddlewareSvcImpl struct {
+	verifier APIKeyVerifier
+	logger   LoggerSvc
+}
+
+func NewAuthMiddlewareSvc(verifier APIKeyVerifier, logger LoggerSvc) AuthMiddlewareSvc {
+	return &AuthMiddlewareSvcImpl{
+		verifier: verifier,
+		logger:   logger,
+	}
+}
+
+// RequireScope only lets a request through when its API key grants the scope, the admin scope granting every other one.
+func (s *AuthMiddlewareSvcImpl) RequireScope(scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := getAPIKey(r)
+			if key == "" {
+				GenerateProblemResp(w, r, s.logger, WrapAppError(errMissingAPIKey, constant.ErrorCodeUnauthorized, "unauthorized", http.StatusUnauthorized))
+				return
+			}
+
+			identity, err := s.verifier.VerifyAPIKey(r.Context(), key)
+			if err != nil {
+				GenerateProblemResp(w, r, s.logger, err)
+				return
+			}
+
+			if !slices.Contains(identity.Scopes, scope) && !slices.Contains(identity.Scopes, constant.APIKeyScopeAdmin) {
+				GenerateProblemResp(w, r, s.logger, WrapAppError(errInsufficientScope, constant.ErrorCodeForbidden, "forbidden", http.StatusForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ContextWithAPIKeyID(r.Context(), identity.ID)))
+		})
+	}
+}
+
+// ContextWithAPIKeyID marks the request as authenticated by the API key with the given ID
+func ContextWithAPIKeyID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, apiKeyIDKey, id)
+}
+
+// APIKeyIDFromContext returns the ID of the API key the request was authenticated with, if any
+func APIKeyIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(apiKeyIDKey).(string)
+	return id, ok && id != ""
+}
+
+// The key is sent in the Authorization header, either bare or as a bearer token.
+func getAPIKey(r *http.Request) string {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+
+	return header
+}
@@ -9,10 +9,15 @@ import (
 	"time"
 
 	"github.com/alicebob/miniredis"
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
 type RedisClient interface {
@@ -24,16 +29,24 @@ type RedisClient interface {
 	Incr(ctx context.Context, key string) *redis.IntCmd
 	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
 	TTL(ctx context.Context, key string) *redis.DurationCmd
+	Ping(ctx context.Context) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
 }
 
 type CacheSvc interface {
+	LockSvc
 	Get(ctx context.Context, key string, output any) error
 	Set(ctx context.Context, key string, data any, duration ...time.Duration) error
 	DelByPrefix(ctx context.Context, prefixName string)
 	Incr(ctx context.Context, key string) (int64, error)
+	IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	GetCount(ctx context.Context, key string) (int64, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) error
 	TTL(ctx context.Context, key string) time.Duration
 	ClearCaches(keys []string, identifier string)
+	Ping(ctx context.Context) error
+	Duration() time.Duration
 }
 
 type CacheSvcImpl struct {
@@ -65,50 +78,228 @@ func NewRedisClient(config *BaseConfig) *redis.Client {
 	return rdb
 }
 
-func GetOrSetData[T any](c CacheSvc, key string, function func() (T, error), duration ...time.Duration) (T, error) {
+const (
+	// cacheLoadTimeout bounds a query shared by several requests, which is not cancelled with the request that started it
+	cacheLoadTimeout = time.Minute
+	// cacheLockPollInterval is how often a replica waiting on the refresh lock checks whether the entry landed
+	cacheLockPollInterval = 100 * time.Millisecond
+)
+
+// incrWithExpireScript starts the expiry of a counter with its first increment, in one step so a counter is never left without one
+const incrWithExpireScript = `local count = redis.call("INCR", KEYS[1]) if count == 1 then redis.call("PEXPIRE", KEYS[1], ARGV[1]) end return count`
+
+// releaseLockScript deletes the lock only while it still holds the caller's token, so an expired lock taken over by another replica is left alone
+const releaseLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`
+
+var (
+	// cacheLoads deduplicates the concurrent loads of a missing key within this replica
+	cacheLoads singleflight.Group
+	// cacheRefreshes deduplicates the background refreshes of a stale key within this replica
+	cacheRefreshes singleflight.Group
+)
+
+type cacheOptions struct {
+	duration    time.Duration
+	softTTL     time.Duration
+	lockTimeout time.Duration
+}
+
+type CacheOption func(*cacheOptions)
+
+// WithCacheDuration keeps the entry for duration instead of CACHE_DURATION.
+func WithCacheDuration(duration time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.duration = duration
+	}
+}
+
+// WithSoftTTL serves an entry older than softTTL as is while it is refreshed in the background, zero disables it.
+func WithSoftTTL(softTTL time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.softTTL = softTTL
+	}
+}
+
+// WithRefreshLock lets a single replica rebuild a missing or stale entry, the others waiting up to timeout
+// for it to land before querying themselves. The lock expires after the longest configured lock timeout if its holder
+// dies, zero disables it.
+func WithRefreshLock(timeout time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.lockTimeout = timeout
+	}
+}
+
+// GetOrSetData reads key from the cache and loads it with function on a miss. Concurrent misses of a key share
+// a single load, and the options add a soft TTL served while refreshing and a refresh lock shared across replicas.
+func GetOrSetData[T any](ctx context.Context, c CacheSvc, key string, function func(ctx context.Context) (T, error), opts ...CacheOption) (T, error) {
+	options := cacheOptions{duration: c.Duration()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	var data T
-	ctx := context.Background()
 	err := c.Get(ctx, key, &data)
 	if err != nil {
 		if err == redis.Nil || err.Error() == "Entry not found" {
-			data, err := function()
-			if err != nil {
-				return data, err
+			observeCacheRequest(key, "miss")
+			return loadData(ctx, c, key, function, options)
+		}
+		observeCacheRequest(key, "error")
+		return data, err
+	}
+
+	if isStale(ctx, c, key, options) {
+		observeCacheRequest(key, "stale")
+		refreshData(ctx, c, key, function, options)
+		return data, nil
+	}
+
+	observeCacheRequest(key, "hit")
+	return data, nil
+}
+
+// isStale tells whether the entry is older than the soft TTL, its age being derived from the TTL left in Redis
+func isStale(ctx context.Context, c CacheSvc, key string, options cacheOptions) bool {
+	if options.softTTL <= 0 || options.softTTL >= options.duration {
+		return false
+	}
+
+	ttl := c.TTL(ctx, key)
+	if ttl < 0 {
+		return false
+	}
+
+	return options.duration-ttl >= options.softTTL
+}
+
+// loadData runs function once per key in this replica, and with a refresh lock once across replicas as long
+// as the holder stores the entry within the lock timeout
+func loadData[T any](ctx context.Context, c CacheSvc, key string, function func(ctx context.Context) (T, error), options cacheOptions) (T, error) {
+	res, err, _ := cacheLoads.Do(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+
+		if options.lockTimeout > 0 {
+			release, acquired, err := c.TryLock(ctx, key)
+			if err == nil && !acquired {
+				var data T
+				var found bool
+				data, release, found = waitForData[T](ctx, c, key, options.lockTimeout)
+				if found {
+					return data, nil
+				}
 			}
+			if release != nil {
+				defer release()
+			}
+		}
+
+		return setData(ctx, c, key, function, options)
+	})
+	if err != nil {
+		var data T
+		return data, err
+	}
+
+	return res.(T), nil
+}
 
-			err = c.Set(ctx, key, data, duration...)
+// refreshData reloads a stale entry in the background, skipping it while another request or replica refreshes the key
+func refreshData[T any](ctx context.Context, c CacheSvc, key string, function func(ctx context.Context) (T, error), options cacheOptions) {
+	cacheRefreshes.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+
+		if options.lockTimeout > 0 {
+			release, acquired, err := c.TryLock(ctx, key)
+			if err == nil && !acquired {
+				return nil, nil
+			}
+			if acquired {
+				defer release()
+			}
+		}
 
-			return data, err
+		data, err := setData(ctx, c, key, function, options)
+		if err != nil {
+			observeCacheRequest(key, "refresh_error")
 		}
 		return data, err
+	})
+}
+
+func setData[T any](ctx context.Context, c CacheSvc, key string, function func(ctx context.Context) (T, error), options cacheOptions) (T, error) {
+	data, err := function(ctx)
+	if err != nil {
+		return data, err
 	}
 
-	return data, nil
+	return data, c.Set(ctx, key, data, options.duration)
+}
+
+// waitForData polls the cache for the entry loaded by the replica holding the refresh lock, taking the lock
+// over once it is released without the entry landing, as when the load failed or had nothing to store
+func waitForData[T any](ctx context.Context, c CacheSvc, key string, timeout time.Duration) (data T, release func(), found bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(cacheLockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return data, nil, false
+		case <-ctx.Done():
+			return data, nil, false
+		case <-ticker.C:
+			if err := c.Get(ctx, key, &data); err == nil {
+				return data, nil, true
+			}
+
+			release, acquired, err := c.TryLock(ctx, key)
+			if err != nil || acquired {
+				return data, release, false
+			}
+		}
+	}
 }
 
 func (s *CacheSvcImpl) Get(ctx context.Context, key string, output any) error {
+	ctx, span := startCacheSpan(ctx, "cache.Get", key)
+	defer span.End()
+
 	val, err := s.redis.Get(ctx, key).Result()
+	span.SetAttributes(attribute.Bool("cache.hit", err == nil))
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("failed when getting cache (Redis) with key -> %s | error: %v", key, err))
+		if err != redis.Nil {
+			SetSpanError(span, err)
+		}
+		s.logger.WithContext(ctx).Error(fmt.Sprintf("failed when getting cache (Redis) with key -> %s | error: %v", key, err))
 		return err
 	}
 
 	err = json.Unmarshal([]byte(val), &output)
 	if err != nil {
-		s.logger.Error("failed when unmarshal data")
+		s.logger.WithContext(ctx).Error("failed when unmarshal data")
 		return err
 	}
 
-	s.logger.Info(fmt.Sprintf("get data from cache (Redis) with key --> %s", key))
+	s.logger.WithContext(ctx).Info(fmt.Sprintf("get data from cache (Redis) with key --> %s", key))
 
 	return nil
 }
 
-func (s *CacheSvcImpl) Set(ctx context.Context, key string, data any, duration ...time.Duration) error {
+func (s *CacheSvcImpl) Set(ctx context.Context, key string, data any, duration ...time.Duration) (err error) {
+	ctx, span := startCacheSpan(ctx, "cache.Set", key)
+	defer func() {
+		SetSpanError(span, err)
+		span.End()
+	}()
+
 	if data != nil {
 		if reflect.TypeOf(data).Kind() == reflect.Slice {
 			if reflect.ValueOf(data).Len() == 0 {
-				s.logger.Error("no data to save, array is empty")
+				s.logger.WithContext(ctx).Error("no data to save, array is empty")
 				return nil
 			}
 		}
@@ -123,11 +314,11 @@ func (s *CacheSvcImpl) Set(ctx context.Context, key string, data any, duration .
 			expiration = duration[0]
 		}
 
-		s.logger.Info(fmt.Sprintf("set data to cache (Redis) with key --> %s", key))
+		s.logger.WithContext(ctx).Info(fmt.Sprintf("set data to cache (Redis) with key --> %s", key))
 		return s.redis.Set(ctx, key, cacheData, expiration).Err()
 	}
 
-	s.logger.Info(fmt.Sprintf("not save data to cache, key --> %s", key))
+	s.logger.WithContext(ctx).Info(fmt.Sprintf("not save data to cache, key --> %s", key))
 
 	return nil
 }
@@ -135,7 +326,7 @@ func (s *CacheSvcImpl) Set(ctx context.Context, key string, data any, duration .
 func (s *CacheSvcImpl) Del(ctx context.Context, key string) error {
 	err := s.redis.Del(ctx, key).Err()
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("failed when deleting cache (Redis) with key --> %s", key), zap.Error(err))
+		s.logger.WithContext(ctx).Error(fmt.Sprintf("failed when deleting cache (Redis) with key --> %s", key), zap.Error(err))
 		return err
 	}
 
@@ -143,22 +334,26 @@ func (s *CacheSvcImpl) Del(ctx context.Context, key string) error {
 }
 
 func (s *CacheSvcImpl) DelByPrefix(ctx context.Context, prefixName string) {
+	ctx, span := startCacheSpan(ctx, "cache.DelByPrefix", prefixName)
+	defer span.End()
+
 	foundedRecordCount := 0
 
 	iter := s.redis.Scan(ctx, 0, fmt.Sprintf("%s*", prefixName), 0).Iterator()
-	s.logger.Info(fmt.Sprintf("your search pattern: %s", prefixName))
+	s.logger.WithContext(ctx).Info(fmt.Sprintf("your search pattern: %s", prefixName))
 
 	for iter.Next(ctx) {
-		s.logger.Error(fmt.Sprintf("deleted (Redis)= %s", iter.Val()))
+		s.logger.WithContext(ctx).Error(fmt.Sprintf("deleted (Redis)= %s", iter.Val()))
 		s.redis.Del(ctx, iter.Val())
 		foundedRecordCount++
 	}
 
 	if err := iter.Err(); err != nil {
-		s.logger.Error("failed when deleting cache (Redis)", zap.Error(err))
+		s.logger.WithContext(ctx).Error("failed when deleting cache (Redis)", zap.Error(err))
+		SetSpanError(span, err)
 	}
 
-	s.logger.Info(fmt.Sprintf("deleted Count (Redis) %d", foundedRecordCount))
+	s.logger.WithContext(ctx).Info(fmt.Sprintf("deleted Count (Redis) %d", foundedRecordCount))
 }
 
 func (s *CacheSvcImpl) ClearCaches(keys []string, identifier string) {
@@ -178,29 +373,115 @@ func (s *CacheSvcImpl) ClearCaches(keys []string, identifier string) {
 	}
 
 	err := ewg.Wait()
-	s.logger.Error(fmt.Sprintf("failed when clearing cache (Redis) with key --> %s", keys), zap.Error(err))
+	s.logger.WithContext(ctx).Error(fmt.Sprintf("failed when clearing cache (Redis) with key --> %s", keys), zap.Error(err))
 }
 
 func (s *CacheSvcImpl) Incr(ctx context.Context, key string) (int64, error) {
+	ctx, span := startCacheSpan(ctx, "cache.Incr", key)
+	defer span.End()
+
 	res, err := s.redis.Incr(ctx, key).Result()
 	if err != nil {
+		SetSpanError(span, err)
+		return res, err
+	}
+	return res, nil
+}
+
+// IncrWithExpire increments a counter, which expires after expiration from its first increment.
+func (s *CacheSvcImpl) IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	ctx, span := startCacheSpan(ctx, "cache.IncrWithExpire", key)
+	defer span.End()
+
+	res, err := s.redis.Eval(ctx, incrWithExpireScript, []string{key}, expiration.Milliseconds()).Int64()
+	if err != nil {
+		SetSpanError(span, err)
 		return res, err
 	}
 	return res, nil
 }
 
+// GetCount reads a counter written by Incr, a missing counter being zero.
+func (s *CacheSvcImpl) GetCount(ctx context.Context, key string) (int64, error) {
+	ctx, span := startCacheSpan(ctx, "cache.GetCount", key)
+	defer span.End()
+
+	res, err := s.redis.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	SetSpanError(span, err)
+	return res, err
+}
+
 func (s *CacheSvcImpl) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	ctx, span := startCacheSpan(ctx, "cache.Expire", key)
+	defer span.End()
+
 	err := s.redis.Expire(ctx, key, expiration).Err()
 	if err != nil {
+		SetSpanError(span, err)
 		return err
 	}
 	return nil
 }
 
 func (s *CacheSvcImpl) TTL(ctx context.Context, key string) time.Duration {
+	ctx, span := startCacheSpan(ctx, "cache.TTL", key)
+	defer span.End()
+
 	return s.redis.TTL(ctx, key).Val()
 }
 
+// TryLock takes the lock of key shared by every replica using this Redis, it is held until release is called or the
+// longer of CACHE_LOCK_TIMEOUT and CACHE_ANALYTICS_LOCK_TIMEOUT passes so a replica dying while holding it does not block
+// the others. Expiring after the longest wait keeps the lock of a slow load from being taken over while it runs.
+func (s *CacheSvcImpl) TryLock(ctx context.Context, key string) (release func(), acquired bool, err error) {
+	lockKey := BuildPrefixKey(constant.CacheLockKey, key)
+	ctx, span := startCacheSpan(ctx, "cache.TryLock", lockKey)
+	defer span.End()
+
+	token := uuid.NewString()
+	acquired, err = s.redis.SetNX(ctx, lockKey, token, max(s.config.CacheLockTimeout, s.config.CacheAnalyticsLockTimeout)).Result()
+	if err != nil {
+		SetSpanError(span, err)
+		s.logger.WithContext(ctx).Error(fmt.Sprintf("failed when locking cache (Redis) with key -> %s", lockKey), zap.Error(err))
+		return nil, false, err
+	}
+	span.SetAttributes(attribute.Bool("cache.lock_acquired", acquired))
+	if !acquired {
+		return nil, false, nil
+	}
+
+	return func() {
+		err := s.redis.Eval(context.WithoutCancel(ctx), releaseLockScript, []string{lockKey}, token).Err()
+		if err != nil && err != redis.Nil {
+			s.logger.WithContext(ctx).Error(fmt.Sprintf("failed when unlocking cache (Redis) with key -> %s", lockKey), zap.Error(err))
+		}
+	}, true, nil
+}
+
+// Duration is how long an entry is kept when Set is not given a duration.
+func (s *CacheSvcImpl) Duration() time.Duration {
+	return s.config.CacheDuration
+}
+
+func (s *CacheSvcImpl) Ping(ctx context.Context) error {
+	ctx, span := startCacheSpan(ctx, "cache.Ping", "")
+	defer span.End()
+
+	err := s.redis.Ping(ctx).Err()
+	SetSpanError(span, err)
+	return err
+}
+
+func startCacheSpan(ctx context.Context, name string, key string) (context.Context, trace.Span) {
+	return StartSpan(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system", "redis"),
+		attribute.String("cache.key", key),
+	))
+}
+
 func BuildCacheKey(key string, identifier string, funcName string, args ...any) string {
 	cacheKey := key
 	if identifier != "" && funcName != "" {
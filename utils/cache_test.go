@@ -0,0 +1,183 @@
+package utils_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func initCacheSvc(t *testing.T, ctrl *gomock.Controller, mr *miniredis.Miniredis) utils.CacheSvc {
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	config.CacheDuration = time.Minute
+	config.CacheLockTimeout = time.Second
+	config.CacheAnalyticsLockTimeout = time.Second
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockutl.LoggerMock(mockLogger)
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	t.Cleanup(func() {
+		redisClient.Close()
+	})
+
+	return utils.NewCacheSvc(config, redisClient, mockLogger)
+}
+
+func TestGetOrSetData(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+	cacheSvc := initCacheSvc(t, ctrl, mr)
+
+	t.Run("concurrent misses share a single load", func(t *testing.T) {
+		var loads atomic.Int32
+		load := func(ctx context.Context) (string, error) {
+			loads.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			return "value", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data, err := utils.GetOrSetData(ctx, cacheSvc, "concurrent-miss", load, utils.WithRefreshLock(time.Second))
+				assert.NoError(t, err)
+				assert.Equal(t, "value", data)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), loads.Load())
+	})
+
+	t.Run("stale entry is served while it is refreshed in the background", func(t *testing.T) {
+		var loads atomic.Int32
+		load := func(ctx context.Context) (int32, error) {
+			return loads.Add(1), nil
+		}
+
+		data, err := utils.GetOrSetData(ctx, cacheSvc, "stale", load, utils.WithSoftTTL(10*time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), data)
+
+		mr.FastForward(20 * time.Second)
+
+		data, err = utils.GetOrSetData(ctx, cacheSvc, "stale", load, utils.WithSoftTTL(10*time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), data)
+
+		assert.Eventually(t, func() bool {
+			var cached int32
+			return cacheSvc.Get(ctx, "stale", &cached) == nil && cached == 2
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(2), loads.Load())
+	})
+
+	t.Run("failed load is not cached", func(t *testing.T) {
+		_, err := utils.GetOrSetData(ctx, cacheSvc, "failed", func(ctx context.Context) (string, error) {
+			return "", context.DeadlineExceeded
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var cached string
+		assert.Error(t, cacheSvc.Get(ctx, "failed", &cached))
+	})
+}
+
+func TestCacheTryLock(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+	replicaA := initCacheSvc(t, ctrl, mr)
+	replicaB := initCacheSvc(t, ctrl, mr)
+
+	t.Run("lock is exclusive across replicas until released", func(t *testing.T) {
+		release, acquired, err := replicaA.TryLock(ctx, "contended")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		_, acquired, err = replicaB.TryLock(ctx, "contended")
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+
+		release()
+
+		release, acquired, err = replicaB.TryLock(ctx, "contended")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		release()
+	})
+
+	t.Run("lock of a dead holder expires", func(t *testing.T) {
+		_, acquired, err := replicaA.TryLock(ctx, "expired")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		mr.FastForward(2 * time.Second)
+
+		release, acquired, err := replicaB.TryLock(ctx, "expired")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		release()
+	})
+
+	t.Run("stale release does not free a lock taken over by another replica", func(t *testing.T) {
+		releaseA, acquired, err := replicaA.TryLock(ctx, "taken-over")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		mr.FastForward(2 * time.Second)
+
+		releaseB, acquired, err := replicaB.TryLock(ctx, "taken-over")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		releaseA()
+
+		_, acquired, err = replicaA.TryLock(ctx, "taken-over")
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+		releaseB()
+	})
+
+	t.Run("replica waits for the entry loaded by the lock holder", func(t *testing.T) {
+		release, acquired, err := replicaA.TryLock(ctx, "shared")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		go func() {
+			time.Sleep(150 * time.Millisecond)
+			assert.NoError(t, replicaA.Set(ctx, "shared", "from replica a"))
+			release()
+		}()
+
+		var loads atomic.Int32
+		data, err := utils.GetOrSetData(ctx, replicaB, "shared", func(ctx context.Context) (string, error) {
+			loads.Add(1)
+			return "from replica b", nil
+		}, utils.WithRefreshLock(time.Second))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "from replica a", data)
+		assert.Equal(t, int32(0), loads.Load())
+	})
+}
@@ -7,18 +7,60 @@ import (
 )
 
 type BaseConfig struct {
-	ServerPort    int           `mapstructure:"SERVER_PORT"`
-	DBConnString  string        `mapstructure:"DB_CONN_STRING"`
-	DBName        string        `mapstructure:"DB_NAME"`
-	MigrationURL  string        `mapstructure:"MIGRATION_URL"`
-	SwaggerURL    string        `mapstructure:"SWAGGER_URL"`
-	LogLevel      string        `mapstructure:"LOG_LEVEL"`
-	CosmosAPIURL  string        `mapstructure:"COSMOS_API_URL"`
-	RedisHost     string        `mapstructure:"REDIS_HOST"`
-	RedisUsername string        `mapstructure:"REDIS_USERNAME"`
-	RedisPassword string        `mapstructure:"REDIS_PASSWORD"`
-	RedisDB       int           `mapstructure:"REDIS_DB"`
-	CacheDuration time.Duration `mapstructure:"CACHE_DURATION"`
+	ServerPort                 int           `mapstructure:"SERVER_PORT"`
+	ShutdownTimeout            time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	DBConnString               string        `mapstructure:"DB_CONN_STRING"`
+	DBName                     string        `mapstructure:"DB_NAME"`
+	MigrationURL               string        `mapstructure:"MIGRATION_URL"`
+	SwaggerURL                 string        `mapstructure:"SWAGGER_URL"`
+	LogLevel                   string        `mapstructure:"LOG_LEVEL"`
+	CosmosAPIURL               string        `mapstructure:"COSMOS_API_URL"`
+	CosmosAPITimeout           time.Duration `mapstructure:"COSMOS_API_TIMEOUT"`
+	CosmosAPIRetryCount        int           `mapstructure:"COSMOS_API_RETRY_COUNT"`
+	CosmosAPIRetryBackoff      time.Duration `mapstructure:"COSMOS_API_RETRY_BACKOFF"`
+	CosmosValidatorAPIURL      string        `mapstructure:"COSMOS_VALIDATOR_API_URL"`
+	CollectorTxTimeout         time.Duration `mapstructure:"COLLECTOR_TX_TIMEOUT"`
+	CommissionFetchTimeout     time.Duration `mapstructure:"COMMISSION_FETCH_TIMEOUT"`
+	SnapshotStorageMode        string        `mapstructure:"SNAPSHOT_STORAGE_MODE"`
+	SnapshotCheckpointInterval time.Duration `mapstructure:"SNAPSHOT_CHECKPOINT_INTERVAL"`
+	SnapshotPartitionMonths    int           `mapstructure:"SNAPSHOT_PARTITION_MONTHS"`
+	ReportingTimezone          string        `mapstructure:"REPORTING_TIMEZONE"`
+	RetentionDays              int           `mapstructure:"RETENTION_DAYS"`
+	RetentionDryRun            bool          `mapstructure:"RETENTION_DRY_RUN"`
+	RetentionTimeout           time.Duration `mapstructure:"RETENTION_TIMEOUT"`
+	ExportStorage              string        `mapstructure:"EXPORT_STORAGE"`
+	ExportLocalDir             string        `mapstructure:"EXPORT_LOCAL_DIR"`
+	ExportS3Endpoint           string        `mapstructure:"EXPORT_S3_ENDPOINT"`
+	ExportS3Bucket             string        `mapstructure:"EXPORT_S3_BUCKET"`
+	ExportS3AccessKey          string        `mapstructure:"EXPORT_S3_ACCESS_KEY"`
+	ExportS3SecretKey          string        `mapstructure:"EXPORT_S3_SECRET_KEY"`
+	ExportS3Region             string        `mapstructure:"EXPORT_S3_REGION"`
+	ExportS3UseSSL             bool          `mapstructure:"EXPORT_S3_USE_SSL"`
+	ExportTimeout              time.Duration `mapstructure:"EXPORT_TIMEOUT"`
+	DelegatorEventsTimeout     time.Duration `mapstructure:"DELEGATOR_EVENTS_TIMEOUT"`
+	RedisHost                  string        `mapstructure:"REDIS_HOST"`
+	RedisUsername              string        `mapstructure:"REDIS_USERNAME"`
+	RedisPassword              string        `mapstructure:"REDIS_PASSWORD"`
+	RedisDB                    int           `mapstructure:"REDIS_DB"`
+	CacheDuration              time.Duration `mapstructure:"CACHE_DURATION"`
+	CacheSoftTTL               time.Duration `mapstructure:"CACHE_SOFT_TTL"`
+	CacheLockTimeout           time.Duration `mapstructure:"CACHE_LOCK_TIMEOUT"`
+	CacheAnalyticsSoftTTL      time.Duration `mapstructure:"CACHE_ANALYTICS_SOFT_TTL"`
+	CacheAnalyticsLockTimeout  time.Duration `mapstructure:"CACHE_ANALYTICS_LOCK_TIMEOUT"`
+	AuthAdminKey               string        `mapstructure:"AUTH_ADMIN_KEY"`
+	AuthReadRequired           bool          `mapstructure:"AUTH_READ_REQUIRED"`
+	RateLimitWindow            time.Duration `mapstructure:"RATE_LIMIT_WINDOW"`
+	RateLimitRead              int           `mapstructure:"RATE_LIMIT_READ"`
+	RateLimitTriggerJobs       int           `mapstructure:"RATE_LIMIT_TRIGGER_JOBS"`
+	RateLimitAdmin             int           `mapstructure:"RATE_LIMIT_ADMIN"`
+	RateLimitAuth              int           `mapstructure:"RATE_LIMIT_AUTH"`
+	RateLimitTrustedProxies    string        `mapstructure:"RATE_LIMIT_TRUSTED_PROXIES"`
+	TracingServiceName         string        `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingOTLPEndpoint        string        `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure        bool          `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingSampleRatio         float64       `mapstructure:"TRACING_SAMPLE_RATIO"`
+	HealthCheckTimeout         time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	HealthMaxCollectionAge     time.Duration `mapstructure:"HEALTH_MAX_COLLECTION_AGE"`
 }
 
 func LoadBaseConfig(path string, configName string, config *BaseConfig) {
@@ -16,13 +16,22 @@ type PGXPool interface {
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
 	Begin(ctx context.Context) (pgx.Tx, error)
 	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+	Ping(ctx context.Context) error
+	Acquire(ctx context.Context) (*pgxpool.Conn, error)
 	Close()
 }
 
 func ConnectDBPool(connString string) *pgxpool.Pool {
-	dbpool, err := pgxpool.New(context.Background(), connString)
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		panic(err)
+	}
+	poolConfig.ConnConfig.Tracer = NewDBTracer()
+
+	dbpool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		panic(err)
 	}
@@ -2,10 +2,19 @@ package utils
 
 import "time"
 
-func GetCurrentTimeInJakarta() time.Time {
-	loc, err := time.LoadLocation("Asia/Jakarta")
-	if err != nil {
-		panic(err)
-	}
-	return time.Now().In(loc)
+func GetCurrentTimeInUTC() time.Time {
+	return time.Now().UTC()
+}
+
+// GetDateInLocation returns the calendar date of t in loc at midnight UTC,
+// so the same day is written to a DATE column whatever the session timezone is.
+func GetDateInLocation(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// GetStartOfDayInLocation returns midnight of the day t falls on in loc.
+func GetStartOfDayInLocation(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
 }
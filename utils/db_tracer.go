@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqlcQueryName matches the name comment sqlc keeps at the start of every generated query
+var sqlcQueryName = regexp.MustCompile(`^-- name: (\w+)`)
+
+// DBTracer traces the queries and copies of the pool, naming each span after its sqlc query
+type DBTracer struct{}
+
+func NewDBTracer() *DBTracer {
+	return &DBTracer{}
+}
+
+func (t *DBTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name := "db.query"
+	if match := sqlcQueryName.FindStringSubmatch(data.SQL); match != nil {
+		name = match[1]
+	}
+
+	ctx, _ = StartSpan(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (t *DBTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	SetSpanError(span, data.Err)
+	span.End()
+}
+
+func (t *DBTracer) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	ctx, _ = StartSpan(ctx, "db.copy_from",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.sql.table", data.TableName.Sanitize()),
+		),
+	)
+
+	return ctx
+}
+
+func (t *DBTracer) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	SetSpanError(span, data.Err)
+	span.End()
+}
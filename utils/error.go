@@ -1,24 +1,38 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
-	"strings"
+	"net/http"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 )
 
+// AppError is an error returned to the client with its HTTP status and machine readable code,
+// the cause is only logged and stays reachable with errors.Is and errors.As.
 type AppError struct {
-	Message    string
 	StatusCode int
+	Code       string
+	Message    string
+	Err        error
 }
 
 func (ae *AppError) Error() string {
-	return fmt.Sprintf("app error: status code %d, message %s", ae.StatusCode, ae.Message)
+	if ae.Err == nil {
+		return ae.Message
+	}
+
+	return fmt.Sprintf("%s: %v", ae.Message, ae.Err)
+}
+
+func (ae *AppError) Unwrap() error {
+	return ae.Err
 }
 
 type ValidationError struct {
-	Message string
-	Field   string
-	Tag     string
+	Message string `json:"message"`
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
 }
 
 func (ve *ValidationError) Error() string {
@@ -26,52 +40,69 @@ func (ve *ValidationError) Error() string {
 }
 
 type ValidationErrors struct {
-	Errors     []ValidationError
-	StatusCode int
+	Errors []ValidationError
 }
 
-func CustomError(message string, statusCode int) error {
-	return fmt.Errorf("%s|%s<->%d|", message, message, statusCode)
+func (ve ValidationErrors) Error() string {
+	return fmt.Sprintf("validation errors: %v", ve.Errors)
 }
 
-func CustomErrorWithTrace(err error, message string, statusCode int) error {
-	return fmt.Errorf("%s|%s<->%d|", err.Error(), message, statusCode)
+func NewAppError(code string, message string, statusCode int) *AppError {
+	return &AppError{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+	}
 }
 
-func PanicIfError(err error) {
-	if err != nil {
-		customError := strings.Split(err.Error(), "<->")
-		message := customError[0]
-		statusCode := 500
-
-		if len(customError) > 1 {
-			statusCode, _ = strconv.Atoi(strings.Split(customError[1], "|")[0])
-		}
-
-		appErr := AppError{
-			Message:    message,
-			StatusCode: statusCode,
-		}
-		panic(appErr)
+// WrapAppError wraps err with the status and code it is reported with, an err that already is an
+// AppError is returned as is so the most specific status set closest to the failure wins.
+func WrapAppError(err error, code string, message string, statusCode int) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+
+	return &AppError{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+		Err:        err,
 	}
 }
 
-func PanicIfAppError(err error, message string, statusCode int) {
-	if err != nil {
-		customErr := CustomErrorWithTrace(err, message, statusCode)
-		PanicIfError(customErr)
+// ToAppError resolves the AppError reported for err, an error that is not one becoming an internal server error.
+func ToAppError(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return &AppError{
+		StatusCode: http.StatusInternalServerError,
+		Code:       constant.ErrorCodeInternal,
+		Message:    "internal server error",
+		Err:        err,
 	}
 }
 
-func PanicAppError(message string, statusCode int) {
-	customErr := CustomError(message, statusCode)
-	PanicIfError(customErr)
+// PanicIfAppError aborts the request with err wrapped as an AppError, it is only meant for the request
+// validation helpers, which run in handlers before any service call and are recovered by the recovery middleware.
+func PanicIfAppError(err error, code string, message string, statusCode int) {
+	if err != nil {
+		panic(WrapAppError(err, code, message, statusCode))
+	}
 }
 
 func PanicValidationError(errors []ValidationError, statusCode int) {
-	validationErrors := ValidationErrors{
-		Errors:     errors,
+	panic(&AppError{
 		StatusCode: statusCode,
-	}
-	panic(validationErrors)
+		Code:       constant.ErrorCodeValidationFailed,
+		Message:    "validation failed",
+		Err:        ValidationErrors{Errors: errors},
+	})
 }
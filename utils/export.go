@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var errUnsupportedExportFormat = errors.New("unsupported format")
+
+var exportContentTypes = map[string]string{
+	constant.ExportFormatCSV:    "text/csv",
+	constant.ExportFormatNDJSON: "application/x-ndjson",
+}
+
+// ValidateExportFormat returns the export format asked for with the format query
+// param or the Accept header, or an empty string for the paginated JSON response.
+func ValidateExportFormat(r *http.Request, queryName string) string {
+	query := r.URL.Query().Get(queryName)
+
+	if query != "" {
+		if query == "json" {
+			return ""
+		}
+		if _, ok := exportContentTypes[query]; !ok {
+			PanicIfAppError(errUnsupportedExportFormat, constant.ErrorCodeInvalidRequest, generateValidationQueryErrorMsg(queryName), http.StatusBadRequest)
+		}
+		return query
+	}
+
+	accept := r.Header.Get("Accept")
+	for format, contentType := range exportContentTypes {
+		if strings.Contains(accept, contentType) {
+			return format
+		}
+	}
+
+	return ""
+}
+
+type ExportWriter[T any] interface {
+	Write(item T) error
+	Flush() error
+	Started() bool
+}
+
+type ExportWriterImpl[T any] struct {
+	w       http.ResponseWriter
+	format  string
+	csv     *csv.Writer
+	json    *jsoniter.Encoder
+	started bool
+}
+
+func NewExportWriter[T any](w http.ResponseWriter, format string) ExportWriter[T] {
+	return &ExportWriterImpl[T]{
+		w:      w,
+		format: format,
+		csv:    csv.NewWriter(w),
+		json:   JSONiter().NewEncoder(w),
+	}
+}
+
+func (e *ExportWriterImpl[T]) Write(item T) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if e.format == constant.ExportFormatCSV {
+		return e.csv.Write(csvRecord(item))
+	}
+
+	return e.json.Encode(item)
+}
+
+func (e *ExportWriterImpl[T]) Flush() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+// Started reports whether the status line and headers were sent, after which an error can no longer be responded with.
+func (e *ExportWriterImpl[T]) Started() bool {
+	return e.started
+}
+
+// start sends the headers with the first row, so a failing query can still
+// be answered with a regular error response.
+func (e *ExportWriterImpl[T]) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	e.w.Header().Set("Content-Type", exportContentTypes[e.format])
+	e.w.WriteHeader(http.StatusOK)
+
+	if e.format == constant.ExportFormatCSV {
+		var item T
+		return e.csv.Write(csvHeader(item))
+	}
+
+	return nil
+}
+
+func csvHeader(item any) []string {
+	t := reflect.TypeOf(item)
+	header := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		header = append(header, strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0])
+	}
+
+	return header
+}
+
+func csvRecord(item any) []string {
+	v := reflect.ValueOf(item)
+	record := make([]string, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		record = append(record, fmt.Sprint(v.Field(i).Interface()))
+	}
+
+	return record
+}
@@ -8,6 +8,11 @@ import (
 	"time"
 
 	"github.com/gadhittana01/cosmos-validation-tracking/utils/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type HTTPClient interface {
@@ -48,7 +53,19 @@ func (c *DefaultHTTPClient) Delete(ctx context.Context, url string) (*types.HTTP
 	return c.doRequestWithContext(ctx, http.MethodDelete, url, nil)
 }
 
-func (c *DefaultHTTPClient) doRequestWithContext(ctx context.Context, method, url string, body []byte) (*types.HTTPResponse, error) {
+func (c *DefaultHTTPClient) doRequestWithContext(ctx context.Context, method, url string, body []byte) (resp *types.HTTPResponse, err error) {
+	ctx, span := StartSpan(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.full", url),
+		),
+	)
+	defer func() {
+		SetSpanError(span, err)
+		span.End()
+	}()
+
 	var bodyReader io.Reader
 	if body != nil {
 		bodyReader = bytes.NewBuffer(body)
@@ -59,21 +76,27 @@ func (c *DefaultHTTPClient) doRequestWithContext(ctx context.Context, method, ur
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
-	resp, err := c.client.Do(req)
+	httpResp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", httpResp.StatusCode))
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(httpResp.StatusCode))
+	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	bodyBytes, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		return nil, err
 	}
 
 	return &types.HTTPResponse{
-		StatusCode: resp.StatusCode,
+		StatusCode: httpResp.StatusCode,
 		Body:       string(bodyBytes),
-		Headers:    resp.Header,
+		Headers:    httpResp.Header,
 	}, nil
 }
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jobCancelGrace is how long cancelled jobs get to roll back and return once the shutdown deadline has passed.
+const jobCancelGrace = 5 * time.Second
+
+type JobManager interface {
+	Go(name string, fn func(ctx context.Context))
+	Shutdown(ctx context.Context) error
+}
+
+type JobManagerImpl struct {
+	logger  LoggerSvc
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	running map[string]int
+	closed  bool
+}
+
+func NewJobManager(logger LoggerSvc) JobManager {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &JobManagerImpl{
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+		running: map[string]int{},
+	}
+}
+
+// Go runs a background job outliving the request that triggered it. Its context is only cancelled
+// when the service shuts down and the job did not finish in time. Jobs started after shutdown began are dropped.
+func (m *JobManagerImpl) Go(name string, fn func(ctx context.Context)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		m.logger.Warn(fmt.Sprintf("Skipping %s, shutting down", name))
+		return
+	}
+
+	m.running[name]++
+	m.wg.Add(1)
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			m.running[name]--
+			if m.running[name] == 0 {
+				delete(m.running, name)
+			}
+			m.mu.Unlock()
+			m.wg.Done()
+		}()
+
+		ctx, span := StartSpan(ContextWithRunID(m.ctx, uuid.NewString()), name)
+		defer span.End()
+
+		start := time.Now()
+		fn(ctx)
+		ObserveJobDuration(name, start)
+	}()
+}
+
+// Shutdown stops accepting jobs and waits for the running ones until ctx is done, then cancels them
+// and waits a short grace period for their transactions to roll back.
+func (m *JobManagerImpl) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	m.logger.Info(fmt.Sprintf("Waiting for running jobs %v", m.running))
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		m.cancel()
+		return nil
+	case <-ctx.Done():
+	}
+
+	m.mu.Lock()
+	m.logger.Warn(fmt.Sprintf("Cancelling running jobs %v", m.running))
+	m.mu.Unlock()
+	m.cancel()
+
+	select {
+	case <-done:
+	case <-time.After(jobCancelGrace):
+		m.logger.Error("Jobs did not return after being cancelled")
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,77 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func initJobManager(ctrl *gomock.Controller) utils.JobManager {
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockutl.LoggerMock(mockLogger)
+
+	return utils.NewJobManager(mockLogger)
+}
+
+func TestJobManagerShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("waits for jobs that finish before the deadline", func(t *testing.T) {
+		jobManager := initJobManager(ctrl)
+		done := make(chan error, 1)
+
+		jobManager.Go("finishing", func(ctx context.Context) {
+			time.Sleep(50 * time.Millisecond)
+			done <- ctx.Err()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.NoError(t, jobManager.Shutdown(ctx))
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("cancels jobs still running at the deadline", func(t *testing.T) {
+		jobManager := initJobManager(ctrl)
+		done := make(chan error, 1)
+
+		jobManager.Go("blocking", func(ctx context.Context) {
+			<-ctx.Done()
+			done <- ctx.Err()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, jobManager.Shutdown(ctx), context.DeadlineExceeded)
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		default:
+			t.Fatal("job did not return before shutdown did")
+		}
+	})
+
+	t.Run("drops jobs started after shutdown began", func(t *testing.T) {
+		jobManager := initJobManager(ctrl)
+		assert.NoError(t, jobManager.Shutdown(context.Background()))
+
+		started := make(chan struct{}, 1)
+		jobManager.Go("late", func(ctx context.Context) {
+			started <- struct{}{}
+		})
+
+		select {
+		case <-started:
+			t.Fatal("job started after shutdown")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	tryAdvisoryLock = `SELECT pg_try_advisory_lock(hashtextextended($1, 0))`
+	advisoryUnlock  = `SELECT pg_advisory_unlock(hashtextextended($1, 0))`
+)
+
+var errLockNotHeld = errors.New("advisory lock was not held")
+
+// LockSvc takes a named lock shared by every replica without waiting, held until release is called. The Postgres
+// implementation guards the scheduler jobs, and CacheSvc implements it on Redis for the cache refresh lock.
+type LockSvc interface {
+	TryLock(ctx context.Context, key string) (release func(), acquired bool, err error)
+}
+
+// lockConn is the session the advisory locks of a replica are held on
+type lockConn interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Ping(ctx context.Context) error
+	IsClosed() bool
+	Release()
+	Close(ctx context.Context) error
+}
+
+type pooledLockConn struct {
+	conn *pgxpool.Conn
+}
+
+func (c pooledLockConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return c.conn.QueryRow(ctx, sql, args...)
+}
+
+func (c pooledLockConn) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+func (c pooledLockConn) IsClosed() bool {
+	return c.conn.Conn().IsClosed()
+}
+
+func (c pooledLockConn) Release() {
+	c.conn.Release()
+}
+
+// Close ends the session instead of handing the connection back to the pool
+func (c pooledLockConn) Close(ctx context.Context) error {
+	return c.conn.Hijack().Close(ctx)
+}
+
+type LockSvcImpl struct {
+	acquire func(ctx context.Context) (lockConn, error)
+	logger  LoggerSvc
+
+	mu   sync.Mutex
+	conn lockConn
+	// held maps every key locked by this replica to the generation of the connection it was locked on, which is bumped
+	// with each new connection, so the keys lost with a dropped one stay refused until their jobs release them
+	held       map[string]uint64
+	generation uint64
+	dirty      bool
+}
+
+func NewLockSvc(db PGXPool, logger LoggerSvc) LockSvc {
+	return &LockSvcImpl{
+		acquire: func(ctx context.Context) (lockConn, error) {
+			conn, err := db.Acquire(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return pooledLockConn{conn: conn}, nil
+		},
+		logger: logger,
+		held:   map[string]uint64{},
+	}
+}
+
+// TryLock takes a Postgres session advisory lock on key without waiting, so every replica sharing the database sees it.
+// Every lock of the replica is held on a single connection, taken from the pool with the first lock and handed back with
+// the last one, so holding many locks pins one connection only, and they are all freed with it when the replica dies.
+// The connection is pinged before another lock is taken on it, and when it dropped, the locks Postgres freed with its
+// session are reported as lost and the new lock is taken on a fresh connection.
+func (s *LockSvcImpl) TryLock(ctx context.Context, key string) (func(), bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		if err := s.conn.Ping(ctx); err != nil {
+			s.loseConn(ctx, err)
+		}
+	}
+
+	// Session locks are reentrant, so a key this replica already holds is refused here
+	if _, ok := s.held[key]; ok {
+		return nil, false, nil
+	}
+
+	if s.conn == nil {
+		conn, err := s.acquire(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		s.conn = conn
+		s.generation++
+	}
+
+	var acquired bool
+	if err := s.conn.QueryRow(ctx, tryAdvisoryLock, key).Scan(&acquired); err != nil {
+		if s.conn.IsClosed() {
+			s.loseConn(ctx, err)
+			return nil, false, err
+		}
+		s.dirty = true
+		s.releaseConn(ctx)
+		return nil, false, err
+	}
+
+	if !acquired {
+		s.releaseConn(ctx)
+		return nil, false, nil
+	}
+
+	s.held[key] = s.generation
+	generation := s.generation
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.unlock(ctx, key, generation)
+		})
+	}, true, nil
+}
+
+func (s *LockSvcImpl) unlock(ctx context.Context, key string, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.held, key)
+	// A lock taken on a connection that dropped since was already freed by Postgres and reported as lost
+	if s.conn == nil || generation != s.generation {
+		return
+	}
+
+	var unlocked bool
+	err := s.conn.QueryRow(context.WithoutCancel(ctx), advisoryUnlock, key).Scan(&unlocked)
+	if err == nil && !unlocked {
+		err = errLockNotHeld
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Error releasing lock", zap.String("key", key), zap.Error(err))
+		if s.conn.IsClosed() {
+			s.loseConn(ctx, err)
+			return
+		}
+		s.dirty = true
+	}
+
+	s.releaseConn(ctx)
+}
+
+// loseConn drops a connection that is gone along with its session, so the locks still held on it were freed by Postgres
+// and another replica may take them while their jobs are still running here. They are logged as lost, and their release
+// does not unlock the keys on the next connection.
+func (s *LockSvcImpl) loseConn(ctx context.Context, err error) {
+	if keys := s.heldOnConn(); len(keys) > 0 {
+		s.logger.WithContext(ctx).Error("Lost locks with their connection", zap.Strings("keys", keys), zap.Error(err))
+		AddJobLocksLost(len(keys))
+	}
+
+	if closeErr := s.conn.Close(context.WithoutCancel(ctx)); closeErr != nil {
+		s.logger.WithContext(ctx).Error("Error closing lock connection", zap.Error(closeErr))
+	}
+
+	s.conn = nil
+	s.dirty = false
+}
+
+// heldOnConn lists the keys locked on the current connection
+func (s *LockSvcImpl) heldOnConn() []string {
+	var keys []string
+	for key, generation := range s.held {
+		if generation == s.generation {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// releaseConn hands the connection back to the pool once it holds no lock. A connection that may still hold
+// one, after a failed lock or unlock, is closed instead so its session ends rather than going back to the pool.
+func (s *LockSvcImpl) releaseConn(ctx context.Context) {
+	if s.conn == nil || len(s.heldOnConn()) > 0 {
+		return
+	}
+
+	if s.dirty {
+		if err := s.conn.Close(context.WithoutCancel(ctx)); err != nil {
+			s.logger.WithContext(ctx).Error("Error closing lock connection", zap.Error(err))
+		}
+	} else {
+		s.conn.Release()
+	}
+
+	s.conn = nil
+	s.dirty = false
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var errConnDropped = errors.New("connection dropped")
+
+type fakeRow struct {
+	value bool
+	err   error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	*dest[0].(*bool) = r.value
+	return nil
+}
+
+// fakeLockConn is a lock session whose locks are freed with it once it drops
+type fakeLockConn struct {
+	dropped bool
+	closed  bool
+	locked  map[string]bool
+	unlocks []string
+}
+
+func (c *fakeLockConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if c.dropped {
+		return fakeRow{err: errConnDropped}
+	}
+
+	key := args[0].(string)
+	if sql == advisoryUnlock {
+		c.unlocks = append(c.unlocks, key)
+		held := c.locked[key]
+		delete(c.locked, key)
+		return fakeRow{value: held}
+	}
+
+	c.locked[key] = true
+	return fakeRow{value: true}
+}
+
+func (c *fakeLockConn) Ping(ctx context.Context) error {
+	if c.dropped {
+		return errConnDropped
+	}
+	return nil
+}
+
+func (c *fakeLockConn) IsClosed() bool {
+	return c.dropped || c.closed
+}
+
+func (c *fakeLockConn) Release() {}
+
+func (c *fakeLockConn) Close(ctx context.Context) error {
+	c.closed = true
+	return nil
+}
+
+func initLockSvc() (*LockSvcImpl, *[]*fakeLockConn, *observer.ObservedLogs) {
+	core, logs := observer.New(zap.ErrorLevel)
+	conns := &[]*fakeLockConn{}
+
+	return &LockSvcImpl{
+		acquire: func(ctx context.Context) (lockConn, error) {
+			conn := &fakeLockConn{locked: map[string]bool{}}
+			*conns = append(*conns, conn)
+			return conn, nil
+		},
+		logger: &Logger{logger: zap.New(core)},
+		held:   map[string]uint64{},
+	}, conns, logs
+}
+
+func TestLockConnectionLoss(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("locks lost with a dropped connection are reported and the next lock takes a new one", func(t *testing.T) {
+		lockSvc, conns, logs := initLockSvc()
+		releaseA, acquired, err := lockSvc.TryLock(ctx, "job:a")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		(*conns)[0].dropped = true
+
+		releaseB, acquired, err := lockSvc.TryLock(ctx, "job:b")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		assert.Len(t, *conns, 2)
+		assert.True(t, (*conns)[0].closed)
+		lost := logs.FilterMessage("Lost locks with their connection").All()
+		if assert.Len(t, lost, 1) {
+			assert.Equal(t, []any{"job:a"}, lost[0].ContextMap()["keys"])
+		}
+
+		// The job that lost its lock is still running here, so its key stays refused until it is released
+		_, acquired, err = lockSvc.TryLock(ctx, "job:a")
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+
+		releaseA()
+		assert.Empty(t, (*conns)[1].unlocks)
+
+		releaseA, acquired, err = lockSvc.TryLock(ctx, "job:a")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		releaseA()
+		releaseB()
+		assert.Equal(t, []string{"job:a", "job:b"}, (*conns)[1].unlocks)
+	})
+
+	t.Run("connection dropped while a lock is held is detected on release", func(t *testing.T) {
+		lockSvc, conns, logs := initLockSvc()
+		release, acquired, err := lockSvc.TryLock(ctx, "job:a")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		(*conns)[0].dropped = true
+		release()
+
+		assert.True(t, (*conns)[0].closed)
+		assert.Equal(t, 1, logs.FilterMessage("Error releasing lock").Len())
+
+		release, acquired, err = lockSvc.TryLock(ctx, "job:a")
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		assert.Len(t, *conns, 2)
+		release()
+	})
+}
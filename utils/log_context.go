@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/go-chi/chi"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type logContextKey int
+
+const (
+	requestIDKey logContextKey = iota
+	runIDKey
+	validatorAddressKey
+)
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// ContextWithRunID tags the logs of a scheduler job run, in place of the request ID of a request
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey, runID)
+}
+
+// ContextWithValidatorAddress tags the logs with the validator being processed, for code that is not behind a {validatorAddress} route
+func ContextWithValidatorAddress(ctx context.Context, validatorAddress string) context.Context {
+	return context.WithValue(ctx, validatorAddressKey, validatorAddress)
+}
+
+// contextLogFields collects the request ID or run ID, route, validator address and trace ID carried by ctx
+func contextLogFields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if runID, ok := ctx.Value(runIDKey).(string); ok {
+		fields = append(fields, zap.String("run_id", runID))
+	}
+
+	validatorAddress, _ := ctx.Value(validatorAddressKey).(string)
+	if routeCtx := chi.RouteContext(ctx); routeCtx != nil {
+		if routeCtx.RoutePattern() != "" {
+			fields = append(fields, zap.String("route", routeCtx.RoutePattern()))
+		}
+		if validatorAddress == "" {
+			validatorAddress = routeCtx.URLParam("validatorAddress")
+		}
+	}
+	if validatorAddress != "" {
+		fields = append(fields, zap.String("validator_address", validatorAddress))
+	}
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields, zap.String("trace_id", spanCtx.TraceID().String()))
+	}
+
+	return fields
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -23,6 +25,7 @@ type LoggerSvc interface {
 	Debug(message string, fields ...zap.Field)
 	Error(message string, fields ...zap.Field)
 	Warn(message string, fields ...zap.Field)
+	WithContext(ctx context.Context) LoggerSvc
 }
 
 func NewLogger(config *BaseConfig) LoggerSvc {
@@ -63,3 +66,10 @@ func (l *Logger) Error(message string, fields ...zap.Field) {
 func (l *Logger) Warn(message string, fields ...zap.Field) {
 	l.logger.Warn(message, fields...)
 }
+
+// WithContext returns a logger that adds the request ID or job run ID, route, validator address and trace ID of ctx to every line
+func (l *Logger) WithContext(ctx context.Context) LoggerSvc {
+	return &Logger{
+		logger: l.logger.With(contextLogFields(ctx)...),
+	}
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const metricsNamespace = "validator_tracking"
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	cacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cache_requests_total",
+		Help:      "GetOrSetData lookups by cache key and result (hit, stale, miss, error or refresh_error).",
+	}, []string{"cache", "result"})
+
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "job_duration_seconds",
+		Help:      "Duration of the scheduler job runs.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800},
+	}, []string{"job"})
+
+	jobsSkippedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "jobs_skipped_total",
+		Help:      "Scheduler job runs skipped because another replica holds their lock.",
+	}, []string{"job"})
+
+	jobLocksLostTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "job_locks_lost_total",
+		Help:      "Job locks freed by Postgres while still held, because the connection holding them dropped.",
+	})
+
+	delegationsFetchedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "delegations_fetched_total",
+		Help:      "Delegations fetched from the cosmos LCD.",
+	})
+
+	snapshotsWrittenTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "snapshots_written_total",
+		Help:      "Delegation snapshot rows written by the hourly collector.",
+	})
+
+	lcdErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "lcd_errors_total",
+		Help:      "Failed cosmos LCD requests by status code, request errors without a response are counted as \"error\".",
+	}, []string{"status"})
+
+	dbTxRetryAttemptsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "db_tx_retry_attempts_total",
+		Help:      "Attempts made by ExecTxPoolWithRetry after a failed transaction, one per retry.",
+	})
+)
+
+func ObserveHTTPRequest(method string, route string, status int, start time.Time) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+}
+
+// observeCacheRequest labels the lookup by the cache key without its identifier and arguments, to keep the cardinality bounded
+func observeCacheRequest(key string, result string) {
+	cacheKey := strings.SplitN(strings.SplitN(key, "|", 2)[0], ":", 2)[0]
+	cacheRequestsTotal.WithLabelValues(cacheKey, result).Inc()
+}
+
+func ObserveJobDuration(job string, start time.Time) {
+	jobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+}
+
+func IncJobSkipped(job string) {
+	jobsSkippedTotal.WithLabelValues(job).Inc()
+}
+
+func AddJobLocksLost(count int) {
+	jobLocksLostTotal.Add(float64(count))
+}
+
+func AddDelegationsFetched(count int) {
+	delegationsFetchedTotal.Add(float64(count))
+}
+
+func AddSnapshotsWritten(count int64) {
+	snapshotsWrittenTotal.Add(float64(count))
+}
+
+// IncLCDError counts a failed LCD request, a status code of 0 means the request got no response
+func IncLCDError(statusCode int) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	lcdErrorsTotal.WithLabelValues(status).Inc()
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+)
+
+type MetricsMiddlewareSvc interface {
+	Metrics(next http.Handler) http.Handler
+}
+
+type MetricsMiddlewareSvcImpl struct{}
+
+func NewMetricsMiddlewareSvc() MetricsMiddlewareSvc {
+	return &MetricsMiddlewareSvcImpl{}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Metrics records every request under its chi route pattern rather than its path, so path parameters
+// such as addresses do not create a series each. Requests that match no route are recorded as "unmatched".
+func (s *MetricsMiddlewareSvcImpl) Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		route := "unmatched"
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+			route = routeCtx.RoutePattern()
+		}
+		ObserveHTTPRequest(r.Method, route, recorder.statusCode, start)
+	})
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+// getHTTPRequestsTotal sums the http_requests_total series whose labels satisfy match
+func getHTTPRequestsTotal(t *testing.T, match func(labels map[string]string) bool) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+
+	var total float64
+	for _, family := range families {
+		if family.GetName() != metricsNamespace+"_http_requests_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if match(labels) {
+				total += metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return total
+}
+
+func TestMetrics(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(NewMetricsMiddlewareSvc().Metrics)
+	router.Get("/metrics-test/{validatorAddress}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	serve := func(path string) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	t.Run("requests are labelled by route pattern", func(t *testing.T) {
+		isRoute := func(labels map[string]string) bool {
+			return labels["method"] == http.MethodGet && labels["route"] == "/metrics-test/{validatorAddress}" && labels["status"] == "418"
+		}
+		before := getHTTPRequestsTotal(t, isRoute)
+
+		serve("/metrics-test/cosmosvaloper1aaa")
+		serve("/metrics-test/cosmosvaloper1bbb")
+
+		assert.Equal(t, before+2, getHTTPRequestsTotal(t, isRoute))
+		assert.Zero(t, getHTTPRequestsTotal(t, func(labels map[string]string) bool {
+			return strings.Contains(labels["route"], "cosmosvaloper1")
+		}))
+	})
+
+	t.Run("unmatched requests share a single label", func(t *testing.T) {
+		isUnmatched := func(labels map[string]string) bool {
+			return labels["route"] == "unmatched" && labels["status"] == "404"
+		}
+		before := getHTTPRequestsTotal(t, isUnmatched)
+
+		serve("/unknown-route")
+
+		assert.Equal(t, before+1, getHTTPRequestsTotal(t, isUnmatched))
+		assert.Zero(t, getHTTPRequestsTotal(t, func(labels map[string]string) bool {
+			return labels["route"] == "/unknown-route"
+		}))
+	})
+}
@@ -2,10 +2,13 @@ package utils
 
 import (
 	"database/sql"
+	"errors"
+	"os"
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
 )
@@ -33,3 +36,29 @@ func RunMigrationPool(db *sql.DB, config *BaseConfig) error {
 
 	return nil
 }
+
+// GetLatestMigrationVersion returns the version of the last migration shipped with the service,
+// which is the version the database is expected to be at once RunMigrationPool is done
+func GetLatestMigrationVersion(migrationURL string) (uint, error) {
+	driver, err := source.Open(migrationURL)
+	if err != nil {
+		return 0, err
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
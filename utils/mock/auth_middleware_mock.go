@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./utils/auth_middleware.go
+
+// Package mockutl is a generated GoMock package.
+package mockutl
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	utils "github.com/gadhittana01/cosmos-validation-tracking/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeyVerifier is a mock of APIKeyVerifier interface.
+type MockAPIKeyVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyVerifierMockRecorder
+}
+
+// MockAPIKeyVerifierMockRecorder is the mock recorder for MockAPIKeyVerifier.
+type MockAPIKeyVerifierMockRecorder struct {
+	mock *MockAPIKeyVerifier
+}
+
+// NewMockAPIKeyVerifier creates a new mock instance.
+func NewMockAPIKeyVerifier(ctrl *gomock.Controller) *MockAPIKeyVerifier {
+	mock := &MockAPIKeyVerifier{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyVerifier) EXPECT() *MockAPIKeyVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyAPIKey mocks base method.
+func (m *MockAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (utils.APIKeyIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAPIKey", ctx, key)
+	ret0, _ := ret[0].(utils.APIKeyIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAPIKey indicates an expected call of VerifyAPIKey.
+func (mr *MockAPIKeyVerifierMockRecorder) VerifyAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAPIKey", reflect.TypeOf((*MockAPIKeyVerifier)(nil).VerifyAPIKey), ctx, key)
+}
+
+// MockAuthMiddlewareSvc is a mock of AuthMiddlewareSvc interface.
+type MockAuthMiddlewareSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMiddlewareSvcMockRecorder
+}
+
+// MockAuthMiddlewareSvcMockRecorder is the mock recorder for MockAuthMiddlewareSvc.
+type MockAuthMiddlewareSvcMockRecorder struct {
+	mock *MockAuthMiddlewareSvc
+}
+
+// NewMockAuthMiddlewareSvc creates a new mock instance.
+func NewMockAuthMiddlewareSvc(ctrl *gomock.Controller) *MockAuthMiddlewareSvc {
+	mock := &MockAuthMiddlewareSvc{ctrl: ctrl}
+	mock.recorder = &MockAuthMiddlewareSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthMiddlewareSvc) EXPECT() *MockAuthMiddlewareSvcMockRecorder {
+	return m.recorder
+}
+
+// RequireScope mocks base method.
+func (m *MockAuthMiddlewareSvc) RequireScope(scope string) func(http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequireScope", scope)
+	ret0, _ := ret[0].(func(http.Handler) http.Handler)
+	return ret0
+}
+
+// RequireScope indicates an expected call of RequireScope.
+func (mr *MockAuthMiddlewareSvcMockRecorder) RequireScope(scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireScope", reflect.TypeOf((*MockAuthMiddlewareSvc)(nil).RequireScope), scope)
+}
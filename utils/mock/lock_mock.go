@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./utils/lock.go
+
+// Package mockutl is a generated GoMock package.
+package mockutl
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockSvc is a mock of LockSvc interface.
+type MockLockSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockSvcMockRecorder
+}
+
+// MockLockSvcMockRecorder is the mock recorder for MockLockSvc.
+type MockLockSvcMockRecorder struct {
+	mock *MockLockSvc
+}
+
+// NewMockLockSvc creates a new mock instance.
+func NewMockLockSvc(ctrl *gomock.Controller) *MockLockSvc {
+	mock := &MockLockSvc{ctrl: ctrl}
+	mock.recorder = &MockLockSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockSvc) EXPECT() *MockLockSvcMockRecorder {
+	return m.recorder
+}
+
+// TryLock mocks base method.
+func (m *MockLockSvc) TryLock(ctx context.Context, key string) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, key)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockLockSvcMockRecorder) TryLock(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockLockSvc)(nil).TryLock), ctx, key)
+}
@@ -5,8 +5,10 @@
 package mockutl
 
 import (
+	context "context"
 	reflect "reflect"
 
+	utils "github.com/gadhittana01/cosmos-validation-tracking/utils"
 	gomock "github.com/golang/mock/gomock"
 	zap "go.uber.org/zap"
 )
@@ -135,3 +137,17 @@ func (mr *MockLoggerSvcMockRecorder) Warn(message interface{}, fields ...interfa
 	varargs := append([]interface{}{message}, fields...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLoggerSvc)(nil).Warn), varargs...)
 }
+
+// WithContext mocks base method.
+func (m *MockLoggerSvc) WithContext(ctx context.Context) utils.LoggerSvc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(utils.LoggerSvc)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockLoggerSvcMockRecorder) WithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockLoggerSvc)(nil).WithContext), ctx)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./utils/rate_limit_middleware.go
+
+// Package mockutl is a generated GoMock package.
+package mockutl
+
+import (
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateLimitMiddlewareSvc is a mock of RateLimitMiddlewareSvc interface.
+type MockRateLimitMiddlewareSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitMiddlewareSvcMockRecorder
+}
+
+// MockRateLimitMiddlewareSvcMockRecorder is the mock recorder for MockRateLimitMiddlewareSvc.
+type MockRateLimitMiddlewareSvcMockRecorder struct {
+	mock *MockRateLimitMiddlewareSvc
+}
+
+// NewMockRateLimitMiddlewareSvc creates a new mock instance.
+func NewMockRateLimitMiddlewareSvc(ctrl *gomock.Controller) *MockRateLimitMiddlewareSvc {
+	mock := &MockRateLimitMiddlewareSvc{ctrl: ctrl}
+	mock.recorder = &MockRateLimitMiddlewareSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitMiddlewareSvc) EXPECT() *MockRateLimitMiddlewareSvcMockRecorder {
+	return m.recorder
+}
+
+// RateLimit mocks base method.
+func (m *MockRateLimitMiddlewareSvc) RateLimit(group string, limit int, window time.Duration) func(http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimit", group, limit, window)
+	ret0, _ := ret[0].(func(http.Handler) http.Handler)
+	return ret0
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockRateLimitMiddlewareSvcMockRecorder) RateLimit(group, limit, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockRateLimitMiddlewareSvc)(nil).RateLimit), group, limit, window)
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+}
+
+type LocalObjectStorage struct {
+	dir string
+}
+
+type S3ObjectStorage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewObjectStorage(config *BaseConfig) ObjectStorage {
+	if config.ExportStorage == constant.ExportStorageS3 {
+		return NewS3ObjectStorage(config)
+	}
+
+	return NewLocalObjectStorage(config.ExportLocalDir)
+}
+
+func NewLocalObjectStorage(dir string) ObjectStorage {
+	return &LocalObjectStorage{
+		dir: dir,
+	}
+}
+
+func NewS3ObjectStorage(config *BaseConfig) ObjectStorage {
+	client, err := minio.New(config.ExportS3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.ExportS3AccessKey, config.ExportS3SecretKey, ""),
+		Secure: config.ExportS3UseSSL,
+		Region: config.ExportS3Region,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return &S3ObjectStorage{
+		client: client,
+		bucket: config.ExportS3Bucket,
+	}
+}
+
+// Put writes the object to a temporary file first so a failed write never leaves a partial object behind
+func (s *LocalObjectStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (s *S3ObjectStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"go.uber.org/zap"
+)
+
+type RateLimitMiddlewareSvc interface {
+	RateLimit(group string, limit int, window time.Duration) func(next http.Handler) http.Handler
+}
+
+type RateLimitMiddlewareSvcImpl struct {
+	cache          CacheSvc
+	logger         LoggerSvc
+	trustedProxies []*net.IPNet
+}
+
+func NewRateLimitMiddlewareSvc(config *BaseConfig, cache CacheSvc, logger LoggerSvc) RateLimitMiddlewareSvc {
+	return &RateLimitMiddlewareSvcImpl{
+		cache:          cache,
+		logger:         logger,
+		trustedProxies: parseTrustedProxies(config.RateLimitTrustedProxies, logger),
+	}
+}
+
+// RateLimit allows limit requests per client in any sliding window, a limit of zero disabling it. The window is approximated
+// by a counter per fixed window, the previous one weighted by how much of it still overlaps the sliding window. A failing
+// Redis lets requests through rather than taking the API down with it.
+func (s *RateLimitMiddlewareSvcImpl) RateLimit(group string, limit int, window time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 || window <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			now := time.Now()
+			windowStart := now.Truncate(window)
+			client := s.getRateLimitClient(r)
+			key := BuildPrefixKey(constant.RateLimitCacheKey, group, client, strconv.FormatInt(windowStart.Unix(), 10))
+			previousKey := BuildPrefixKey(constant.RateLimitCacheKey, group, client, strconv.FormatInt(windowStart.Add(-window).Unix(), 10))
+
+			count, err := s.cache.IncrWithExpire(ctx, key, 2*window)
+			if err != nil {
+				s.logger.WithContext(r.Context()).Error("Error counting rate limited request", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			previousCount, err := s.cache.GetCount(ctx, previousKey)
+			if err != nil {
+				s.logger.WithContext(r.Context()).Error("Error getting rate limit counter", zap.Error(err))
+			}
+
+			elapsed := now.Sub(windowStart)
+			weight := float64(window-elapsed) / float64(window)
+			used := int(math.Ceil(float64(previousCount)*weight + float64(count)))
+			reset := int(math.Ceil((window - elapsed).Seconds()))
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(limit-used, 0)))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(reset))
+
+			if used > limit {
+				w.Header().Set("Retry-After", strconv.Itoa(reset))
+				GenerateProblemResp(w, r, s.logger, WrapAppError(fmt.Errorf("rate limited %s %s", group, client), constant.ErrorCodeRateLimited, "too many requests", http.StatusTooManyRequests))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Clients are told apart by the API key they were authenticated with, or else by their IP. An API key that was not
+// verified, as on the read routes when AUTH_READ_REQUIRED is off, is ignored so a client cannot get a fresh limit
+// by sending made up keys.
+func (s *RateLimitMiddlewareSvcImpl) getRateLimitClient(r *http.Request) string {
+	if id, ok := APIKeyIDFromContext(r.Context()); ok {
+		return "key:" + id
+	}
+
+	return "ip:" + s.getClientIP(r)
+}
+
+// getClientIP is the peer address, unless it is a trusted proxy. X-Forwarded-For is then walked from the right, every
+// entry having been appended by the hop before it, up to the first address that is not a trusted proxy, so a client
+// cannot pick its IP by sending the header itself.
+func (s *RateLimitMiddlewareSvcImpl) getClientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	if !s.isTrustedProxy(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwarded[i])
+		if hop == "" {
+			continue
+		}
+
+		ip = hop
+		if !s.isTrustedProxy(hop) {
+			break
+		}
+	}
+
+	return ip
+}
+
+func (s *RateLimitMiddlewareSvcImpl) isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, proxy := range s.trustedProxies {
+		if proxy.Contains(parsed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTrustedProxies reads the comma separated IPs and CIDRs of RATE_LIMIT_TRUSTED_PROXIES, skipping invalid entries
+func parseTrustedProxies(proxies string, logger LoggerSvc) []*net.IPNet {
+	trustedProxies := make([]*net.IPNet, 0)
+	for _, proxy := range strings.Split(proxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			logger.Error("Invalid trusted proxy", zap.String("proxy", proxy), zap.Error(err))
+			continue
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+
+	return trustedProxies
+}
@@ -0,0 +1,106 @@
+package utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	mockutl "github.com/gadhittana01/cosmos-validation-tracking/utils/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func initRateLimitMiddleware(t *testing.T, ctrl *gomock.Controller, trustedProxies string) utils.RateLimitMiddlewareSvc {
+	config := &utils.BaseConfig{}
+	utils.LoadBaseConfig("../config", "test", config)
+	config.RateLimitTrustedProxies = trustedProxies
+	mockLogger := mockutl.NewMockLoggerSvc(ctrl)
+	mockutl.LoggerMock(mockLogger)
+
+	return utils.NewRateLimitMiddlewareSvc(config, utils.InitCacheSvc(t, config, mockLogger), mockLogger)
+}
+
+func serveRateLimited(handler http.Handler, remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/validators", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("limit is enforced and resets after the window", func(t *testing.T) {
+		window := time.Second
+		handler := initRateLimitMiddleware(t, ctrl, "").RateLimit("enforced", 2, window)(next)
+
+		// Start at the beginning of a window, so the burst is not split across two of them
+		time.Sleep(time.Until(time.Now().Truncate(window).Add(window)))
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "192.0.2.1:1234", "").Code)
+		rec := serveRateLimited(handler, "192.0.2.1:1234", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+		rec = serveRateLimited(handler, "192.0.2.1:1234", "")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+		time.Sleep(2 * window)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "192.0.2.1:1234", "").Code)
+	})
+
+	t.Run("clients are limited apart by ip", func(t *testing.T) {
+		handler := initRateLimitMiddleware(t, ctrl, "").RateLimit("by_ip", 1, time.Minute)(next)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "192.0.2.1:1234", "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "192.0.2.1:5678", "").Code)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "192.0.2.2:1234", "").Code)
+	})
+
+	t.Run("clients are limited apart by verified api key", func(t *testing.T) {
+		handler := initRateLimitMiddleware(t, ctrl, "").RateLimit("by_key", 1, time.Minute)(next)
+		serve := func(id string) int {
+			req := httptest.NewRequest(http.MethodGet, "/validators", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			req = req.WithContext(utils.ContextWithAPIKeyID(req.Context(), id))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusOK, serve("key-a"))
+		assert.Equal(t, http.StatusTooManyRequests, serve("key-a"))
+		assert.Equal(t, http.StatusOK, serve("key-b"))
+	})
+
+	t.Run("forwarded for of an untrusted peer is ignored", func(t *testing.T) {
+		handler := initRateLimitMiddleware(t, ctrl, "").RateLimit("untrusted_proxy", 1, time.Minute)(next)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "192.0.2.1:1234", "198.51.100.1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "192.0.2.1:1234", "198.51.100.2").Code)
+	})
+
+	t.Run("forwarded for of a trusted proxy is walked up to the client", func(t *testing.T) {
+		handler := initRateLimitMiddleware(t, ctrl, "10.0.0.0/8").RateLimit("trusted_proxy", 1, time.Minute)(next)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "10.0.0.1:1234", "198.51.100.1").Code)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "10.0.0.1:1234", "198.51.100.2, 10.0.0.2").Code)
+		// A spoofed leftmost entry does not hide the address appended by the proxy
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "10.0.0.1:1234", "203.0.113.9, 198.51.100.1").Code)
+	})
+}
@@ -1,18 +1,24 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 	"github.com/go-chi/chi"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
+var errNegativeQueryParam = errors.New("must not be negative")
+
 func generateValidationParamErrorMsg(paramName string) string {
 	return fmt.Sprintf("invalid param %s", paramName)
 }
@@ -29,7 +35,7 @@ func ValidateURLParamUUID(r *http.Request, paramName string, defaultValue ...uui
 		if len(defaultValue) > 0 {
 			uuid = defaultValue[0]
 		} else {
-			PanicIfError(CustomErrorWithTrace(err, generateValidationParamErrorMsg(paramName), 400))
+			PanicIfAppError(err, constant.ErrorCodeInvalidRequest, generateValidationParamErrorMsg(paramName), http.StatusBadRequest)
 		}
 	}
 
@@ -50,12 +56,24 @@ func ValidateURLParamString(r *http.Request, paramName string, defaultValue ...s
 
 func ValidateBodyPayload[T any](body io.ReadCloser, output *T) T {
 	err := JSONiter().NewDecoder(body).Decode(output)
-	PanicIfAppError(err, "failed when decode body payload", 400)
+	PanicIfAppError(err, constant.ErrorCodeInvalidRequest, "failed when decode body payload", http.StatusBadRequest)
 
 	ValidateStruct(output)
 	return *output
 }
 
+func ValidateQueryParamString(r *http.Request, queryName string, defaultValue ...string) string {
+	query := r.URL.Query().Get(queryName)
+
+	if query == "" {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+	}
+
+	return query
+}
+
 func ValidateQueryParamInt(r *http.Request, queryName string, defaultValue ...int) int {
 	var queryInt int
 	var err error
@@ -63,9 +81,10 @@ func ValidateQueryParamInt(r *http.Request, queryName string, defaultValue ...in
 
 	if query != "" {
 		queryInt, err = strconv.Atoi(query)
-		if err != nil || queryInt < 0 {
-			PanicIfError(CustomErrorWithTrace(err, generateValidationQueryErrorMsg(queryName), 400))
+		if err == nil && queryInt < 0 {
+			err = errNegativeQueryParam
 		}
+		PanicIfAppError(err, constant.ErrorCodeInvalidRequest, generateValidationQueryErrorMsg(queryName), http.StatusBadRequest)
 	} else if len(defaultValue) > 0 {
 		queryInt = defaultValue[0]
 	}
@@ -73,6 +92,89 @@ func ValidateQueryParamInt(r *http.Request, queryName string, defaultValue ...in
 	return queryInt
 }
 
+func ValidateQueryParamBool(r *http.Request, queryName string, defaultValue ...bool) bool {
+	var queryBool bool
+	var err error
+	query := r.URL.Query().Get(queryName)
+
+	if query != "" {
+		queryBool, err = strconv.ParseBool(query)
+		if err != nil {
+			PanicIfAppError(err, constant.ErrorCodeInvalidRequest, generateValidationQueryErrorMsg(queryName), http.StatusBadRequest)
+		}
+	} else if len(defaultValue) > 0 {
+		queryBool = defaultValue[0]
+	}
+
+	return queryBool
+}
+
+func ValidateQueryParamTimezone(r *http.Request, queryName string, defaultValue ...string) string {
+	query := r.URL.Query().Get(queryName)
+
+	if query == "" {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		return query
+	}
+
+	_, err := time.LoadLocation(query)
+	if err != nil {
+		PanicIfAppError(err, constant.ErrorCodeInvalidTimezone, generateValidationQueryErrorMsg(queryName), http.StatusBadRequest)
+	}
+
+	return query
+}
+
+func ValidateQueryParamDate(r *http.Request, queryName string, defaultValue ...string) string {
+	query := r.URL.Query().Get(queryName)
+
+	if query == "" {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		return query
+	}
+
+	_, err := time.Parse(constant.DateFormat, query)
+	if err != nil {
+		PanicIfAppError(err, constant.ErrorCodeInvalidRequest, generateValidationQueryErrorMsg(queryName), http.StatusBadRequest)
+	}
+
+	return query
+}
+
+func ValidateQueryParamTimestamp(r *http.Request, queryName string, defaultValue ...string) string {
+	query := r.URL.Query().Get(queryName)
+
+	if query == "" {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		return query
+	}
+
+	_, err := time.Parse(constant.TimeFormat, query)
+	if err != nil {
+		PanicIfAppError(err, constant.ErrorCodeInvalidRequest, generateValidationQueryErrorMsg(queryName), http.StatusBadRequest)
+	}
+
+	return query
+}
+
+func ValidateQueryParamStringList(r *http.Request, queryName string) []string {
+	values := []string{}
+	for _, value := range strings.Split(r.URL.Query().Get(queryName), ",") {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
 func ValidateStruct(data interface{}) {
 	var validationErrors []ValidationError
 	validate := validator.New()
@@ -95,6 +197,6 @@ func ValidateStruct(data interface{}) {
 			validationError.Tag = err.Tag()
 			validationErrors = append(validationErrors, validationError)
 		}
-		PanicValidationError(validationErrors, 400)
+		PanicValidationError(validationErrors, http.StatusBadRequest)
 	}
 }
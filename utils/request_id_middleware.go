@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/google/uuid"
+)
+
+type RequestIDMiddlewareSvc interface {
+	RequestID(next http.Handler) http.Handler
+}
+
+type RequestIDMiddlewareSvcImpl struct{}
+
+func NewRequestIDMiddlewareSvc() RequestIDMiddlewareSvc {
+	return &RequestIDMiddlewareSvcImpl{}
+}
+
+// RequestID keeps the X-Request-ID sent by the caller, or generates one, and returns it on the response.
+// IDs longer than RequestIDMaxLength are replaced so a caller cannot bloat every log line.
+func (s *RequestIDMiddlewareSvcImpl) RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(constant.RequestIDHeader)
+		if requestID == "" || len(requestID) > constant.RequestIDMaxLength {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(constant.RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(ContextWithRequestID(r.Context(), requestID)))
+	})
+}
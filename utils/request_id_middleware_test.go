@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	var contextRequestID string
+	handler := NewRequestIDMiddlewareSvc().RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextRequestID, _ = r.Context().Value(requestIDKey).(string)
+	}))
+
+	serve := func(requestID string) string {
+		contextRequestID = ""
+		req := httptest.NewRequest(http.MethodGet, "/validators", nil)
+		if requestID != "" {
+			req.Header.Set(constant.RequestIDHeader, requestID)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Header().Get(constant.RequestIDHeader)
+	}
+
+	t.Run("request ID is generated when none is sent", func(t *testing.T) {
+		requestID := serve("")
+
+		_, err := uuid.Parse(requestID)
+		assert.NoError(t, err)
+		assert.Equal(t, requestID, contextRequestID)
+	})
+
+	t.Run("request ID sent by the caller is propagated", func(t *testing.T) {
+		requestID := serve("caller-request-id")
+
+		assert.Equal(t, "caller-request-id", requestID)
+		assert.Equal(t, "caller-request-id", contextRequestID)
+	})
+
+	t.Run("request ID longer than the limit is replaced", func(t *testing.T) {
+		tooLong := strings.Repeat("a", constant.RequestIDMaxLength+1)
+		requestID := serve(tooLong)
+
+		assert.NotEqual(t, tooLong, requestID)
+		_, err := uuid.Parse(requestID)
+		assert.NoError(t, err)
+		assert.Equal(t, requestID, contextRequestID)
+	})
+}
@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
 )
 
 type SuccessResponse[T any] struct {
@@ -11,11 +15,17 @@ type SuccessResponse[T any] struct {
 	Data       T    `json:"data"`
 }
 
-type FailedResponse[T any] struct {
-	Success    bool `json:"success"`
-	StatusCode int  `json:"statusCode"`
-	ErrorCode  int  `json:"errorCode"`
-	Errors     T    `json:"errors"`
+// ProblemResponse is an RFC 7807 problem details body, extended with the machine readable code,
+// the request ID and the failed fields of a validation error.
+type ProblemResponse struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code"`
+	RequestID string            `json:"requestId,omitempty"`
+	Errors    []ValidationError `json:"errors,omitempty"`
 }
 
 // FOR TESTING PURPOSE
@@ -55,17 +65,11 @@ func GenerateSuccessResp[T any](w http.ResponseWriter, data T, statusCode int) {
 	}
 }
 
-func GenerateErrorResp[T any](w http.ResponseWriter, data T, statusCode int, errorCode ...int) {
+func GenerateDefaultResp(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
-	response := FailedResponse[T]{
-		Success:    false,
-		StatusCode: statusCode,
-		Errors:     data,
-	}
-
-	responseEncode, err := Marshal(response)
+	responseEncode, err := Marshal(data)
 	if err != nil {
 		panic(err)
 	}
@@ -76,15 +80,44 @@ func GenerateErrorResp[T any](w http.ResponseWriter, data T, statusCode int, err
 	}
 }
 
-func GenerateDefaultResp(w http.ResponseWriter, data interface{}, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+// GenerateProblemResp is the single place errors are turned into a response, it writes err as
+// problem+json and logs it, server errors with their cause and client errors as warnings.
+func GenerateProblemResp(w http.ResponseWriter, r *http.Request, logger LoggerSvc, err error) {
+	appErr := ToAppError(err)
+	logger = logger.WithContext(r.Context())
 
-	responseEncode, err := Marshal(data)
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		logger.Error(fmt.Sprintf("APP ERROR %s", appErr.Error()))
+	} else {
+		logger.Warn(fmt.Sprintf("APP ERROR %s", appErr.Error()))
+	}
+
+	response := ProblemResponse{
+		Type:     constant.ProblemTypeDefault,
+		Title:    http.StatusText(appErr.StatusCode),
+		Status:   appErr.StatusCode,
+		Detail:   appErr.Message,
+		Instance: r.URL.Path,
+		Code:     appErr.Code,
+	}
+
+	if requestID, ok := r.Context().Value(requestIDKey).(string); ok {
+		response.RequestID = requestID
+	}
+
+	var validationErrs ValidationErrors
+	if errors.As(appErr, &validationErrs) {
+		response.Errors = validationErrs.Errors
+	}
+
+	responseEncode, err := Marshal(response)
 	if err != nil {
 		panic(err)
 	}
 
+	w.Header().Set("Content-Type", constant.ProblemContentType)
+	w.WriteHeader(appErr.StatusCode)
+
 	_, err = w.Write(responseEncode)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/gadhittana01/cosmos-validation-tracking/constant"
+)
+
+func RetryWithBackoff(ctx context.Context, retryCount int, backoff time.Duration, fn func() error) error {
+	if retryCount <= 0 {
+		retryCount = constant.RetryCount
+	}
+
+	err := fn()
+	for i := 0; i < retryCount && err != nil; i++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		err = fn()
+	}
+
+	return err
+}
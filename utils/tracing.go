@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/gadhittana01/cosmos-validation-tracking"
+
+type TracerSvc interface {
+	Shutdown(ctx context.Context) error
+}
+
+type TracerSvcImpl struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewTracerSvc registers the global tracer provider and the W3C trace context propagator.
+// Spans are exported over OTLP/HTTP to TRACING_OTLP_ENDPOINT, an empty endpoint keeps tracing in process only.
+func NewTracerSvc(config *BaseConfig) TracerSvc {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", config.TracingServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TracingSampleRatio))),
+	}
+
+	if config.TracingOTLPEndpoint != "" {
+		exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.TracingOTLPEndpoint)}
+		if config.TracingOTLPInsecure {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(context.Background(), exporterOpts...)
+		if err != nil {
+			panic(err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return &TracerSvcImpl{
+		provider: provider,
+	}
+}
+
+// Shutdown flushes the spans that are not exported yet
+func (t *TracerSvcImpl) Shutdown(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}
+
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan ends the span, marking it as failed with the error stored in errp by the time it returns or
+// with the panic that is unwinding through it. It must be deferred directly to see the panic, which is
+// re-raised for the recovery middleware.
+func EndSpan(span trace.Span, errp *error) {
+	if err := recover(); err != nil {
+		span.RecordError(fmt.Errorf("%v", err))
+		span.SetStatus(codes.Error, fmt.Sprintf("%v", err))
+		span.End()
+		panic(err)
+	}
+
+	if errp != nil {
+		SetSpanError(span, *errp)
+	}
+	span.End()
+}
+
+// SetSpanError marks the span as failed, nil errors are ignored
+func SetSpanError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
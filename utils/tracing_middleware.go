@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewareSvc interface {
+	Tracing(next http.Handler) http.Handler
+}
+
+type TracingMiddlewareSvcImpl struct{}
+
+func NewTracingMiddlewareSvc() TracingMiddlewareSvc {
+	return &TracingMiddlewareSvcImpl{}
+}
+
+// Tracing starts a server span for every request, continuing the trace of the caller when it sends a traceparent header.
+// The span is named after the chi route pattern once the request is routed, the same way the metrics are labelled.
+func (s *TracingMiddlewareSvcImpl) Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := StartSpan(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+			span.SetName(fmt.Sprintf("%s %s", r.Method, routeCtx.RoutePattern()))
+			span.SetAttributes(attribute.String("http.route", routeCtx.RoutePattern()))
+		}
+
+		span.SetAttributes(attribute.Int("http.response.status_code", recorder.statusCode))
+		if recorder.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.statusCode))
+		}
+	})
+}
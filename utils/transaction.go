@@ -72,6 +72,7 @@ func ExecTxPoolWithRetry(ctx context.Context, DB PGXPool, retryCount int, fn fun
 	}
 
 	for i := 0; i < retryCount; i++ {
+		dbTxRetryAttemptsTotal.Inc()
 		err = retryFunc()
 		if err == nil {
 			return nil
@@ -0,0 +1,72 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockrepo "github.com/gadhittana01/cosmos-validation-tracking/db/repository/mock"
+	"github.com/gadhittana01/cosmos-validation-tracking/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTx = errors.New("tx failed")
+
+// getDBTxRetryAttemptsTotal reads the db_tx_retry_attempts_total counter
+func getDBTxRetryAttemptsTotal(t *testing.T) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == "validator_tracking_db_tx_retry_attempts_total" {
+			return family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+
+	return 0
+}
+
+func TestExecTxPoolWithRetry(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("retry attempts are counted until the transaction commits", func(t *testing.T) {
+		mockDB := mockrepo.NewMockPGXPool(ctrl)
+		mockTx := mockrepo.NewMockPgxIface(ctrl)
+		mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(2)
+		mockTx.EXPECT().Rollback(gomock.Any()).Return(nil).Times(1)
+		mockTx.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+		before := getDBTxRetryAttemptsTotal(t)
+
+		attempts := 0
+		err := utils.ExecTxPoolWithRetry(ctx, mockDB, 3, func(tx pgx.Tx) error {
+			attempts++
+			if attempts == 1 {
+				return errTx
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, getDBTxRetryAttemptsTotal(t))
+	})
+
+	t.Run("every retry attempt of a failing transaction is counted", func(t *testing.T) {
+		mockDB := mockrepo.NewMockPGXPool(ctrl)
+		mockTx := mockrepo.NewMockPgxIface(ctrl)
+		mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(3)
+		mockTx.EXPECT().Rollback(gomock.Any()).Return(nil).Times(3)
+		before := getDBTxRetryAttemptsTotal(t)
+
+		err := utils.ExecTxPoolWithRetry(ctx, mockDB, 2, func(tx pgx.Tx) error {
+			return errTx
+		})
+
+		assert.ErrorIs(t, err, errTx)
+		assert.Equal(t, before+2, getDBTxRetryAttemptsTotal(t))
+	})
+}
@@ -25,13 +25,28 @@ func InitializeApp(route *chi.Mux, DB utils.PGXPool, config *utils.BaseConfig) (
 	loggerSvc := utils.NewLogger(config)
 	client := utils.NewRedisClient(config)
 	cacheSvc := utils.NewCacheSvc(config, client, loggerSvc)
-	validatorSvc := service.NewValidatorSvc(repository, loggerSvc, cacheSvc)
+	validatorSvc := service.NewValidatorSvc(repository, config, loggerSvc, cacheSvc)
 	validatorHandler := handler.NewValidatorHandler(validatorSvc, loggerSvc)
+	delegatorSvc := service.NewDelegatorSvc(repository, config, loggerSvc, cacheSvc)
+	delegatorHandler := handler.NewDelegatorHandler(delegatorSvc, loggerSvc)
 	httpClient := utils.NewDefaultHTTPClient()
-	validatorScheduler := scheduler.NewValidatorScheduler(repository, config, loggerSvc, httpClient, cacheSvc)
-	schedulerHandler := handler.NewSchedulerHandler(validatorScheduler, loggerSvc)
+	objectStorage := utils.NewObjectStorage(config)
+	lockSvc := utils.NewLockSvc(DB, loggerSvc)
+	jobManager := utils.NewJobManager(loggerSvc)
+	validatorScheduler := scheduler.NewValidatorScheduler(repository, config, loggerSvc, httpClient, cacheSvc, objectStorage, lockSvc, jobManager)
+	schedulerHandler := handler.NewSchedulerHandler(validatorScheduler, config, loggerSvc)
+	apiKeySvc := service.NewAPIKeySvc(repository, config, loggerSvc)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeySvc, loggerSvc)
+	healthSvc := service.NewHealthSvc(repository, config, loggerSvc, cacheSvc)
+	healthHandler := handler.NewHealthHandler(healthSvc)
 	recoveryMiddlewareSvc := utils.NewRecoveryMiddlewareSvc(loggerSvc)
-	appApp := app.NewApp(route, config, validatorHandler, schedulerHandler, loggerSvc, recoveryMiddlewareSvc)
+	authMiddlewareSvc := utils.NewAuthMiddlewareSvc(apiKeySvc, loggerSvc)
+	rateLimitMiddlewareSvc := utils.NewRateLimitMiddlewareSvc(config, cacheSvc, loggerSvc)
+	metricsMiddlewareSvc := utils.NewMetricsMiddlewareSvc()
+	tracingMiddlewareSvc := utils.NewTracingMiddlewareSvc()
+	requestIDMiddlewareSvc := utils.NewRequestIDMiddlewareSvc()
+	tracerSvc := utils.NewTracerSvc(config)
+	appApp := app.NewApp(route, config, validatorHandler, delegatorHandler, schedulerHandler, apiKeyHandler, healthHandler, loggerSvc, recoveryMiddlewareSvc, authMiddlewareSvc, rateLimitMiddlewareSvc, metricsMiddlewareSvc, tracingMiddlewareSvc, requestIDMiddlewareSvc, tracerSvc, jobManager, DB, client)
 	return appApp, nil
 }
 
@@ -39,12 +54,32 @@ func InitializeApp(route *chi.Mux, DB utils.PGXPool, config *utils.BaseConfig) (
 
 var validatorHandlerSet = wire.NewSet(querier.NewRepository, handler.NewValidatorHandler, service.NewValidatorSvc)
 
+var delegatorHandlerSet = wire.NewSet(handler.NewDelegatorHandler, service.NewDelegatorSvc)
+
+var healthHandlerSet = wire.NewSet(handler.NewHealthHandler, service.NewHealthSvc)
+
 var loggerSet = wire.NewSet(utils.NewLogger)
 
 var recoveryMiddlewareSet = wire.NewSet(utils.NewRecoveryMiddlewareSvc)
 
+var authMiddlewareSet = wire.NewSet(wire.Bind(new(utils.APIKeyVerifier), new(service.APIKeySvc)), service.NewAPIKeySvc, handler.NewAPIKeyHandler, utils.NewAuthMiddlewareSvc)
+
+var rateLimitMiddlewareSet = wire.NewSet(utils.NewRateLimitMiddlewareSvc)
+
+var metricsMiddlewareSet = wire.NewSet(utils.NewMetricsMiddlewareSvc)
+
+var requestIDMiddlewareSet = wire.NewSet(utils.NewRequestIDMiddlewareSvc)
+
+var tracingSet = wire.NewSet(utils.NewTracerSvc, utils.NewTracingMiddlewareSvc)
+
 var httpClientSet = wire.NewSet(utils.NewDefaultHTTPClient)
 
 var validatorSchedulerSet = wire.NewSet(scheduler.NewValidatorScheduler, handler.NewSchedulerHandler)
 
+var lockSet = wire.NewSet(utils.NewLockSvc)
+
+var jobManagerSet = wire.NewSet(utils.NewJobManager)
+
+var objectStorageSet = wire.NewSet(utils.NewObjectStorage)
+
 var cacheSet = wire.NewSet(wire.Bind(new(utils.RedisClient), new(*redis.Client)), utils.NewRedisClient, utils.NewCacheSvc)